@@ -1,7 +1,15 @@
 package cms
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"time"
 
 	"github.com/gomig/avatar"
 
@@ -9,6 +17,10 @@ import (
 	"github.com/gowool/cms/repository"
 )
 
+const avatarSize = 128
+
+var ErrAvatarInvalid = errors.New("avatar is not a valid image")
+
 type AdminService struct {
 	repo repository.Admin
 }
@@ -25,23 +37,11 @@ func (s *AdminService) Create(ctx context.Context, email, password, issuer strin
 		return model.Admin{}, "", err
 	}
 
-	otp, err := model.NewOTP()
+	admin, err := newAdmin(email, pswd, model.RoleAdmin)
 	if err != nil {
 		return model.Admin{}, "", err
 	}
 
-	male := avatar.NewPersonAvatar(true)
-	male.RandomizeShape(avatar.Circle)
-
-	admin := model.Admin{
-		Avatar:   male.SVG(),
-		Email:    email,
-		Password: pswd,
-		OTP:      otp,
-		Role:     model.RoleAdmin,
-	}
-	admin = admin.WithRandomSalt()
-
 	key, err := admin.OTPKey(issuer)
 	if err != nil {
 		return model.Admin{}, "", err
@@ -53,6 +53,19 @@ func (s *AdminService) Create(ctx context.Context, email, password, issuer strin
 	return admin, key, nil
 }
 
+// Provision creates the admin signing in through an identity provider, the admin has no password.
+func (s *AdminService) Provision(ctx context.Context, email string, role model.Role) (model.Admin, error) {
+	admin, err := newAdmin(email, model.Password{}, role)
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	if err = s.repo.Create(ctx, &admin); err != nil {
+		return model.Admin{}, err
+	}
+	return admin, nil
+}
+
 func (s *AdminService) ChangePassword(ctx context.Context, email, password string) error {
 	admin, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
@@ -67,6 +80,112 @@ func (s *AdminService) ChangePassword(ctx context.Context, email, password strin
 	return s.repo.Update(ctx, &admin)
 }
 
+// ChangeOwnPassword replaces the password of the admin after checking the current one,
+// the signed tokens issued before are invalidated.
+func (s *AdminService) ChangeOwnPassword(ctx context.Context, email, current, password string) error {
+	admin, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if err = admin.ValidatePassword(current); err != nil {
+		return err
+	}
+
+	admin.Password, err = model.NewPassword(password)
+	if err != nil {
+		return err
+	}
+
+	admin = admin.WithRandomSalt()
+	return s.repo.Update(ctx, &admin)
+}
+
+// ChangeAvatar replaces the avatar of the admin by the image cropped to a square,
+// the image is embedded into the svg, so the avatar stays an svg document.
+func (s *AdminService) ChangeAvatar(ctx context.Context, email string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > mediaMaxPixels {
+		return ErrAvatarInvalid
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ErrAvatarInvalid
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, resizeImage(src, MediaOptions{Width: avatarSize, Height: avatarSize, Fit: MediaFitCover})); err != nil {
+		return err
+	}
+
+	admin, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	admin.Avatar = fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d"><image width="%[1]d" height="%[1]d" href="data:image/png;base64,%[2]s"/></svg>`,
+		avatarSize,
+		base64.StdEncoding.EncodeToString(buf.Bytes()),
+	)
+
+	return s.repo.Update(ctx, &admin)
+}
+
+// ResetAvatar replaces the avatar of the admin by a random one.
+func (s *AdminService) ResetAvatar(ctx context.Context, email string) error {
+	admin, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	admin.Avatar = randomAvatar()
+
+	return s.repo.Update(ctx, &admin)
+}
+
+// Disable keeps the admin from signing in without deleting the account.
+func (s *AdminService) Disable(ctx context.Context, id int64) (model.Admin, error) {
+	admin, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return model.Admin{}, err
+	}
+	if admin.Disabled != nil {
+		return admin, nil
+	}
+
+	now := time.Now()
+	admin.Disabled = &now
+
+	if err = s.repo.Update(ctx, &admin); err != nil {
+		return model.Admin{}, err
+	}
+	return admin, nil
+}
+
+func (s *AdminService) Enable(ctx context.Context, id int64) (model.Admin, error) {
+	admin, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return model.Admin{}, err
+	}
+	if admin.Disabled == nil {
+		return admin, nil
+	}
+
+	admin.Disabled = nil
+
+	if err = s.repo.Update(ctx, &admin); err != nil {
+		return model.Admin{}, err
+	}
+	return admin, nil
+}
+
 func (s *AdminService) ChangeRole(ctx context.Context, email string, role model.Role) error {
 	admin, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
@@ -97,3 +216,25 @@ func (s *AdminService) GetOTPKey(ctx context.Context, email, issuer string, newO
 
 	return admin.OTPKey(issuer)
 }
+
+func newAdmin(email string, password model.Password, role model.Role) (model.Admin, error) {
+	otp, err := model.NewOTP()
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	admin := model.Admin{
+		Avatar:   randomAvatar(),
+		Email:    email,
+		Password: password,
+		OTP:      otp,
+		Role:     role,
+	}
+	return admin.WithRandomSalt(), nil
+}
+
+func randomAvatar() string {
+	male := avatar.NewPersonAvatar(true)
+	male.RandomizeShape(avatar.Circle)
+	return male.SVG()
+}
@@ -0,0 +1,171 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	ErrSessionInvalid = errors.New("invalid session")
+	ErrSessionReused  = errors.New("refresh token reused")
+)
+
+// AdminSessionService issues rotating refresh tokens. Presenting a rotated token again
+// revokes its family, since either the token leaked or the client replays it.
+type AdminSessionService struct {
+	repo repository.AdminSession
+
+	// Lifetime limits a session regardless of its activity.
+	Lifetime time.Duration
+	// IdleTimeout ends a session which is not refreshed in time.
+	IdleTimeout time.Duration
+}
+
+func NewAdminSessionService(repo repository.AdminSession, lifetime, idleTimeout time.Duration) *AdminSessionService {
+	if repo == nil {
+		panic("admin session repository is not specified")
+	}
+	return &AdminSessionService{repo: repo, Lifetime: lifetime, IdleTimeout: idleTimeout}
+}
+
+// Start opens a new session of the admin and returns its refresh token.
+func (s *AdminSessionService) Start(ctx context.Context, adminID int64, twoFA bool, ip, userAgent string) (model.AdminSession, string, error) {
+	now := time.Now()
+	if err := s.repo.DeleteExpired(ctx, now); err != nil {
+		return model.AdminSession{}, "", err
+	}
+
+	return s.issue(ctx, model.AdminSession{
+		AdminID:   adminID,
+		FamilyID:  uuid.NewString(),
+		TwoFA:     twoFA,
+		IP:        ip,
+		UserAgent: userAgent,
+		Started:   now,
+		Expires:   now.Add(s.Lifetime),
+	})
+}
+
+// Refresh exchanges the refresh token for the next one of its family.
+func (s *AdminSessionService) Refresh(ctx context.Context, token, ip, userAgent string) (model.AdminSession, string, error) {
+	m, err := s.find(ctx, token)
+	if err != nil {
+		return model.AdminSession{}, "", err
+	}
+
+	now := time.Now()
+	if m.Rotated != nil && m.Revoked == nil && now.Before(m.Expires) {
+		if err = s.Revoke(ctx, m.FamilyID); err != nil {
+			return model.AdminSession{}, "", err
+		}
+		return model.AdminSession{}, "", ErrSessionReused
+	}
+	if !m.IsActive(now, s.IdleTimeout) {
+		return model.AdminSession{}, "", ErrSessionInvalid
+	}
+
+	// a concurrent refresh with the same token rotates it first and forks the family otherwise
+	rotated, err := s.repo.Rotate(ctx, m.ID, now)
+	if err != nil {
+		return model.AdminSession{}, "", err
+	}
+	if !rotated {
+		if err = s.Revoke(ctx, m.FamilyID); err != nil {
+			return model.AdminSession{}, "", err
+		}
+		return model.AdminSession{}, "", ErrSessionReused
+	}
+
+	m.IP, m.UserAgent = ip, userAgent
+	return s.issue(ctx, m)
+}
+
+// Current returns the session of the family when it is neither revoked nor expired.
+func (s *AdminSessionService) Current(ctx context.Context, familyID string) (model.AdminSession, error) {
+	m, err := s.repo.FindCurrent(ctx, familyID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			err = errors.Join(ErrSessionInvalid, err)
+		}
+		return model.AdminSession{}, err
+	}
+	if m.Revoked != nil || !time.Now().Before(m.Expires) {
+		return model.AdminSession{}, ErrSessionInvalid
+	}
+	return m, nil
+}
+
+// Sessions returns the active sessions of the admin.
+func (s *AdminSessionService) Sessions(ctx context.Context, adminID int64) ([]model.AdminSession, error) {
+	sessions, err := s.repo.FindByAdminID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := sessions[:0]
+	for _, m := range sessions {
+		if m.IsActive(now, s.IdleTimeout) {
+			active = append(active, m)
+		}
+	}
+	return active, nil
+}
+
+// Revoke ends the sessions of the families.
+func (s *AdminSessionService) Revoke(ctx context.Context, familyIDs ...string) error {
+	return s.repo.RevokeFamilies(ctx, time.Now(), familyIDs...)
+}
+
+// RevokeToken ends the session the refresh token belongs to, rotated or not.
+func (s *AdminSessionService) RevokeToken(ctx context.Context, token string) error {
+	m, err := s.find(ctx, token)
+	if err != nil {
+		return err
+	}
+	return s.Revoke(ctx, m.FamilyID)
+}
+
+// RevokeAdmin ends every session of the admin.
+func (s *AdminSessionService) RevokeAdmin(ctx context.Context, adminID int64) error {
+	sessions, err := s.repo.FindByAdminID(ctx, adminID)
+	if err != nil {
+		return err
+	}
+
+	familyIDs := make([]string, 0, len(sessions))
+	for _, m := range sessions {
+		familyIDs = append(familyIDs, m.FamilyID)
+	}
+	return s.Revoke(ctx, familyIDs...)
+}
+
+func (s *AdminSessionService) find(ctx context.Context, token string) (model.AdminSession, error) {
+	m, err := s.repo.FindByTokenHash(ctx, hashToken(token))
+	if err != nil && errors.Is(err, repository.ErrNotFound) {
+		err = errors.Join(ErrSessionInvalid, err)
+	}
+	return m, err
+}
+
+func (s *AdminSessionService) issue(ctx context.Context, m model.AdminSession) (model.AdminSession, string, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return model.AdminSession{}, "", err
+	}
+
+	m.ID = 0
+	m.TokenHash = hashToken(token)
+	m.Rotated, m.Revoked = nil, nil
+
+	if err = s.repo.Create(ctx, &m); err != nil {
+		return model.AdminSession{}, "", err
+	}
+	return m, token, nil
+}
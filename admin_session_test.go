@@ -0,0 +1,85 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestAdminSessionService_RefreshRotatesOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAdminSessionRepository()
+	service := cms.NewAdminSessionService(repo, time.Hour, time.Hour)
+
+	session, token, err := service.Start(ctx, 1, true, "127.0.0.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 8
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		refreshed int
+		reused    int
+	)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := service.Refresh(ctx, token, "127.0.0.1", "test")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				refreshed++
+			case errors.Is(err, cms.ErrSessionReused):
+				reused++
+			case errors.Is(err, cms.ErrSessionInvalid):
+				// the family is revoked already
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshed != 1 || reused == 0 {
+		t.Fatalf("refreshed %d, reused %d, want 1 and at least 1", refreshed, reused)
+	}
+
+	// the reuse revokes the whole family, the child issued by the winner included
+	if _, err = service.Current(ctx, session.FamilyID); !errors.Is(err, cms.ErrSessionInvalid) {
+		t.Fatalf("family is not revoked: %v", err)
+	}
+}
+
+func TestAdminSessionService_RefreshReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	service := cms.NewAdminSessionService(memory.NewAdminSessionRepository(), time.Hour, time.Hour)
+
+	session, token, err := service.Start(ctx, 1, false, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, next, err := service.Refresh(ctx, token, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = service.Refresh(ctx, token, "", ""); !errors.Is(err, cms.ErrSessionReused) {
+		t.Fatalf("got %v, want %v", err, cms.ErrSessionReused)
+	}
+	if _, _, err = service.Refresh(ctx, next, "", ""); !errors.Is(err, cms.ErrSessionInvalid) {
+		t.Fatalf("got %v, want %v", err, cms.ErrSessionInvalid)
+	}
+	if _, err = service.Current(ctx, session.FamilyID); !errors.Is(err, cms.ErrSessionInvalid) {
+		t.Fatalf("got %v, want %v", err, cms.ErrSessionInvalid)
+	}
+}
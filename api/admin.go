@@ -12,22 +12,31 @@ import (
 	"github.com/gowool/cms/repository"
 )
 
+type AdminInput struct {
+	ID int64 `path:"id"`
+}
+
 type Admin struct {
 	List[model.Admin]
 	Read[model.Admin, int64]
 
-	path   string
-	pathID string
-	tags   []string
+	service *cms.AdminService
+	path    string
+	pathID  string
+	tags    []string
+
+	// Sessions signs the disabled admins out when set.
+	Sessions *cms.AdminSessionService
 }
 
 func NewAdmin(r repository.Admin, errorTransformer ErrorTransformerFunc) Admin {
 	return Admin{
-		List:   NewList(r.FindAndCount, errorTransformer),
-		Read:   NewRead(r.FindByID, errorTransformer),
-		path:   "/admin",
-		pathID: "/admin/{id}",
-		tags:   []string{"Admin"},
+		List:    NewList(r.FindAndCount, errorTransformer),
+		Read:    NewRead(r.FindByID, errorTransformer),
+		service: cms.NewAdminService(r),
+		path:    "/admin",
+		pathID:  "/admin/{id}",
+		tags:    []string{"Admin"},
 	}
 }
 
@@ -50,6 +59,24 @@ func (r Admin) Register(_ *echo.Echo, humaAPI huma.API) {
 			"target": cms.NewCallTarget(cms.AccessAdmin),
 		},
 	})
+	Register(humaAPI, r.disable, huma.Operation{
+		Summary: "Disable Admin",
+		Method:  http.MethodPost,
+		Path:    r.pathID + "/disable",
+		Tags:    r.tags,
+		Metadata: map[string]any{
+			"target": r.target(),
+		},
+	})
+	Register(humaAPI, r.enable, huma.Operation{
+		Summary: "Enable Admin",
+		Method:  http.MethodPost,
+		Path:    r.pathID + "/enable",
+		Tags:    r.tags,
+		Metadata: map[string]any{
+			"target": r.target(),
+		},
+	})
 	Register(humaAPI, r.me, huma.Operation{
 		Summary: "Me",
 		Method:  http.MethodGet,
@@ -66,3 +93,40 @@ func (r Admin) me(ctx context.Context, _ *struct{}) (*Response[*model.Admin], er
 
 	return &Response[*model.Admin]{Body: admin}, nil
 }
+
+func (r Admin) disable(ctx context.Context, in *AdminInput) (*Response[model.Admin], error) {
+	if admin := cms.CtxAdmin(ctx); admin == nil || admin.ID == in.ID {
+		return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+			Message:  "admin cannot disable itself",
+			Location: "path.id",
+			Value:    in.ID,
+		})
+	}
+
+	admin, err := r.service.Disable(ctx, in.ID)
+	if err != nil {
+		return nil, r.List.ErrorTransformer(ctx, err)
+	}
+
+	if r.Sessions != nil {
+		if err = r.Sessions.RevokeAdmin(ctx, admin.ID); err != nil {
+			return nil, r.List.ErrorTransformer(ctx, err)
+		}
+	}
+	return &Response[model.Admin]{Body: admin}, nil
+}
+
+func (r Admin) enable(ctx context.Context, in *AdminInput) (*Response[model.Admin], error) {
+	admin, err := r.service.Enable(ctx, in.ID)
+	if err != nil {
+		return nil, r.List.ErrorTransformer(ctx, err)
+	}
+	return &Response[model.Admin]{Body: admin}, nil
+}
+
+// target keeps the tokens away from managing the admins.
+func (r Admin) target() *cms.CallTarget {
+	target := cms.NewCallTarget(cms.AccessAdmin)
+	delete(target.Access, cms.TokenScheme)
+	return target
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type APITokenBody struct {
+	AdminID   int64      `json:"admin_id" yaml:"admin_id" required:"true"`
+	Name      string     `json:"name" yaml:"name" required:"true" minLength:"1"`
+	Role      string     `json:"role" yaml:"role" required:"true" enum:"reader,writer,admin" doc:"The highest role the token acts with, the role of the admin still applies."`
+	Resources []string   `json:"resources,omitempty" yaml:"resources,omitempty" required:"false" enum:"page,site,template,menu,node,block,redirect,media" uniqueItems:"true" doc:"Restricts the token to the resources, an empty list allows every call."`
+	Expires   *time.Time `json:"expires,omitempty" yaml:"expires,omitempty" required:"false"`
+}
+
+func (dto APITokenBody) Decode(m *model.APIToken) {
+	m.AdminID = dto.AdminID
+	m.Name = dto.Name
+	m.Role = model.NewRole(dto.Role)
+	m.Resources = dto.Resources
+	m.Expires = dto.Expires
+}
+
+type APITokenIssueResponse struct {
+	Location string `header:"Content-Location"`
+	Body     struct {
+		Token    string         `json:"token" yaml:"token" required:"true" doc:"The secret of the token, it is shown only once."`
+		APIToken model.APIToken `json:"api_token" yaml:"api_token" required:"true"`
+	}
+}
+
+type APIToken struct {
+	List[model.APIToken]
+	Read[model.APIToken, int64]
+	Delete[int64]
+	DeleteMany[int64]
+
+	Service *cms.APITokenService
+	path    string
+	pathID  string
+	tags    []string
+}
+
+func NewAPIToken(repo repository.APIToken, service *cms.APITokenService, errorTransformer ErrorTransformerFunc) APIToken {
+	return APIToken{
+		List:       NewList(repo.FindAndCount, errorTransformer),
+		Read:       NewRead(repo.FindByID, errorTransformer),
+		Delete:     NewDelete(service.Revoke, errorTransformer),
+		DeleteMany: NewDeleteMany(service.Revoke, errorTransformer),
+		Service:    service,
+		path:       "/api-tokens",
+		pathID:     "/api-tokens/{id}",
+		tags:       []string{"API Token"},
+	}
+}
+
+func (h APIToken) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List.Handler, huma.Operation{
+		Summary: "Get API Tokens",
+		Method:  http.MethodGet,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Read.Handler, huma.Operation{
+		Summary: "Get API Token",
+		Method:  http.MethodGet,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Issue, huma.Operation{
+		Summary:       "Issue API Token",
+		DefaultStatus: http.StatusCreated,
+		Method:        http.MethodPost,
+		Path:          h.path,
+		Tags:          h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.DeleteMany.Handler, huma.Operation{
+		Summary: "Revoke API Tokens",
+		Method:  http.MethodDelete,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Delete.Handler, huma.Operation{
+		Summary: "Revoke API Token",
+		Method:  http.MethodDelete,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+}
+
+func (h APIToken) Issue(ctx context.Context, in *CreateInput[APITokenBody]) (*APITokenIssueResponse, error) {
+	var m model.APIToken
+	in.Body.Decode(&m)
+
+	token, err := h.Service.Issue(ctx, &m)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  "admin not found",
+				Location: "body.admin_id",
+				Value:    in.Body.AdminID,
+			})
+		}
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+
+	out := &APITokenIssueResponse{Location: Location(h.pathID, m.ID).Location}
+	out.Body.Token = token
+	out.Body.APIToken = m
+	return out, nil
+}
+
+// target keeps the tokens away from managing the tokens.
+func (h APIToken) target() *cms.CallTarget {
+	target := cms.NewCallTarget(cms.AccessAdmin)
+	delete(target.Access, cms.TokenScheme)
+	return target
+}
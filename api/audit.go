@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type AuditConfig struct {
+	Skipper     func(huma.Context) bool
+	IPExtractor echo.IPExtractor
+}
+
+// Audit marks the calls of mutating operations to be recorded by the audit repositories.
+func Audit(cfg AuditConfig) func(huma.API) func(huma.Context, func(huma.Context)) {
+	if cfg.IPExtractor == nil {
+		cfg.IPExtractor = echo.ExtractIPDirect()
+	}
+
+	if cfg.Skipper == nil {
+		cfg.Skipper = func(c huma.Context) bool {
+			switch c.Method() {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return true
+			default:
+				return false
+			}
+		}
+	}
+
+	return func(huma.API) func(huma.Context, func(huma.Context)) {
+		return func(c huma.Context, next func(huma.Context)) {
+			if cfg.Skipper(c) {
+				next(c)
+				return
+			}
+
+			// IPExtractor needs only the remote address and the headers of the request
+			r := &http.Request{RemoteAddr: c.RemoteAddr(), Header: http.Header{}}
+			c.EachHeader(r.Header.Add)
+
+			entry := model.AuditLog{
+				OperationID: c.Operation().OperationID,
+				IP:          cfg.IPExtractor(r),
+			}
+			if target, ok := c.Operation().Metadata["target"].(*cms.CallTarget); ok && target.OperationID != "" {
+				entry.OperationID = target.OperationID
+			}
+			if claims := cms.CtxClaims(c.Context()); claims.Subject != nil {
+				entry.AdminID = &claims.Subject.ID
+				entry.AdminEmail = claims.Subject.Email
+			}
+
+			next(huma.WithContext(c, cms.WithAudit(c.Context(), entry)))
+		}
+	}
+}
+
+type AuditLog struct {
+	List[model.AuditLog]
+	Read[model.AuditLog, int64]
+
+	path   string
+	pathID string
+	tags   []string
+}
+
+func NewAuditLog(repo repository.AuditLog, errorTransformer ErrorTransformerFunc) AuditLog {
+	return AuditLog{
+		List:   NewList(repo.FindAndCount, errorTransformer),
+		Read:   NewRead(repo.FindByID, errorTransformer),
+		path:   "/audit-log",
+		pathID: "/audit-log/{id}",
+		tags:   []string{"Audit"},
+	}
+}
+
+func (h AuditLog) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List.Handler, huma.Operation{
+		Summary: "Get Audit Log",
+		Method:  http.MethodGet,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.Read.Handler, huma.Operation{
+		Summary: "Get Audit Log Entry",
+		Method:  http.MethodGet,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+}
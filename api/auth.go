@@ -3,14 +3,12 @@ package api
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"reflect"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 
@@ -19,9 +17,20 @@ import (
 	"github.com/gowool/cms/repository"
 )
 
-const prefixRefreshToken = "token:refresh:"
+// Client carries the address and the agent of the caller into the handlers.
+type Client struct {
+	request *http.Request
+}
+
+func (c *Client) Resolve(ctx huma.Context) []error {
+	// IPExtractor needs only the remote address and the headers of the request
+	c.request = &http.Request{RemoteAddr: ctx.RemoteAddr(), Header: http.Header{}}
+	ctx.EachHeader(c.request.Header.Add)
+	return nil
+}
 
 type SignIn struct {
+	Client
 	Body struct {
 		Email    string `json:"email,omitempty" required:"true" minLength:"3" maxLength:"254" format:"email"`
 		Password string `json:"password,omitempty" required:"true" minLength:"8" maxLength:"64"`
@@ -29,12 +38,14 @@ type SignIn struct {
 }
 
 type OTP struct {
+	Client
 	Body struct {
 		Password string `json:"password,omitempty" required:"true" minLength:"6" maxLength:"6" pattern:"[0-9]+"`
 	}
 }
 
 type RefreshToken struct {
+	Client
 	Body struct {
 		RefreshToken string `json:"refresh_token,omitempty" required:"true"`
 	}
@@ -45,23 +56,41 @@ type Session struct {
 	RefreshToken string `json:"refresh_token" required:"true"`
 }
 
-type cacheItem struct {
-	ID    int64 `json:"id"`
-	TwoFA bool  `json:"two_fa"`
+type AdminSessionsInput struct {
+	AdminID int64 `path:"id"`
+}
+
+type AdminSessionInput struct {
+	AdminID  int64  `path:"id"`
+	FamilyID string `path:"family_id"`
 }
 
 type Auth struct {
 	logger      *zap.Logger
 	repo        repository.Admin
-	cache       cms.Cache
+	sessions    *cms.AdminSessionService
 	tokenExpiry time.Duration
 	secret      string
 	tags        []string
+
+	IPExtractor echo.IPExtractor
+	// Throttle slows down and locks out the failed sign-ins when set.
+	Throttle *cms.Throttle
+	// AuditLog records the failed sign-ins and the lockouts when set.
+	AuditLog repository.AuditLog
+	// WebAuthn enables the passkeys and the security keys as the second factor when set.
+	WebAuthn *cms.WebAuthnService
+	// RecoveryCodes enables the recovery codes as the second factor when set.
+	RecoveryCodes *cms.RecoveryCodeService
+	// OIDC enables the sign-in through an OpenID Connect provider when set.
+	OIDC *cms.OIDCService
+	// PasswordReset enables the reset of the forgotten passwords by mail when set.
+	PasswordReset *cms.PasswordResetService
 }
 
 func NewAuth(
 	repo repository.Admin,
-	cache cms.Cache,
+	sessions *cms.AdminSessionService,
 	secret string,
 	tokenExpiry time.Duration,
 	logger *zap.Logger,
@@ -69,10 +98,11 @@ func NewAuth(
 	return Auth{
 		logger:      logger.Named("auth"),
 		repo:        repo,
-		cache:       cache,
+		sessions:    sessions,
 		secret:      secret,
 		tokenExpiry: tokenExpiry,
 		tags:        []string{"Auth"},
+		IPExtractor: echo.ExtractIPDirect(),
 	}
 }
 
@@ -99,8 +129,8 @@ func (r Auth) Register(_ *echo.Echo, humaAPI huma.API) {
 		Metadata: map[string]any{
 			"target": &cms.CallTarget{
 				Access: map[cms.AuthScheme]cms.Decider{
-					cms.BasicScheme: cms.NewDecider(cms.AccessPrivate, false),
-					cms.JWTScheme:   cms.NewDecider(cms.AccessPrivate, false),
+					cms.BasicScheme: cms.NewDecider(cms.AccessSelf, false),
+					cms.JWTScheme:   cms.NewDecider(cms.AccessSelf, false),
 				},
 			},
 		},
@@ -119,19 +149,89 @@ func (r Auth) Register(_ *echo.Echo, humaAPI huma.API) {
 			},
 		},
 	})
+	Register(humaAPI, r.signOut, huma.Operation{
+		Summary:  "Sign Out",
+		Method:   http.MethodPost,
+		Path:     "/auth/sign-out",
+		Tags:     r.tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{
+			"target": &cms.CallTarget{
+				Access: map[cms.AuthScheme]cms.Decider{
+					cms.UnknownScheme: cms.NewDecider(cms.AccessPublic, false),
+					cms.BasicScheme:   cms.NewDecider(cms.AccessPublic, false),
+					cms.JWTScheme:     cms.NewDecider(cms.AccessPublic, false),
+				},
+			},
+		},
+	})
+	if r.OIDC != nil {
+		r.registerOIDC(humaAPI)
+	}
+	if r.PasswordReset != nil {
+		r.registerPasswordReset(humaAPI)
+	}
+	if r.WebAuthn != nil {
+		r.registerWebAuthn(humaAPI)
+	}
+	if r.RecoveryCodes != nil {
+		r.registerRecoveryCodes(humaAPI)
+	}
+	Register(humaAPI, r.listSessions, huma.Operation{
+		Summary: "Get Admin Sessions",
+		Method:  http.MethodGet,
+		Path:    "/admin/{id}/sessions",
+		Tags:    r.tags,
+		Metadata: map[string]any{
+			"target": r.sessionsTarget(),
+		},
+	})
+	Register(humaAPI, r.revokeSessions, huma.Operation{
+		Summary: "Revoke Admin Sessions",
+		Method:  http.MethodDelete,
+		Path:    "/admin/{id}/sessions",
+		Tags:    r.tags,
+		Metadata: map[string]any{
+			"target": r.sessionsTarget(),
+		},
+	})
+	Register(humaAPI, r.revokeSession, huma.Operation{
+		Summary: "Revoke Admin Session",
+		Method:  http.MethodDelete,
+		Path:    "/admin/{id}/sessions/{family_id}",
+		Tags:    r.tags,
+		Metadata: map[string]any{
+			"target": r.sessionsTarget(),
+		},
+	})
 }
 
 func (r Auth) signIn(ctx context.Context, in *SignIn) (*Response[Session], error) {
-	admin, err := r.repo.FindByEmail(ctx, in.Body.Email)
-	if err != nil {
-		return nil, r.error(err)
+	ip := r.IPExtractor(in.request)
+	keys := []string{cms.ThrottleEmailKey(in.Body.Email), cms.ThrottleIPKey(ip)}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
 	}
+	defer r.done(ctx, keys...)
 
-	if err = admin.ValidatePassword(in.Body.Password); err != nil {
+	admin, err := r.repo.FindByEmail(ctx, in.Body.Email)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return nil, r.error(err)
 	}
+	if err == nil {
+		err = admin.ValidatePassword(in.Body.Password)
+	}
+	if err != nil {
+		return nil, r.fail(ctx, err, in.Body.Email, ip, keys...)
+	}
+
+	// the address keeps its failures, an account of the attacker must not clear them
+	r.reset(ctx, cms.ThrottleEmailKey(in.Body.Email))
 
-	return r.session(ctx, admin, false)
+	if admin.IsDisabled() {
+		return nil, r.error(model.ErrAdminDisabled)
+	}
+	return r.start(ctx, admin, false, in.Client)
 }
 
 func (r Auth) otp(ctx context.Context, in *OTP) (*Response[Session], error) {
@@ -140,34 +240,97 @@ func (r Auth) otp(ctx context.Context, in *OTP) (*Response[Session], error) {
 		return nil, r.error(errors.New("invalid context, admin not found"))
 	}
 
+	ip := r.IPExtractor(in.request)
+	keys := []string{cms.ThrottleEmailKey(admin.Email), cms.ThrottleIPKey(ip)}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
+	}
+	defer r.done(ctx, keys...)
+
 	if err := admin.ValidateOTP(in.Body.Password); err != nil {
-		return nil, r.error(err)
+		return nil, r.fail(ctx, err, admin.Email, ip, keys...)
 	}
+	r.reset(ctx, cms.ThrottleEmailKey(admin.Email))
 
-	return r.session(ctx, *admin, true)
+	return r.secondFactor(ctx, *admin, in.Client)
 }
 
 func (r Auth) refreshToken(ctx context.Context, in *RefreshToken) (*Response[Session], error) {
-	key := prefixRefreshToken + in.Body.RefreshToken
-	var item cacheItem
-	if err := r.cache.Get(ctx, key, &item); err != nil {
+	session, refreshToken, err := r.sessions.Refresh(ctx, in.Body.RefreshToken, r.IPExtractor(in.request), in.request.UserAgent())
+	if err != nil {
 		return nil, r.error(err)
 	}
 
-	admin, err := r.repo.FindByID(ctx, item.ID)
+	admin, err := r.repo.FindByID(ctx, session.AdminID)
 	if err != nil {
 		return nil, r.error(err)
 	}
+	if admin.IsDisabled() {
+		return nil, r.error(model.ErrAdminDisabled)
+	}
 
-	return r.session(ctx, admin, item.TwoFA)
+	return r.session(admin, session, refreshToken)
 }
 
-func (r Auth) session(ctx context.Context, admin model.Admin, twoFA bool) (*Response[Session], error) {
-	tag := fmt.Sprintf("admin:tag:%d", admin.ID)
-	_ = r.cache.DelByTag(ctx, tag)
+func (r Auth) signOut(ctx context.Context, in *RefreshToken) (*struct{}, error) {
+	if err := r.sessions.RevokeToken(ctx, in.Body.RefreshToken); err != nil && !errors.Is(err, cms.ErrSessionInvalid) {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
 
+func (r Auth) listSessions(ctx context.Context, in *AdminSessionsInput) (*Response[[]model.AdminSession], error) {
+	sessions, err := r.sessions.Sessions(ctx, in.AdminID)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &Response[[]model.AdminSession]{Body: sessions}, nil
+}
+
+func (r Auth) revokeSessions(ctx context.Context, in *AdminSessionsInput) (*struct{}, error) {
+	if err := r.sessions.RevokeAdmin(ctx, in.AdminID); err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+func (r Auth) revokeSession(ctx context.Context, in *AdminSessionInput) (*struct{}, error) {
+	session, err := r.sessions.Current(ctx, in.FamilyID)
+	if err != nil || session.AdminID != in.AdminID {
+		return nil, huma.Error404NotFound("session not found")
+	}
+	if err = r.sessions.Revoke(ctx, in.FamilyID); err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+// secondFactor replaces the session signed in with the password by the two-factor one.
+func (r Auth) secondFactor(ctx context.Context, admin model.Admin, client Client) (*Response[Session], error) {
+	if familyID, ok := cms.CtxClaims(ctx).Metadata[cms.ClaimSessionID].(string); ok {
+		if err := r.sessions.Revoke(ctx, familyID); err != nil {
+			return nil, r.error(err)
+		}
+	}
+	return r.start(ctx, admin, true, client)
+}
+
+func (r Auth) start(ctx context.Context, admin model.Admin, twoFA bool, client Client) (*Response[Session], error) {
+	session, refreshToken, err := r.sessions.Start(ctx, admin.ID, twoFA, r.IPExtractor(client.request), client.request.UserAgent())
+	if err != nil {
+		return nil, r.error(err)
+	}
+	return r.session(admin, session, refreshToken)
+}
+
+func (r Auth) session(admin model.Admin, session model.AdminSession, refreshToken string) (*Response[Session], error) {
 	accessToken, err := cms.NewJWT(
-		jwt.MapClaims{"sub": admin.Email, "model": reflect.TypeOf(admin).Name(), "2fa": twoFA},
+		jwt.MapClaims{
+			"sub":              admin.Email,
+			"model":            reflect.TypeOf(admin).Name(),
+			"2fa":              session.TwoFA,
+			cms.ClaimSessionID: session.FamilyID,
+		},
 		admin.Salt+r.secret,
 		r.tokenExpiry,
 	)
@@ -175,11 +338,6 @@ func (r Auth) session(ctx context.Context, admin model.Admin, twoFA bool) (*Resp
 		return nil, r.error(err)
 	}
 
-	refreshToken := uuid.NewString()
-	if err = r.cache.Set(ctx, prefixRefreshToken+refreshToken, &cacheItem{ID: admin.ID, TwoFA: twoFA}, tag); err != nil {
-		return nil, r.error(err)
-	}
-
 	return &Response[Session]{
 		Body: Session{
 			AccessToken:  accessToken,
@@ -188,6 +346,91 @@ func (r Auth) session(ctx context.Context, admin model.Admin, twoFA bool) (*Resp
 	}, nil
 }
 
+// factorTarget allows the signed-in admins of any role, twoFA requires the second factor from the jwt sessions.
+func factorTarget(twoFA bool) *cms.CallTarget {
+	return &cms.CallTarget{
+		Access: map[cms.AuthScheme]cms.Decider{
+			cms.BasicScheme: cms.NewDecider(cms.AccessSelf, false),
+			cms.JWTScheme:   cms.NewDecider(cms.AccessSelf, twoFA),
+		},
+	}
+}
+
+// sessionsTarget keeps the api tokens away from the sessions.
+func (r Auth) sessionsTarget() *cms.CallTarget {
+	target := cms.NewCallTarget(cms.AccessAdmin)
+	delete(target.Access, cms.TokenScheme)
+	return target
+}
+
+func (r Auth) allow(ctx context.Context, keys ...string) error {
+	if r.Throttle == nil {
+		return nil
+	}
+	if err := r.Throttle.Allow(ctx, keys...); err != nil {
+		return ErrorTransformer(ctx, err)
+	}
+	return nil
+}
+
+// fail counts the failed attempt against the keys and hides its reason from the caller.
+func (r Auth) fail(ctx context.Context, err error, email, ip string, keys ...string) error {
+	r.audit(ctx, model.AuditLoginFailed, email, ip)
+
+	if r.Throttle != nil {
+		locked, err := r.Throttle.Fail(ctx, keys...)
+		if err != nil {
+			r.logger.Error("failed to count the attempt", zap.Error(err))
+		}
+		if locked {
+			r.audit(ctx, model.AuditLockout, email, ip)
+		}
+	}
+	return r.error(err)
+}
+
+// count counts the attempt against the keys as a failed one, for the attempts which are throttled
+// whether they succeed or not.
+func (r Auth) count(ctx context.Context, keys ...string) {
+	if r.Throttle == nil {
+		return
+	}
+	if _, err := r.Throttle.Fail(ctx, keys...); err != nil {
+		r.logger.Error("failed to count the attempt", zap.Error(err))
+	}
+}
+
+func (r Auth) done(ctx context.Context, keys ...string) {
+	if r.Throttle != nil {
+		r.Throttle.Done(ctx, keys...)
+	}
+}
+
+func (r Auth) reset(ctx context.Context, keys ...string) {
+	if r.Throttle == nil {
+		return
+	}
+	if err := r.Throttle.Reset(ctx, keys...); err != nil {
+		r.logger.Error("failed to reset the attempts", zap.Error(err))
+	}
+}
+
+func (r Auth) audit(ctx context.Context, action model.AuditAction, email, ip string) {
+	if r.AuditLog == nil {
+		return
+	}
+
+	entry, _ := cms.CtxAudit(ctx)
+	entry.Action = action
+	entry.TargetType = "auth"
+	entry.TargetID = email
+	entry.IP = ip
+
+	if err := r.AuditLog.Create(ctx, &entry); err != nil {
+		r.logger.Error("failed to record the attempt", zap.String("action", string(action)), zap.Error(err))
+	}
+}
+
 func (r Auth) error(err error) error {
 	r.logger.Error("login failed", zap.Error(err))
 	return huma.Error400BadRequest("Login failed, please try again")
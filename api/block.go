@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type BlockBody struct {
+	SiteID   *int64         `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID   *int64         `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false"`
+	ParentID *int64         `json:"parent_id,omitempty" yaml:"parent_id,omitempty" required:"false"`
+	Region   string         `json:"region,omitempty" yaml:"region,omitempty" required:"true" minLength:"1"`
+	Type     string         `json:"type,omitempty" yaml:"type,omitempty" required:"true" minLength:"1"`
+	Name     string         `json:"name,omitempty" yaml:"name,omitempty" required:"false"`
+	Settings map[string]any `json:"settings,omitempty" yaml:"settings,omitempty" required:"false"`
+	Position int            `json:"position,omitempty" yaml:"position,omitempty" required:"false"`
+	Shared   bool           `json:"shared,omitempty" yaml:"shared,omitempty" required:"false"`
+	Enabled  bool           `json:"enabled,omitempty" yaml:"enabled,omitempty" required:"false"`
+}
+
+func (dto *BlockBody) Resolve(_ huma.Context, prefix *huma.PathBuffer) []error {
+	if dto.Shared && dto.PageID != nil {
+		return []error{&huma.ErrorDetail{
+			Message:  "shared block cannot belong to a page",
+			Location: prefix.With("page_id"),
+			Value:    *dto.PageID,
+		}}
+	}
+	if !dto.Shared && dto.PageID == nil {
+		return []error{&huma.ErrorDetail{
+			Message:  "page_id is required unless the block is shared",
+			Location: prefix.With("page_id"),
+		}}
+	}
+	return nil
+}
+
+func (dto BlockBody) Decode(m *model.Block) {
+	m.SiteID = dto.SiteID
+	m.PageID = dto.PageID
+	m.ParentID = dto.ParentID
+	m.Region = dto.Region
+	m.Type = dto.Type
+	m.Name = dto.Name
+	m.Settings = dto.Settings
+	m.Position = dto.Position
+	m.Shared = dto.Shared
+	m.Enabled = dto.Enabled
+}
+
+type BlockType struct {
+	Name     string       `json:"name" yaml:"name" required:"true"`
+	Label    string       `json:"label,omitempty" yaml:"label,omitempty" required:"false"`
+	Template string       `json:"template" yaml:"template" required:"true"`
+	Schema   *huma.Schema `json:"schema,omitempty" yaml:"schema,omitempty" required:"false"`
+}
+
+type Block struct {
+	CRUD[BlockBody, model.Block, int64]
+	Types    cms.BlockTypes
+	Registry huma.Registry
+}
+
+func NewBlock(repo repository.Block, types cms.BlockTypes, errorTransformer ErrorTransformerFunc) Block {
+	h := Block{
+		CRUD:     NewCRUD[BlockBody](repo, errorTransformer, "/blocks", "Block", "Blocks", "Block"),
+		Types:    types,
+		Registry: huma.NewMapRegistry("#/components/schemas/", huma.DefaultSchemaNamer),
+	}
+	h.Create.Saver = h.validate(repo.Create)
+	h.Update.Saver = h.validate(repo.Update)
+	return h
+}
+
+func (h Block) Register(e *echo.Echo, api huma.API) {
+	h.CRUD.Register(e, api)
+
+	Register(api, h.ListTypes, huma.Operation{
+		Summary: "Get Block Types",
+		Method:  http.MethodGet,
+		Path:    "/block-types",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": h.target(cms.AccessRead),
+		},
+	})
+}
+
+func (h Block) ListTypes(context.Context, *struct{}) (*Response[[]BlockType], error) {
+	types := h.Types.All()
+
+	body := make([]BlockType, 0, len(types))
+	for _, t := range types {
+		body = append(body, BlockType{
+			Name:     t.Name,
+			Label:    t.Label,
+			Template: t.Template,
+			Schema:   h.schema(t),
+		})
+	}
+	return &Response[[]BlockType]{Body: body}, nil
+}
+
+// validate checks the settings of the block against the schema of its type.
+func (h Block) validate(saver func(context.Context, *model.Block) error) func(context.Context, *model.Block) error {
+	return func(ctx context.Context, m *model.Block) error {
+		t, ok := h.Types.Get(m.Type)
+		if !ok {
+			return huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  "unknown block type",
+				Location: "body.type",
+				Value:    m.Type,
+			})
+		}
+
+		if schema := h.schema(t); schema != nil {
+			settings := m.Settings
+			if settings == nil {
+				settings = map[string]any{}
+			}
+
+			pb := huma.NewPathBuffer([]byte{}, 0)
+			pb.Push("body")
+			pb.Push("settings")
+
+			res := &huma.ValidateResult{}
+			huma.Validate(h.Registry, schema, pb, huma.ModeWriteToServer, settings, res)
+			if len(res.Errors) > 0 {
+				return huma.Error422UnprocessableEntity("validation failed", res.Errors...)
+			}
+		}
+
+		return saver(ctx, m)
+	}
+}
+
+func (h Block) schema(t cms.BlockType) *huma.Schema {
+	if t.Settings == nil {
+		return nil
+	}
+	return huma.SchemaFromType(h.Registry, reflect.TypeOf(t.Settings))
+}
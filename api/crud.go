@@ -13,6 +13,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
 	"github.com/gowool/cms/repository"
 )
 
@@ -207,6 +208,14 @@ func (h DeleteMany[ID]) Handler(ctx context.Context, in *IDsInput[ID]) (*struct{
 }
 
 func ErrorTransformer(_ context.Context, err error) error {
+	var throttledErr *cms.ThrottledError
+	if errors.As(err, &throttledErr) {
+		return huma.ErrorWithHeaders(
+			huma.Error429TooManyRequests("Too Many Requests", err),
+			http.Header{"Retry-After": {throttledErr.RetryAfterHeader()}},
+		)
+	}
+
 	var statusErr huma.StatusError
 	if errors.As(err, &statusErr) {
 		return statusErr
@@ -258,6 +267,10 @@ type CRUD[B interface{ Decode(*M) }, M interface{ GetID() ID }, ID any] struct {
 	LabelSingular string
 	LabelPlural   string
 	Tags          []string
+
+	// Permissions restricts the handlers to the records the caller is granted access to,
+	// it applies to the models implementing model.Scoped.
+	Permissions cms.Permissions
 }
 
 func NewCRUD[B interface{ Decode(*M) }, M interface{ GetID() ID }, ID any](
@@ -283,13 +296,15 @@ func NewCRUD[B interface{ Decode(*M) }, M interface{ GetID() ID }, ID any](
 }
 
 func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
+	h = h.restricted()
+
 	Register(api, h.List.Handler, huma.Operation{
 		Summary: "Get " + h.LabelPlural,
 		Method:  http.MethodGet,
 		Path:    h.Path,
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessRead),
+			"target": h.target(cms.AccessRead),
 		},
 	})
 	Register(api, h.Read.Handler, huma.Operation{
@@ -298,7 +313,7 @@ func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
 		Path:    h.PathID,
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessRead),
+			"target": h.target(cms.AccessRead),
 		},
 	})
 	Register(api, h.DeleteMany.Handler, huma.Operation{
@@ -307,7 +322,7 @@ func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
 		Path:    h.Path,
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessWrite),
+			"target": h.target(cms.AccessWrite),
 		},
 	})
 	Register(api, h.Delete.Handler, huma.Operation{
@@ -316,7 +331,7 @@ func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
 		Path:    h.PathID,
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessWrite),
+			"target": h.target(cms.AccessWrite),
 		},
 	})
 	Register(api, h.Create.Handler, huma.Operation{
@@ -326,7 +341,7 @@ func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
 		Path:          h.Path,
 		Tags:          h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessWrite),
+			"target": h.target(cms.AccessWrite),
 		},
 	})
 	Register(api, h.Update.Handler, huma.Operation{
@@ -335,7 +350,105 @@ func (h CRUD[B, M, ID]) Register(_ *echo.Echo, api huma.API) {
 		Path:    h.PathID,
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessWrite),
+			"target": h.target(cms.AccessWrite),
 		},
 	})
 }
+
+func (h CRUD[B, M, ID]) resource() (string, bool) {
+	var m M
+	if scoped, ok := any(m).(model.Scoped); ok {
+		return scoped.Scope().Resource, true
+	}
+	return "", false
+}
+
+func (h CRUD[B, M, ID]) target(access cms.Access) *cms.CallTarget {
+	resource, ok := h.resource()
+	switch {
+	case !ok:
+		return cms.NewCallTarget(access)
+	case h.Permissions != nil:
+		return cms.NewResourceCallTarget(resource, access, h.Permissions)
+	default:
+		return cms.NewScopedCallTarget(resource, access)
+	}
+}
+
+// restricted wraps the handlers with the permission checks of the records.
+func (h CRUD[B, M, ID]) restricted() CRUD[B, M, ID] {
+	resource, ok := h.resource()
+	if !ok || h.Permissions == nil {
+		return h
+	}
+
+	guard := func(ctx context.Context, m M, access cms.Access) error {
+		allowed, err := h.Permissions.Allowed(ctx, cms.CtxClaims(ctx).Subject, any(m).(model.Scoped).Scope(), access)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return huma.Error403Forbidden("Forbidden")
+		}
+		return nil
+	}
+
+	finder := func(access cms.Access) func(context.Context, ID) (M, error) {
+		find := h.Read.Finder
+		return func(ctx context.Context, id ID) (M, error) {
+			m, err := find(ctx, id)
+			if err != nil {
+				return m, err
+			}
+			return m, guard(ctx, m, access)
+		}
+	}
+
+	deleter := func(del func(context.Context, ...ID) error) func(context.Context, ...ID) error {
+		find := h.Read.Finder
+		return func(ctx context.Context, ids ...ID) error {
+			for _, id := range ids {
+				m, err := find(ctx, id)
+				if err != nil {
+					if errors.Is(err, repository.ErrNotFound) {
+						continue
+					}
+					return err
+				}
+				if err = guard(ctx, m, cms.AccessWrite); err != nil {
+					return err
+				}
+			}
+			return del(ctx, ids...)
+		}
+	}
+
+	saver := func(save func(context.Context, *M) error) func(context.Context, *M) error {
+		return func(ctx context.Context, m *M) error {
+			if err := guard(ctx, *m, cms.AccessWrite); err != nil {
+				return err
+			}
+			return save(ctx, m)
+		}
+	}
+
+	list := h.List.Finder
+	h.List.Finder = func(ctx context.Context, criteria *cr.Criteria) ([]M, int, error) {
+		v, err := h.Permissions.Visibility(ctx, cms.CtxClaims(ctx).Subject, resource, cms.AccessRead)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !v.All {
+			criteria.Filter = cr.Filter{Conditions: []any{criteria.Filter, v.Filter(resource)}}
+		}
+		return list(ctx, criteria)
+	}
+
+	h.Update.Finder = finder(cms.AccessWrite)
+	h.Update.Saver = saver(h.Update.Saver)
+	h.Create.Saver = saver(h.Create.Saver)
+	h.Delete.Deleter = deleter(h.Delete.Deleter)
+	h.DeleteMany.Deleter = deleter(h.DeleteMany.Deleter)
+	h.Read.Finder = finder(cms.AccessRead)
+	return h
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type PageDraftInput struct {
+	ID   int64 `path:"id"`
+	Body PageBody
+}
+
+type PreviewToken struct {
+	Token   string    `json:"token" yaml:"token" required:"true"`
+	URL     string    `json:"url" yaml:"url" required:"true"`
+	Expires time.Time `json:"expires" yaml:"expires" required:"true"`
+}
+
+type PageDraft struct {
+	DraftRepository  repository.PageDraft
+	PageRepository   repository.Page
+	ErrorTransformer ErrorTransformerFunc
+	PreviewSecret    string
+	PreviewDuration  time.Duration
+	PreviewParam     string
+	Path             string
+	Tags             []string
+}
+
+func NewPageDraft(
+	draftRepo repository.PageDraft,
+	pageRepo repository.Page,
+	previewSecret string,
+	previewDuration time.Duration,
+	errorTransformer ErrorTransformerFunc,
+) PageDraft {
+	return PageDraft{
+		DraftRepository:  draftRepo,
+		PageRepository:   pageRepo,
+		ErrorTransformer: errorTransformer,
+		PreviewSecret:    previewSecret,
+		PreviewDuration:  previewDuration,
+		PreviewParam:     "preview",
+		Path:             "/pages/{id}/draft",
+		Tags:             []string{"Page"},
+	}
+}
+
+func (h PageDraft) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.Read, huma.Operation{
+		Summary: "Get Page Draft",
+		Method:  http.MethodGet,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessRead),
+		},
+	})
+	Register(api, h.Save, huma.Operation{
+		Summary: "Save Page Draft",
+		Method:  http.MethodPut,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+	Register(api, h.Delete, huma.Operation{
+		Summary: "Discard Page Draft",
+		Method:  http.MethodDelete,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+	Register(api, h.Publish, huma.Operation{
+		Summary: "Publish Page Draft",
+		Method:  http.MethodPost,
+		Path:    h.Path + "/publish",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+	Register(api, h.Preview, huma.Operation{
+		Summary: "Create Page Preview Token",
+		Method:  http.MethodPost,
+		Path:    h.Path + "/preview",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+}
+
+func (h PageDraft) Read(ctx context.Context, in *IDInput[int64]) (*Response[model.PageDraft], error) {
+	draft, err := h.DraftRepository.FindByPageID(ctx, in.ID)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[model.PageDraft]{Body: draft}, nil
+}
+
+func (h PageDraft) Save(ctx context.Context, in *PageDraftInput) (*Response[model.PageDraft], error) {
+	page, err := h.PageRepository.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	draft, err := h.DraftRepository.FindByPageID(ctx, in.ID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	exists := err == nil
+	if !exists {
+		draft = model.PageDraft{PageID: page.ID, Page: page}
+	}
+
+	in.Body.Decode(&draft.Page)
+	draft.Page.ID = page.ID
+	draft.Page.URL = page.URL
+
+	if admin := cms.CtxAdmin(ctx); admin != nil {
+		draft.AdminID = &admin.ID
+	}
+
+	if exists {
+		err = h.DraftRepository.Update(ctx, &draft)
+	} else {
+		err = h.DraftRepository.Create(ctx, &draft)
+	}
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[model.PageDraft]{Body: draft}, nil
+}
+
+func (h PageDraft) Delete(ctx context.Context, in *IDInput[int64]) (*struct{}, error) {
+	draft, err := h.DraftRepository.FindByPageID(ctx, in.ID)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	if err = h.DraftRepository.Delete(ctx, draft.ID); err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+func (h PageDraft) Publish(ctx context.Context, in *IDInput[int64]) (*Response[model.Page], error) {
+	page, err := h.DraftRepository.Publish(ctx, in.ID, h.PageRepository.Update)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[model.Page]{Body: page}, nil
+}
+
+func (h PageDraft) Preview(ctx context.Context, in *IDInput[int64]) (*Response[PreviewToken], error) {
+	page, err := h.PageRepository.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	expires := time.Now().Add(h.PreviewDuration)
+
+	token, err := cms.NewPreviewToken(page.ID, h.PreviewSecret, h.PreviewDuration)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	return &Response[PreviewToken]{
+		Body: PreviewToken{
+			Token:   token,
+			URL:     page.URL + "?" + url.Values{h.PreviewParam: {token}}.Encode(),
+			Expires: expires,
+		},
+	}, nil
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type InvitationBody struct {
+	Email   string  `json:"email" yaml:"email" required:"true" format:"email"`
+	Role    string  `json:"role" yaml:"role" required:"true" enum:"reader,writer,admin"`
+	SiteIDs []int64 `json:"site_ids,omitempty" yaml:"site_ids,omitempty" required:"false" uniqueItems:"true" doc:"Grants the role on the sites only, an empty list grants it everywhere."`
+}
+
+type InvitationIssueResponse struct {
+	Location string `header:"Content-Location"`
+	Body     struct {
+		Token      string                `json:"token" yaml:"token" required:"true" doc:"The token the invitee redeems, it is shown only once."`
+		Invitation model.AdminInvitation `json:"invitation" yaml:"invitation" required:"true"`
+	}
+}
+
+type InvitationToken struct {
+	Body struct {
+		Token string `json:"token" required:"true" minLength:"1"`
+	}
+}
+
+type InvitationEnrolment struct {
+	Invitation model.AdminInvitation `json:"invitation" required:"true"`
+	OTPKey     string                `json:"otp_key" required:"true" doc:"The otpauth URL of the secret to enrol."`
+}
+
+type InvitationAccept struct {
+	Body struct {
+		Token    string `json:"token" required:"true" minLength:"1"`
+		Password string `json:"password" required:"true" minLength:"8" maxLength:"64"`
+		OTP      string `json:"otp" required:"true" minLength:"6" maxLength:"6"`
+	}
+}
+
+type Invitation struct {
+	List[model.AdminInvitation]
+	Read[model.AdminInvitation, int64]
+	Delete[int64]
+	DeleteMany[int64]
+
+	Service *cms.InvitationService
+	path    string
+	pathID  string
+	tags    []string
+}
+
+func NewInvitation(repo repository.AdminInvitation, service *cms.InvitationService, errorTransformer ErrorTransformerFunc) Invitation {
+	return Invitation{
+		List:       NewList(repo.FindAndCount, errorTransformer),
+		Read:       NewRead(repo.FindByID, errorTransformer),
+		Delete:     NewDelete(repo.Delete, errorTransformer),
+		DeleteMany: NewDeleteMany(repo.Delete, errorTransformer),
+		Service:    service,
+		path:       "/admin-invitations",
+		pathID:     "/admin-invitations/{id}",
+		tags:       []string{"Admin Invitation"},
+	}
+}
+
+func (h Invitation) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List.Handler, huma.Operation{
+		Summary: "Get Admin Invitations",
+		Method:  http.MethodGet,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Read.Handler, huma.Operation{
+		Summary: "Get Admin Invitation",
+		Method:  http.MethodGet,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Issue, huma.Operation{
+		Summary:       "Invite Admin",
+		DefaultStatus: http.StatusCreated,
+		Method:        http.MethodPost,
+		Path:          h.path,
+		Tags:          h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.DeleteMany.Handler, huma.Operation{
+		Summary: "Delete Admin Invitations",
+		Method:  http.MethodDelete,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+	Register(api, h.Delete.Handler, huma.Operation{
+		Summary: "Delete Admin Invitation",
+		Method:  http.MethodDelete,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": h.target(),
+		},
+	})
+
+	public := &cms.CallTarget{
+		Access: map[cms.AuthScheme]cms.Decider{
+			cms.UnknownScheme: cms.NewDecider(cms.AccessPublic, false),
+			cms.BasicScheme:   cms.NewDecider(cms.AccessPublic, false),
+			cms.JWTScheme:     cms.NewDecider(cms.AccessPublic, false),
+		},
+	}
+	Register(api, h.Begin, huma.Operation{
+		Summary:  "Begin Invitation",
+		Method:   http.MethodPost,
+		Path:     "/auth/invitation",
+		Tags:     h.tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{"target": public},
+	})
+	Register(api, h.Accept, huma.Operation{
+		Summary:       "Accept Invitation",
+		DefaultStatus: http.StatusCreated,
+		Method:        http.MethodPost,
+		Path:          "/auth/invitation/accept",
+		Tags:          h.tags,
+		Security:      []map[string][]string{},
+		Metadata:      map[string]any{"target": public},
+	})
+}
+
+func (h Invitation) Issue(ctx context.Context, in *CreateInput[InvitationBody]) (*InvitationIssueResponse, error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, h.List.ErrorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+
+	m, token, err := h.Service.Invite(ctx, admin.ID, in.Body.Email, model.NewRole(in.Body.Role), in.Body.SiteIDs)
+	if err != nil {
+		if errors.Is(err, repository.ErrUniqueViolation) {
+			return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  "admin already exists",
+				Location: "body.email",
+				Value:    in.Body.Email,
+			})
+		}
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+
+	out := &InvitationIssueResponse{Location: Location(h.pathID, m.ID).Location}
+	out.Body.Token = token
+	out.Body.Invitation = m
+	return out, nil
+}
+
+func (h Invitation) Begin(ctx context.Context, in *InvitationToken) (*Response[InvitationEnrolment], error) {
+	m, key, err := h.Service.Begin(ctx, in.Body.Token)
+	if err != nil {
+		return nil, h.error(ctx, err)
+	}
+	return &Response[InvitationEnrolment]{Body: InvitationEnrolment{Invitation: m, OTPKey: key}}, nil
+}
+
+func (h Invitation) Accept(ctx context.Context, in *InvitationAccept) (*Response[model.Admin], error) {
+	admin, err := h.Service.Redeem(ctx, in.Body.Token, in.Body.Password, in.Body.OTP)
+	if err != nil {
+		if errors.Is(err, model.ErrOTPNotValid) {
+			return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  err.Error(),
+				Location: "body.otp",
+			})
+		}
+		return nil, passwordError(ctx, "body.password", err, h.error)
+	}
+	return &Response[model.Admin]{Body: admin}, nil
+}
+
+func (h Invitation) error(ctx context.Context, err error) error {
+	if errors.Is(err, cms.ErrInvitationInvalid) || errors.Is(err, cms.ErrInvitationOTP) {
+		return huma.Error400BadRequest("Invitation is not valid", err)
+	}
+	return h.List.ErrorTransformer(ctx, err)
+}
+
+// target keeps the tokens away from inviting the admins.
+func (h Invitation) target() *cms.CallTarget {
+	target := cms.NewCallTarget(cms.AccessAdmin)
+	delete(target.Access, cms.TokenScheme)
+	return target
+}
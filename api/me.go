@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type ChangePassword struct {
+	Body struct {
+		CurrentPassword string `json:"current_password,omitempty" required:"true" minLength:"1" maxLength:"64"`
+		Password        string `json:"password,omitempty" required:"true" minLength:"8" maxLength:"64"`
+	}
+}
+
+type OTPKey struct {
+	Key string `json:"key,omitempty" required:"true" doc:"The otpauth URL of the new secret."`
+}
+
+type AvatarUploadForm struct {
+	File huma.FormFile `form:"file" required:"true" contentType:"image/png,image/jpeg,image/gif,image/webp"`
+}
+
+type AvatarUploadInput struct {
+	RawBody huma.MultipartFormFiles[AvatarUploadForm]
+}
+
+// Me is the self-service of the signed-in admin.
+type Me struct {
+	repo             repository.Admin
+	service          *cms.AdminService
+	issuer           string
+	errorTransformer ErrorTransformerFunc
+	path             string
+	tags             []string
+
+	// Sessions signs the admin out of the other sessions after the password change when set.
+	Sessions      *cms.AdminSessionService
+	AvatarMaxSize int64
+}
+
+func NewMe(repo repository.Admin, issuer string, errorTransformer ErrorTransformerFunc) Me {
+	return Me{
+		repo:             repo,
+		service:          cms.NewAdminService(repo),
+		issuer:           issuer,
+		errorTransformer: errorTransformer,
+		path:             "/me",
+		tags:             []string{"Me"},
+		AvatarMaxSize:    2 << 20,
+	}
+}
+
+func (r Me) Register(_ *echo.Echo, humaAPI huma.API) {
+	Register(humaAPI, r.me, huma.Operation{
+		Summary:  "Me",
+		Method:   http.MethodGet,
+		Path:     r.path,
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.changePassword, huma.Operation{
+		Summary:  "Change Password",
+		Method:   http.MethodPut,
+		Path:     r.path + "/password",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(true)},
+	})
+	Register(humaAPI, r.rotateOTP, huma.Operation{
+		Summary:  "Rotate OTP Secret",
+		Method:   http.MethodPost,
+		Path:     r.path + "/otp",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(true)},
+	})
+	Register(humaAPI, r.changeAvatar, huma.Operation{
+		Summary:      "Change Avatar",
+		Method:       http.MethodPut,
+		Path:         r.path + "/avatar",
+		Tags:         r.tags,
+		MaxBodyBytes: r.AvatarMaxSize + 1<<10,
+		Metadata:     map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.resetAvatar, huma.Operation{
+		Summary:  "Reset Avatar",
+		Method:   http.MethodDelete,
+		Path:     r.path + "/avatar",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+}
+
+func (r Me) me(ctx context.Context, _ *struct{}) (*Response[*model.Admin], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.errorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+	return &Response[*model.Admin]{Body: admin}, nil
+}
+
+func (r Me) changePassword(ctx context.Context, in *ChangePassword) (*struct{}, error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.errorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+
+	if err := r.service.ChangeOwnPassword(ctx, admin.Email, in.Body.CurrentPassword, in.Body.Password); err != nil {
+		location := "body.password"
+		if errors.Is(err, model.ErrPasswordNotValid) {
+			location = "body.current_password"
+		}
+		return nil, passwordError(ctx, location, err, r.errorTransformer)
+	}
+
+	if r.Sessions == nil {
+		return nil, nil
+	}
+
+	sessions, err := r.Sessions.Sessions(ctx, admin.ID)
+	if err != nil {
+		return nil, r.errorTransformer(ctx, err)
+	}
+
+	current, _ := cms.CtxClaims(ctx).Metadata[cms.ClaimSessionID].(string)
+
+	var others []string
+	for _, session := range sessions {
+		if session.FamilyID != current {
+			others = append(others, session.FamilyID)
+		}
+	}
+	if len(others) > 0 {
+		if err = r.Sessions.Revoke(ctx, others...); err != nil {
+			return nil, r.errorTransformer(ctx, err)
+		}
+	}
+	return nil, nil
+}
+
+func (r Me) rotateOTP(ctx context.Context, _ *struct{}) (*Response[OTPKey], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.errorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+
+	key, err := r.service.GetOTPKey(ctx, admin.Email, r.issuer, true)
+	if err != nil {
+		return nil, r.errorTransformer(ctx, err)
+	}
+	return &Response[OTPKey]{Body: OTPKey{Key: key}}, nil
+}
+
+func (r Me) changeAvatar(ctx context.Context, in *AvatarUploadInput) (*Response[*model.Admin], error) {
+	form := in.RawBody.Data()
+	defer func() {
+		_ = form.File.Close()
+	}()
+
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.errorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+
+	if form.File.Size > r.AvatarMaxSize {
+		return nil, huma.NewError(http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+	}
+
+	if err := r.service.ChangeAvatar(ctx, admin.Email, form.File); err != nil {
+		if errors.Is(err, cms.ErrAvatarInvalid) {
+			return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  err.Error(),
+				Location: "body.file",
+			})
+		}
+		return nil, r.errorTransformer(ctx, err)
+	}
+	return r.reload(ctx, admin.ID)
+}
+
+func (r Me) resetAvatar(ctx context.Context, _ *struct{}) (*Response[*model.Admin], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.errorTransformer(ctx, errors.New("invalid context, admin not found"))
+	}
+
+	if err := r.service.ResetAvatar(ctx, admin.Email); err != nil {
+		return nil, r.errorTransformer(ctx, err)
+	}
+	return r.reload(ctx, admin.ID)
+}
+
+func (r Me) reload(ctx context.Context, id int64) (*Response[*model.Admin], error) {
+	admin, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, r.errorTransformer(ctx, err)
+	}
+	return &Response[*model.Admin]{Body: &admin}, nil
+}
+
+// passwordError reports the rejected password at the location, other errors are transformed.
+func passwordError(ctx context.Context, location string, err error, errorTransformer ErrorTransformerFunc) error {
+	for _, target := range []error{model.ErrPasswordNotValid, model.ErrPasswordIsEmpty, model.ErrPasswordShort, model.ErrPasswordLong} {
+		if errors.Is(err, target) {
+			return huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  target.Error(),
+				Location: location,
+			})
+		}
+	}
+	return errorTransformer(ctx, err)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type MediaBody struct {
+	SiteID *int64 `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	Folder string `json:"folder,omitempty" yaml:"folder,omitempty" required:"false"`
+	Name   string `json:"name,omitempty" yaml:"name,omitempty" required:"true" minLength:"1"`
+	Alt    string `json:"alt,omitempty" yaml:"alt,omitempty" required:"false"`
+}
+
+func (dto MediaBody) Decode(m *model.Media) {
+	m.SiteID = dto.SiteID
+	m.Folder = model.MediaFolder(dto.Folder)
+	m.Name = dto.Name
+	m.Alt = dto.Alt
+}
+
+type MediaUploadForm struct {
+	File huma.FormFile `form:"file" required:"true" doc:"The file to upload, the form may also carry the folder, alt and site_id values."`
+}
+
+type MediaUploadInput struct {
+	RawBody huma.MultipartFormFiles[MediaUploadForm]
+}
+
+type MediaUploadResponse struct {
+	Location string `header:"Content-Location"`
+	Body     model.Media
+}
+
+type Media struct {
+	List[model.Media]
+	Read[model.Media, int64]
+	Update[MediaBody, model.Media, int64]
+	Delete[int64]
+	DeleteMany[int64]
+
+	Repository repository.Media
+	Service    *cms.MediaService
+	path       string
+	pathID     string
+	tags       []string
+}
+
+func NewMedia(repo repository.Media, service *cms.MediaService, errorTransformer ErrorTransformerFunc) Media {
+	errorTransformer = mediaErrorTransformer(errorTransformer)
+
+	return Media{
+		List:       NewList(repo.FindAndCount, errorTransformer),
+		Read:       NewRead(repo.FindByID, errorTransformer),
+		Update:     NewUpdate[MediaBody](repo.FindByID, repo.Update, errorTransformer),
+		Delete:     NewDelete(service.Delete, errorTransformer),
+		DeleteMany: NewDeleteMany(service.Delete, errorTransformer),
+		Repository: repo,
+		Service:    service,
+		path:       "/media",
+		pathID:     "/media/{id}",
+		tags:       []string{"Media"},
+	}
+}
+
+func (h Media) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List.Handler, huma.Operation{
+		Summary: "Get Media",
+		Method:  http.MethodGet,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessRead),
+		},
+	})
+	Register(api, h.Read.Handler, huma.Operation{
+		Summary: "Get Media Object",
+		Method:  http.MethodGet,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessRead),
+		},
+	})
+	Register(api, h.Folders, huma.Operation{
+		Summary: "Get Media Folders",
+		Method:  http.MethodGet,
+		Path:    "/media-folders",
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessRead),
+		},
+	})
+	Register(api, h.Upload, huma.Operation{
+		Summary:       "Upload Media",
+		DefaultStatus: http.StatusCreated,
+		Method:        http.MethodPost,
+		Path:          h.path,
+		Tags:          h.tags,
+		MaxBodyBytes:  h.Service.MaxSize + 1<<20,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessWrite),
+		},
+	})
+	Register(api, h.Update.Handler, huma.Operation{
+		Summary: "Update Media",
+		Method:  http.MethodPut,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessWrite),
+		},
+	})
+	Register(api, h.DeleteMany.Handler, huma.Operation{
+		Summary: "Delete Media",
+		Method:  http.MethodDelete,
+		Path:    h.path,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessWrite),
+		},
+	})
+	Register(api, h.Delete.Handler, huma.Operation{
+		Summary: "Delete Media Object",
+		Method:  http.MethodDelete,
+		Path:    h.pathID,
+		Tags:    h.tags,
+		Metadata: map[string]any{
+			"target": cms.NewScopedCallTarget(model.ResourceMedia, cms.AccessWrite),
+		},
+	})
+}
+
+func (h Media) Folders(ctx context.Context, _ *struct{}) (*Response[[]string], error) {
+	folders, err := h.Repository.Folders(ctx)
+	if err != nil {
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+	return &Response[[]string]{Body: folders}, nil
+}
+
+func (h Media) Upload(ctx context.Context, in *MediaUploadInput) (*MediaUploadResponse, error) {
+	form := in.RawBody.Data()
+	defer func() {
+		_ = form.File.Close()
+	}()
+
+	values := url.Values(in.RawBody.Form.Value)
+
+	m := model.Media{
+		Name:   form.File.Filename,
+		Folder: values.Get("folder"),
+		Alt:    values.Get("alt"),
+	}
+	if value := values.Get("site_id"); value != "" {
+		siteID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  "invalid site id",
+				Location: "body.site_id",
+				Value:    value,
+			})
+		}
+		m.SiteID = &siteID
+	}
+
+	if err := h.Service.Upload(ctx, &m, form.File); err != nil {
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+	return &MediaUploadResponse{Location: Location(h.pathID, m.ID).Location, Body: m}, nil
+}
+
+func mediaErrorTransformer(next ErrorTransformerFunc) ErrorTransformerFunc {
+	return func(ctx context.Context, err error) error {
+		if errors.Is(err, cms.ErrMediaTooLarge) {
+			return huma.NewError(http.StatusRequestEntityTooLarge, "Request Entity Too Large", err)
+		}
+		return next(ctx, err)
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/gowool/cms"
+)
+
+// oidcCookie binds the sign-in to the browser starting it, so the callback of a sign-in
+// started by someone else is rejected.
+const oidcCookie = "cms_oidc"
+
+type OIDCAuthorization struct {
+	URL string `json:"url,omitempty" required:"true"`
+}
+
+type OIDCAuthorizationResponse struct {
+	SetCookie http.Cookie `header:"Set-Cookie"`
+	Body      OIDCAuthorization
+}
+
+type OIDCSessionResponse struct {
+	SetCookie http.Cookie `header:"Set-Cookie"`
+	Body      Session
+}
+
+type OIDCCallback struct {
+	Client
+	Binding string `cookie:"cms_oidc"`
+	Body    struct {
+		Code  string `json:"code,omitempty" required:"true" minLength:"1"`
+		State string `json:"state,omitempty" required:"true" minLength:"1"`
+	}
+}
+
+func (r Auth) registerOIDC(humaAPI huma.API) {
+	target := &cms.CallTarget{
+		Access: map[cms.AuthScheme]cms.Decider{
+			cms.UnknownScheme: cms.NewDecider(cms.AccessPublic, false),
+		},
+	}
+
+	Register(humaAPI, r.oidcAuthorize, huma.Operation{
+		Summary:  "Get OpenID Connect Authorization URL",
+		Method:   http.MethodGet,
+		Path:     "/auth/oidc/authorize",
+		Tags:     r.tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{"target": target},
+	})
+	Register(humaAPI, r.oidcCallback, huma.Operation{
+		Summary:  "OpenID Connect Callback",
+		Method:   http.MethodPost,
+		Path:     "/auth/oidc/callback",
+		Tags:     r.tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{"target": target},
+	})
+}
+
+func (r Auth) oidcAuthorize(ctx context.Context, _ *struct{}) (*OIDCAuthorizationResponse, error) {
+	url, binding, err := r.OIDC.AuthURL(ctx)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &OIDCAuthorizationResponse{
+		SetCookie: oidcBindingCookie(binding, int(r.OIDC.StateTimeout()/time.Second)),
+		Body:      OIDCAuthorization{URL: url},
+	}, nil
+}
+
+func (r Auth) oidcCallback(ctx context.Context, in *OIDCCallback) (*OIDCSessionResponse, error) {
+	admin, twoFA, err := r.OIDC.Exchange(ctx, in.Body.State, in.Binding, in.Body.Code)
+	if err != nil {
+		return nil, r.error(err)
+	}
+
+	session, err := r.start(ctx, admin, twoFA, in.Client)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCSessionResponse{SetCookie: oidcBindingCookie("", -1), Body: session.Body}, nil
+}
+
+func oidcBindingCookie(value string, maxAge int) http.Cookie {
+	return http.Cookie{
+		Name:     oidcCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -79,7 +79,7 @@ func (h Page) Register(e *echo.Echo, api huma.API) {
 		Path:    h.Path + "/hybrid-patterns",
 		Tags:    h.Tags,
 		Metadata: map[string]any{
-			"target": cms.NewCallTarget(cms.AccessRead),
+			"target": h.target(cms.AccessRead),
 		},
 	})
 }
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/gowool/cms"
+)
+
+type PasswordResetRequest struct {
+	Client
+	Body struct {
+		Email string `json:"email,omitempty" required:"true" format:"email"`
+	}
+}
+
+type PasswordResetConfirm struct {
+	Client
+	Body struct {
+		Token    string `json:"token,omitempty" required:"true" minLength:"1"`
+		Password string `json:"password,omitempty" required:"true" minLength:"8" maxLength:"64"`
+	}
+}
+
+func (r Auth) registerPasswordReset(humaAPI huma.API) {
+	target := &cms.CallTarget{
+		Access: map[cms.AuthScheme]cms.Decider{
+			cms.UnknownScheme: cms.NewDecider(cms.AccessPublic, false),
+		},
+	}
+
+	Register(humaAPI, r.requestPasswordReset, huma.Operation{
+		Summary:       "Request Password Reset",
+		Method:        http.MethodPost,
+		Path:          "/auth/password-reset",
+		DefaultStatus: http.StatusAccepted,
+		Tags:          r.tags,
+		Security:      []map[string][]string{},
+		Metadata:      map[string]any{"target": target},
+	})
+	Register(humaAPI, r.confirmPasswordReset, huma.Operation{
+		Summary:  "Reset Password",
+		Method:   http.MethodPost,
+		Path:     "/auth/password-reset/confirm",
+		Tags:     r.tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{"target": target},
+	})
+}
+
+func (r Auth) requestPasswordReset(ctx context.Context, in *PasswordResetRequest) (*struct{}, error) {
+	keys := []string{cms.ThrottleEmailKey(in.Body.Email), cms.ThrottleIPKey(r.IPExtractor(in.request))}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
+	}
+	defer r.done(ctx, keys...)
+
+	// every request mails the admin, so it is counted whether it succeeds or not
+	err := r.PasswordReset.Request(ctx, in.Body.Email)
+	r.count(ctx, keys...)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+func (r Auth) confirmPasswordReset(ctx context.Context, in *PasswordResetConfirm) (*struct{}, error) {
+	ip := r.IPExtractor(in.request)
+	keys := []string{cms.ThrottleIPKey(ip)}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
+	}
+	defer r.done(ctx, keys...)
+
+	if err := r.PasswordReset.Reset(ctx, in.Body.Token, in.Body.Password); err != nil {
+		if errors.Is(err, cms.ErrPasswordResetInvalid) {
+			_ = r.fail(ctx, err, "", ip, keys...)
+			return nil, huma.Error400BadRequest("Password reset token is not valid")
+		}
+		return nil, passwordError(ctx, "body.password", err, ErrorTransformer)
+	}
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/api"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+type nopMailer struct{}
+
+func (nopMailer) Send(context.Context, cms.Mail) error {
+	return nil
+}
+
+func TestAuth_PasswordResetRequestsAreThrottled(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+	admin := model.Admin{Email: "admin@example.com", Role: model.RoleAdmin}
+	if err := admins.Create(ctx, &admin); err != nil {
+		t.Fatal(err)
+	}
+
+	c := cache.NewLRU(100, 0, nil)
+
+	throttle := cms.NewThrottle(c)
+	throttle.BaseDelay = 0
+	throttle.MaxFailures = 3
+
+	auth := api.NewAuth(admins, nil, "secret", 0, zap.NewNop())
+	auth.Throttle = throttle
+	auth.PasswordReset = cms.NewPasswordResetService(admins, nopMailer{}, c, "https://cms.test/reset")
+
+	_, humaAPI := humatest.New(t)
+	auth.Register(nil, humaAPI)
+
+	for i := range throttle.MaxFailures + 1 {
+		resp := humaAPI.Post("/auth/password-reset", map[string]any{"email": admin.Email})
+
+		want := http.StatusAccepted
+		if i == throttle.MaxFailures {
+			want = http.StatusTooManyRequests
+		}
+		if resp.Code != want {
+			t.Fatalf("request %d: status = %d, want %d: %s", i+1, resp.Code, want, resp.Body.String())
+		}
+	}
+}
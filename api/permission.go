@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type PermissionBody struct {
+	AdminID  int64  `json:"admin_id" yaml:"admin_id" required:"true"`
+	Role     string `json:"role" yaml:"role" required:"true" enum:"reader,writer,admin"`
+	Resource string `json:"resource,omitempty" yaml:"resource,omitempty" required:"false" enum:",page,site,template,menu,node,block,redirect,media" doc:"An empty resource grants the role on every resource."`
+	SiteID   *int64 `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID   *int64 `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false" doc:"Narrows the permission to the page and its descendants."`
+}
+
+func (dto PermissionBody) Decode(m *model.Permission) {
+	m.AdminID = dto.AdminID
+	m.Role = model.NewRole(dto.Role)
+	m.Resource = dto.Resource
+	m.SiteID = dto.SiteID
+	m.PageID = dto.PageID
+}
+
+type Permission struct {
+	CRUD[PermissionBody, model.Permission, int64]
+}
+
+func NewPermission(repo repository.Permission, errorTransformer ErrorTransformerFunc) Permission {
+	return Permission{
+		CRUD: NewCRUD[PermissionBody](repo, errorTransformer, "/permissions", "Permission", "Permissions", "Permission"),
+	}
+}
+
+func (h Permission) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List.Handler, huma.Operation{
+		Summary: "Get " + h.LabelPlural,
+		Method:  http.MethodGet,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.Read.Handler, huma.Operation{
+		Summary: "Get " + h.LabelSingular,
+		Method:  http.MethodGet,
+		Path:    h.PathID,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.DeleteMany.Handler, huma.Operation{
+		Summary: "Delete " + h.LabelPlural,
+		Method:  http.MethodDelete,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.Delete.Handler, huma.Operation{
+		Summary: "Delete " + h.LabelSingular,
+		Method:  http.MethodDelete,
+		Path:    h.PathID,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.Create.Handler, huma.Operation{
+		Summary:       "Create " + h.LabelSingular,
+		DefaultStatus: http.StatusCreated,
+		Method:        http.MethodPost,
+		Path:          h.Path,
+		Tags:          h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+	Register(api, h.Update.Handler, huma.Operation{
+		Summary: "Update " + h.LabelSingular,
+		Method:  http.MethodPut,
+		Path:    h.PathID,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessAdmin),
+		},
+	})
+}
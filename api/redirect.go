@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type RedirectBody struct {
+	SiteID  *int64             `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID  *int64             `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false"`
+	Type    model.RedirectType `json:"type,omitempty" yaml:"type,omitempty" required:"true" enum:"exact,prefix,regex"`
+	Source  string             `json:"source,omitempty" yaml:"source,omitempty" required:"true" minLength:"1"`
+	Target  string             `json:"target,omitempty" yaml:"target,omitempty" required:"false"`
+	Status  int                `json:"status,omitempty" yaml:"status,omitempty" required:"true" enum:"301,302,307,308,410"`
+	Enabled bool               `json:"enabled,omitempty" yaml:"enabled,omitempty" required:"false"`
+}
+
+func (dto *RedirectBody) Resolve(_ huma.Context, prefix *huma.PathBuffer) []error {
+	var errs []error
+	if dto.Type == model.RedirectRegex {
+		if _, ok := internal.Regexp(dto.Source); !ok {
+			errs = append(errs, &huma.ErrorDetail{
+				Message:  "invalid regular expression",
+				Location: prefix.With("source"),
+				Value:    dto.Source,
+			})
+		}
+	}
+	if dto.Target == "" && dto.Status != http.StatusGone {
+		errs = append(errs, &huma.ErrorDetail{
+			Message:  "target is required unless status is 410",
+			Location: prefix.With("target"),
+			Value:    dto.Target,
+		})
+	}
+	return errs
+}
+
+func (dto RedirectBody) Decode(m *model.Redirect) {
+	m.SiteID = dto.SiteID
+	m.PageID = dto.PageID
+	m.Type = dto.Type
+	m.Source = dto.Source
+	m.Target = dto.Target
+	m.Status = dto.Status
+	m.Enabled = dto.Enabled
+}
+
+type Redirect struct {
+	CRUD[RedirectBody, model.Redirect, int64]
+}
+
+func NewRedirect(repo repository.Redirect, errorTransformer ErrorTransformerFunc) Redirect {
+	return Redirect{
+		CRUD: NewCRUD[RedirectBody](repo, errorTransformer, "/redirects", "Redirect", "Redirects", "Redirect"),
+	}
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gowool/cr"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type RevisionListInput struct {
+	ID int64 `path:"id"`
+	ListInput
+}
+
+type RevisionInput struct {
+	ID      int64 `path:"id"`
+	Version int   `path:"version"`
+}
+
+type RevisionDiffInput struct {
+	ID      int64 `path:"id"`
+	Version int   `path:"version"`
+	To      int   `path:"to"`
+}
+
+type RevisionDiff struct {
+	From    int                    `json:"from" yaml:"from" required:"true"`
+	To      int                    `json:"to" yaml:"to" required:"true"`
+	Changes []model.RevisionChange `json:"changes" yaml:"changes" required:"true"`
+}
+
+type Revision[M any] struct {
+	Revisions        repository.Revision[M]
+	Finder           func(context.Context, int64) (M, error)
+	Saver            func(context.Context, *M) error
+	ErrorTransformer ErrorTransformerFunc
+	Path             string
+	LabelSingular    string
+	LabelPlural      string
+	Tags             []string
+}
+
+func NewRevision[M any](
+	revisions repository.Revision[M],
+	repo repository.Repository[M, int64],
+	errorTransformer ErrorTransformerFunc,
+	path,
+	labelSingular,
+	labelPlural string,
+	tags ...string,
+) Revision[M] {
+	return Revision[M]{
+		Revisions:        revisions,
+		Finder:           repo.FindByID,
+		Saver:            repo.Update,
+		ErrorTransformer: errorTransformer,
+		Path:             path,
+		LabelSingular:    labelSingular,
+		LabelPlural:      labelPlural,
+		Tags:             tags,
+	}
+}
+
+func NewPageRevision(revisions repository.PageRevision, repo repository.Page, errorTransformer ErrorTransformerFunc) Revision[model.Page] {
+	return NewRevision(revisions, repo, errorTransformer, "/pages/{id}/revisions", "Page Revision", "Page Revisions", "Page")
+}
+
+func NewTemplateRevision(revisions repository.TemplateRevision, repo repository.Template, errorTransformer ErrorTransformerFunc) Revision[model.Template] {
+	return NewRevision(revisions, repo, errorTransformer, "/templates/{id}/revisions", "Template Revision", "Template Revisions", "Template")
+}
+
+func (h Revision[M]) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.List, huma.Operation{
+		Summary: "Get " + h.LabelPlural,
+		Method:  http.MethodGet,
+		Path:    h.Path,
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessRead),
+		},
+	})
+	Register(api, h.Read, huma.Operation{
+		Summary: "Get " + h.LabelSingular,
+		Method:  http.MethodGet,
+		Path:    h.Path + "/{version}",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessRead),
+		},
+	})
+	Register(api, h.Diff, huma.Operation{
+		Summary: "Diff " + h.LabelPlural,
+		Method:  http.MethodGet,
+		Path:    h.Path + "/{version}/diff/{to}",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessRead),
+		},
+	})
+	Register(api, h.Restore, huma.Operation{
+		Summary: "Restore " + h.LabelSingular,
+		Method:  http.MethodPost,
+		Path:    h.Path + "/{version}/restore",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+}
+
+func (h Revision[M]) List(ctx context.Context, in *RevisionListInput) (*Response[ListOutput[model.Revision[M]]], error) {
+	criteria := in.criteria()
+
+	conditions := []any{cr.Condition{Column: "record_id", Value: in.ID}}
+	if !criteria.Filter.IsEmpty() {
+		conditions = append(conditions, criteria.Filter)
+	}
+	criteria.Filter = cr.Filter{Conditions: conditions}
+
+	items, total, err := h.Revisions.FindAndCount(ctx, criteria)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[ListOutput[model.Revision[M]]]{
+		Body: ListOutput[model.Revision[M]]{
+			ListInput: in.ListInput,
+			Items:     items,
+			Total:     total,
+		},
+	}, nil
+}
+
+func (h Revision[M]) Read(ctx context.Context, in *RevisionInput) (*Response[model.Revision[M]], error) {
+	rev, err := h.Revisions.FindByVersion(ctx, in.ID, in.Version)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[model.Revision[M]]{Body: rev}, nil
+}
+
+func (h Revision[M]) Diff(ctx context.Context, in *RevisionDiffInput) (*Response[RevisionDiff], error) {
+	from, err := h.Revisions.FindByVersion(ctx, in.ID, in.Version)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	to, err := h.Revisions.FindByVersion(ctx, in.ID, in.To)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	changes, err := from.Diff(to)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[RevisionDiff]{
+		Body: RevisionDiff{
+			From:    from.Version,
+			To:      to.Version,
+			Changes: changes,
+		},
+	}, nil
+}
+
+func (h Revision[M]) Restore(ctx context.Context, in *RevisionInput) (*struct{}, error) {
+	if _, err := h.Finder(ctx, in.ID); err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	rev, err := h.Revisions.FindByVersion(ctx, in.ID, in.Version)
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	m := rev.Data
+	if err = h.Saver(ctx, &m); err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type SearchInput struct {
+	SiteID int64  `query:"site_id" json:"site_id,omitempty" yaml:"site_id,omitempty" required:"true"`
+	Locale string `query:"locale" json:"locale,omitempty" yaml:"locale,omitempty" required:"false"`
+	Query  string `query:"q" json:"q,omitempty" yaml:"q,omitempty" required:"true" minLength:"1" maxLength:"256"`
+	Page   int    `query:"page" json:"page,omitempty" yaml:"page,omitempty" required:"false"`
+	Limit  int    `query:"limit" json:"limit,omitempty" yaml:"limit,omitempty" required:"false"`
+}
+
+func (in *SearchInput) Resolve(huma.Context) []error {
+	if in.Page < 1 {
+		in.Page = 1
+	}
+	if in.Limit < 1 || in.Limit > 100 {
+		in.Limit = 20
+	}
+	return nil
+}
+
+type SearchOutput struct {
+	SearchInput
+	Items []model.SearchHit `json:"items,omitempty" yaml:"items,omitempty" required:"false"`
+	Total int               `json:"total,omitempty" yaml:"total,omitempty" required:"false"`
+}
+
+type Search struct {
+	Repository       repository.Search
+	SiteRepository   repository.Site
+	ErrorTransformer ErrorTransformerFunc
+	Path             string
+	Tags             []string
+}
+
+func NewSearch(repo repository.Search, siteRepo repository.Site, errorTransformer ErrorTransformerFunc) Search {
+	return Search{
+		Repository:       repo,
+		SiteRepository:   siteRepo,
+		ErrorTransformer: errorTransformer,
+		Path:             "/search",
+		Tags:             []string{"Search"},
+	}
+}
+
+func (h Search) Register(_ *echo.Echo, api huma.API) {
+	Register(api, h.Search, huma.Operation{
+		Summary:  "Search Pages",
+		Method:   http.MethodGet,
+		Path:     h.Path,
+		Tags:     h.Tags,
+		Security: []map[string][]string{},
+		Metadata: map[string]any{
+			"target": &cms.CallTarget{
+				Access: map[cms.AuthScheme]cms.Decider{
+					cms.UnknownScheme: cms.NewDecider(cms.AccessPublic, false),
+					cms.BasicScheme:   cms.NewDecider(cms.AccessPublic, false),
+					cms.JWTScheme:     cms.NewDecider(cms.AccessPublic, false),
+				},
+			},
+		},
+	})
+}
+
+func (h Search) Search(ctx context.Context, in *SearchInput) (*Response[SearchOutput], error) {
+	now := time.Now()
+
+	// the pages of the sites not visible are not searchable either
+	site, err := h.SiteRepository.FindByID(ctx, in.SiteID)
+	if err == nil && !site.IsEnabled(now) {
+		err = repository.ErrSiteNotFound
+	}
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+
+	hits, total, err := h.Repository.Search(ctx, model.SearchQuery{
+		SiteID: site.ID,
+		Locale: in.Locale,
+		Text:   in.Query,
+		Now:    now,
+		Offset: (in.Page - 1) * in.Limit,
+		Limit:  in.Limit,
+	})
+	if err != nil {
+		return nil, h.ErrorTransformer(ctx, err)
+	}
+	return &Response[SearchOutput]{
+		Body: SearchOutput{
+			SearchInput: *in,
+			Items:       hits,
+			Total:       total,
+		},
+	}, nil
+}
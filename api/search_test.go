@@ -0,0 +1,63 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/gowool/cms/api"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestSearch_OnlyVisibleSites(t *testing.T) {
+	ctx := context.Background()
+	published := time.Now().Add(-time.Hour)
+	expired := time.Now().Add(-time.Minute)
+
+	sites := memory.NewSiteRepository()
+	visible := model.Site{Name: "visible", Host: "visible.test", Separator: "-", Published: &published}
+	gone := model.Site{Name: "gone", Host: "gone.test", Separator: "-", Published: &published, Expired: &expired}
+	for _, site := range []*model.Site{&visible, &gone} {
+		if err := sites.Create(ctx, site); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := memory.NewSearchRepository()
+	for _, site := range []model.Site{visible, gone} {
+		if err := search.Index(ctx, model.SearchDocument{
+			PageID:    site.ID,
+			SiteID:    site.ID,
+			URL:       "/launch",
+			Title:     "Launch",
+			Published: &published,
+			Updated:   published,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := api.NewSearch(search, sites, api.ErrorTransformer)
+
+	out, err := h.Search(ctx, &api.SearchInput{SiteID: visible.ID, Query: "launch", Page: 1, Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Body.Total != 1 {
+		t.Fatalf("total = %d, want the page of the visible site", out.Body.Total)
+	}
+
+	for _, siteID := range []int64{gone.ID, 100} {
+		_, err = h.Search(ctx, &api.SearchInput{SiteID: siteID, Query: "launch", Page: 1, Limit: 20})
+
+		var statusErr huma.StatusError
+		if !errors.As(err, &statusErr) || statusErr.GetStatus() != http.StatusNotFound {
+			t.Fatalf("site %d: err = %v, want not found", siteID, err)
+		}
+	}
+}
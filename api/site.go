@@ -17,6 +17,7 @@ type SiteBody struct {
 	IsDefault    bool              `json:"is_default,omitempty" yaml:"is_default,omitempty" required:"false"`
 	Javascript   string            `json:"javascript,omitempty" yaml:"javascript,omitempty" required:"false"`
 	Stylesheet   string            `json:"stylesheet,omitempty" yaml:"stylesheet,omitempty" required:"false"`
+	Robots       string            `json:"robots,omitempty" yaml:"robots,omitempty" required:"false"`
 	Metas        []model.Meta      `json:"metas,omitempty" yaml:"metas,omitempty" required:"false"`
 	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty" required:"false"`
 	Published    *time.Time        `json:"published,omitempty" yaml:"published,omitempty" required:"false"`
@@ -33,6 +34,7 @@ func (dto SiteBody) Decode(m *model.Site) {
 	m.IsDefault = dto.IsDefault
 	m.Javascript = dto.Javascript
 	m.Stylesheet = dto.Stylesheet
+	m.Robots = dto.Robots
 	m.Metas = dto.Metas
 	m.Metadata = dto.Metadata
 	m.Published = dto.Published
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type TranslationGroupBody struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty" required:"true" minLength:"1"`
+}
+
+func (dto TranslationGroupBody) Decode(m *model.TranslationGroup) {
+	m.Name = dto.Name
+}
+
+type TranslationPagesInput struct {
+	ID   int64 `path:"id"`
+	Body struct {
+		PageIDs []int64 `json:"page_ids" required:"true" minItems:"0" nullable:"false" uniqueItems:"true"`
+	}
+}
+
+type TranslationGroup struct {
+	CRUD[TranslationGroupBody, model.TranslationGroup, int64]
+	Service *cms.TranslationService
+}
+
+func NewTranslationGroup(repo repository.TranslationGroup, service *cms.TranslationService, errorTransformer ErrorTransformerFunc) TranslationGroup {
+	errorTransformer = translationErrorTransformer(errorTransformer)
+
+	crud := NewCRUD[TranslationGroupBody](repo, errorTransformer, "/translation-groups", "Translation Group", "Translation Groups", "Translation")
+	crud.Delete = NewDelete(service.Delete, errorTransformer)
+	crud.DeleteMany = NewDeleteMany(service.Delete, errorTransformer)
+
+	return TranslationGroup{CRUD: crud, Service: service}
+}
+
+func (h TranslationGroup) Register(e *echo.Echo, api huma.API) {
+	h.CRUD.Register(e, api)
+
+	Register(api, h.Pages, huma.Operation{
+		Summary: "Get Translation Group Pages",
+		Method:  http.MethodGet,
+		Path:    h.PathID + "/pages",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessRead),
+		},
+	})
+	Register(api, h.SetPages, huma.Operation{
+		Summary: "Set Translation Group Pages",
+		Method:  http.MethodPut,
+		Path:    h.PathID + "/pages",
+		Tags:    h.Tags,
+		Metadata: map[string]any{
+			"target": cms.NewCallTarget(cms.AccessWrite),
+		},
+	})
+}
+
+func (h TranslationGroup) Pages(ctx context.Context, in *IDInput[int64]) (*Response[[]model.Page], error) {
+	pages, err := h.Service.Pages(ctx, in.ID)
+	if err != nil {
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+	return &Response[[]model.Page]{Body: pages}, nil
+}
+
+func (h TranslationGroup) SetPages(ctx context.Context, in *TranslationPagesInput) (*struct{}, error) {
+	if err := h.Service.SetPages(ctx, in.ID, in.Body.PageIDs...); err != nil {
+		return nil, h.List.ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+func translationErrorTransformer(next ErrorTransformerFunc) ErrorTransformerFunc {
+	return func(ctx context.Context, err error) error {
+		if errors.Is(err, cms.ErrTranslationSiteConflict) {
+			return huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+				Message:  err.Error(),
+				Location: "body.page_ids",
+			})
+		}
+		return next(ctx, err)
+	}
+}
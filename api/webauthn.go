@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+)
+
+type WebAuthnRegistration struct {
+	Body struct {
+		Name       string          `json:"name,omitempty" required:"true" minLength:"1" maxLength:"255"`
+		Credential json.RawMessage `json:"credential,omitempty" required:"true"`
+	}
+}
+
+type WebAuthnAssertion struct {
+	Client
+	Body struct {
+		Credential json.RawMessage `json:"credential,omitempty" required:"true"`
+	}
+}
+
+type WebAuthnCredentialInput struct {
+	ID int64 `path:"id"`
+}
+
+type RecoveryCodeInput struct {
+	Client
+	Body struct {
+		Code string `json:"code,omitempty" required:"true" minLength:"16" maxLength:"32"`
+	}
+}
+
+type RecoveryCodes struct {
+	Codes     []string `json:"codes,omitempty" required:"false"`
+	Remaining int      `json:"remaining" required:"true"`
+}
+
+func (r Auth) registerWebAuthn(humaAPI huma.API) {
+	Register(humaAPI, r.webAuthnRegistrationOptions, huma.Operation{
+		Summary:  "Get WebAuthn Registration Options",
+		Method:   http.MethodPost,
+		Path:     "/auth/webauthn/registration/options",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(true)},
+	})
+	Register(humaAPI, r.webAuthnRegistration, huma.Operation{
+		Summary:       "Register WebAuthn Credential",
+		Method:        http.MethodPost,
+		Path:          "/auth/webauthn/registration",
+		DefaultStatus: http.StatusCreated,
+		Tags:          r.tags,
+		Metadata:      map[string]any{"target": factorTarget(true)},
+	})
+	Register(humaAPI, r.webAuthnCredentials, huma.Operation{
+		Summary:  "Get WebAuthn Credentials",
+		Method:   http.MethodGet,
+		Path:     "/auth/webauthn/credentials",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.webAuthnDeleteCredential, huma.Operation{
+		Summary:  "Delete WebAuthn Credential",
+		Method:   http.MethodDelete,
+		Path:     "/auth/webauthn/credentials/{id}",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(true)},
+	})
+	Register(humaAPI, r.webAuthnAssertionOptions, huma.Operation{
+		Summary:  "Get WebAuthn Assertion Options",
+		Method:   http.MethodPost,
+		Path:     "/auth/webauthn/assertion/options",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.webAuthnAssertion, huma.Operation{
+		Summary:  "WebAuthn",
+		Method:   http.MethodPost,
+		Path:     "/auth/webauthn/assertion",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+}
+
+func (r Auth) registerRecoveryCodes(humaAPI huma.API) {
+	Register(humaAPI, r.recovery, huma.Operation{
+		Summary:  "Recovery Code",
+		Method:   http.MethodPost,
+		Path:     "/auth/recovery",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.recoveryCodes, huma.Operation{
+		Summary:  "Get Recovery Codes",
+		Method:   http.MethodGet,
+		Path:     "/auth/recovery-codes",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(false)},
+	})
+	Register(humaAPI, r.generateRecoveryCodes, huma.Operation{
+		Summary:  "Generate Recovery Codes",
+		Method:   http.MethodPost,
+		Path:     "/auth/recovery-codes",
+		Tags:     r.tags,
+		Metadata: map[string]any{"target": factorTarget(true)},
+	})
+}
+
+func (r Auth) webAuthnRegistrationOptions(ctx context.Context, _ *struct{}) (*Response[json.RawMessage], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	creation, err := r.WebAuthn.BeginRegistration(ctx, *admin)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return r.raw(ctx, creation)
+}
+
+func (r Auth) webAuthnRegistration(ctx context.Context, in *WebAuthnRegistration) (*Response[model.WebAuthnCredential], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	credential, err := r.WebAuthn.FinishRegistration(ctx, *admin, in.Body.Name, in.Body.Credential)
+	if err != nil {
+		r.logger.Error("webauthn registration failed", zap.Error(err))
+		return nil, huma.Error400BadRequest("Registration failed, please try again")
+	}
+	return &Response[model.WebAuthnCredential]{Body: credential}, nil
+}
+
+func (r Auth) webAuthnCredentials(ctx context.Context, _ *struct{}) (*Response[[]model.WebAuthnCredential], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	credentials, err := r.WebAuthn.Credentials(ctx, admin.ID)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &Response[[]model.WebAuthnCredential]{Body: credentials}, nil
+}
+
+func (r Auth) webAuthnDeleteCredential(ctx context.Context, in *WebAuthnCredentialInput) (*struct{}, error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	if err := r.WebAuthn.Delete(ctx, admin.ID, in.ID); err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return nil, nil
+}
+
+func (r Auth) webAuthnAssertionOptions(ctx context.Context, _ *struct{}) (*Response[json.RawMessage], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	assertion, err := r.WebAuthn.BeginLogin(ctx, *admin)
+	if err != nil {
+		if errors.Is(err, cms.ErrWebAuthnNoCredentials) {
+			return nil, huma.Error404NotFound("No credentials registered")
+		}
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return r.raw(ctx, assertion)
+}
+
+func (r Auth) webAuthnAssertion(ctx context.Context, in *WebAuthnAssertion) (*Response[Session], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	ip := r.IPExtractor(in.request)
+	keys := []string{cms.ThrottleEmailKey(admin.Email), cms.ThrottleIPKey(ip)}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
+	}
+	defer r.done(ctx, keys...)
+
+	if _, err := r.WebAuthn.FinishLogin(ctx, *admin, in.Body.Credential); err != nil {
+		return nil, r.fail(ctx, err, admin.Email, ip, keys...)
+	}
+	r.reset(ctx, cms.ThrottleEmailKey(admin.Email))
+
+	return r.secondFactor(ctx, *admin, in.Client)
+}
+
+func (r Auth) recovery(ctx context.Context, in *RecoveryCodeInput) (*Response[Session], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	ip := r.IPExtractor(in.request)
+	keys := []string{cms.ThrottleEmailKey(admin.Email), cms.ThrottleIPKey(ip)}
+	if err := r.allow(ctx, keys...); err != nil {
+		return nil, err
+	}
+	defer r.done(ctx, keys...)
+
+	if err := r.RecoveryCodes.Use(ctx, admin.ID, in.Body.Code); err != nil {
+		if errors.Is(err, cms.ErrRecoveryCodeInvalid) {
+			return nil, r.fail(ctx, err, admin.Email, ip, keys...)
+		}
+		return nil, r.error(err)
+	}
+	r.reset(ctx, cms.ThrottleEmailKey(admin.Email))
+
+	return r.secondFactor(ctx, *admin, in.Client)
+}
+
+func (r Auth) recoveryCodes(ctx context.Context, _ *struct{}) (*Response[RecoveryCodes], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	remaining, err := r.RecoveryCodes.Remaining(ctx, admin.ID)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &Response[RecoveryCodes]{Body: RecoveryCodes{Remaining: remaining}}, nil
+}
+
+func (r Auth) generateRecoveryCodes(ctx context.Context, _ *struct{}) (*Response[RecoveryCodes], error) {
+	admin := cms.CtxAdmin(ctx)
+	if admin == nil {
+		return nil, r.error(errors.New("invalid context, admin not found"))
+	}
+
+	codes, err := r.RecoveryCodes.Generate(ctx, admin.ID)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &Response[RecoveryCodes]{Body: RecoveryCodes{Codes: codes, Remaining: len(codes)}}, nil
+}
+
+func (r Auth) raw(ctx context.Context, v any) (*Response[json.RawMessage], error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, ErrorTransformer(ctx, err)
+	}
+	return &Response[json.RawMessage]{Body: raw}, nil
+}
@@ -0,0 +1,99 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const APITokenPrefix = "cms_"
+
+var ErrAPITokenInvalid = errors.New("invalid api token")
+
+type APITokenService struct {
+	repo   repository.APIToken
+	admins repository.Admin
+
+	// TouchInterval limits how often the last use of a token is written.
+	TouchInterval time.Duration
+}
+
+func NewAPITokenService(repo repository.APIToken, admins repository.Admin) *APITokenService {
+	if repo == nil {
+		panic("api token repository is not specified")
+	}
+	if admins == nil {
+		panic("admin repository is not specified")
+	}
+	return &APITokenService{repo: repo, admins: admins, TouchInterval: time.Minute}
+}
+
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+func HashAPIToken(token string) string {
+	return hashToken(token)
+}
+
+// Issue creates the token and returns its secret, the secret cannot be recovered later.
+func (s *APITokenService) Issue(ctx context.Context, m *model.APIToken) (string, error) {
+	if _, err := s.admins.FindByID(ctx, m.AdminID); err != nil {
+		return "", err
+	}
+
+	secret, err := randomToken(24)
+	if err != nil {
+		return "", err
+	}
+	token := APITokenPrefix + secret
+
+	m.Prefix = token[:len(APITokenPrefix)+8]
+	m.Hash = HashAPIToken(token)
+	m.Revoked, m.LastUsed, m.LastUsedIP = nil, nil, ""
+
+	if err = s.repo.Create(ctx, m); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *APITokenService) Revoke(ctx context.Context, ids ...int64) error {
+	return s.repo.Revoke(ctx, time.Now(), ids...)
+}
+
+// Authenticate returns the active token and its admin and records the use of the token.
+func (s *APITokenService) Authenticate(ctx context.Context, token, ip string) (model.APIToken, model.Admin, error) {
+	m, err := s.repo.FindByHash(ctx, HashAPIToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			err = errors.Join(ErrAPITokenInvalid, err)
+		}
+		return model.APIToken{}, model.Admin{}, err
+	}
+
+	now := time.Now()
+	if !m.IsActive(now) {
+		return model.APIToken{}, model.Admin{}, ErrAPITokenInvalid
+	}
+
+	admin, err := s.admins.FindByID(ctx, m.AdminID)
+	if err != nil {
+		return model.APIToken{}, model.Admin{}, err
+	}
+	if admin.IsDisabled() {
+		return model.APIToken{}, model.Admin{}, model.ErrAdminDisabled
+	}
+
+	if m.LastUsed == nil || now.Sub(*m.LastUsed) >= s.TouchInterval || m.LastUsedIP != ip {
+		if err = s.repo.Touch(ctx, m.ID, now, ip); err != nil {
+			return model.APIToken{}, model.Admin{}, err
+		}
+		m.LastUsed, m.LastUsedIP = &now, ip
+	}
+	return m, admin, nil
+}
@@ -0,0 +1,63 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	cacherepo "github.com/gowool/cms/repository/cache"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestAPITokenService_RevokeWhileAuthenticating(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+	admin := newAdmin(t, admins, "admin@example.com", "password")
+
+	repo := cacherepo.NewAPITokenRepository(memory.NewAPITokenRepository(), cache.NewLRU(100, 0, nil))
+	service := cms.NewAPITokenService(repo, admins)
+	service.TouchInterval = 0
+
+	m := model.APIToken{AdminID: admin.ID, Name: "ci", Role: model.RoleAdmin}
+	token, err := service.Issue(ctx, &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := service.Authenticate(ctx, token, fmt.Sprintf("10.0.0.%d", i))
+			if err != nil && !errors.Is(err, cms.ErrAPITokenInvalid) {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := service.Revoke(ctx, m.ID); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+
+	if _, _, err = service.Authenticate(ctx, token, "10.0.0.1"); !errors.Is(err, cms.ErrAPITokenInvalid) {
+		t.Fatalf("err = %v, the revoked token is still accepted", err)
+	}
+
+	saved, err := repo.FindByID(ctx, m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Revoked == nil {
+		t.Fatal("the revocation is lost")
+	}
+}
@@ -24,6 +24,9 @@ func BasicAuthValidator(repo repository.Admin) middleware.BasicAuthValidator {
 		if err = admin.Password.Validate(password); err != nil {
 			return false, err
 		}
+		if admin.IsDisabled() {
+			return false, model.ErrAdminDisabled
+		}
 
 		ctx = WithAdmin(ctx, &admin)
 		ctx = WithClaims(ctx, &Claims{
@@ -37,7 +40,16 @@ func BasicAuthValidator(repo repository.Admin) middleware.BasicAuthValidator {
 	}
 }
 
+// ClaimSessionID is the claim of the access token holding the family of its refresh token.
+const ClaimSessionID = "sid"
+
 func JWTAuthValidator(repo repository.Admin, secret string) func(string, echo.Context) (bool, error) {
+	return JWTSessionAuthValidator(repo, nil, secret)
+}
+
+// JWTSessionAuthValidator is JWTAuthValidator that also rejects the tokens of the revoked sessions,
+// a nil sessions skips the check.
+func JWTSessionAuthValidator(repo repository.Admin, sessions *AdminSessionService, secret string) func(string, echo.Context) (bool, error) {
 	return func(token string, c echo.Context) (bool, error) {
 		r := c.Request()
 		ctx := r.Context()
@@ -58,12 +70,27 @@ func JWTAuthValidator(repo repository.Admin, secret string) func(string, echo.Co
 			if _, err = ParseJWT(token, admin.Salt+secret); err != nil {
 				return false, err
 			}
+			if admin.IsDisabled() {
+				return false, model.ErrAdminDisabled
+			}
+
+			familyID := cast.ToString(claimsValue(claims, ClaimSessionID))
+			if sessions != nil {
+				session, err := sessions.Current(ctx, familyID)
+				if err != nil {
+					return false, err
+				}
+				if session.AdminID != admin.ID {
+					return false, ErrSessionInvalid
+				}
+			}
 
 			ctx = WithAdmin(ctx, &admin)
 			ctx = WithClaims(ctx, &Claims{
-				Subject: &admin,
-				Scheme:  JWTScheme,
-				TwoFA:   cast.ToBool(claimsValue(claims, "2fa")),
+				Subject:  &admin,
+				Scheme:   JWTScheme,
+				TwoFA:    cast.ToBool(claimsValue(claims, "2fa")),
+				Metadata: map[string]any{ClaimSessionID: familyID},
 			})
 
 			c.SetRequest(r.WithContext(ctx))
@@ -74,6 +101,33 @@ func JWTAuthValidator(repo repository.Admin, secret string) func(string, echo.Co
 	}
 }
 
+func APITokenAuthValidator(service *APITokenService) func(string, echo.Context) (bool, error) {
+	return func(token string, c echo.Context) (bool, error) {
+		if !IsAPIToken(token) {
+			return false, nil
+		}
+
+		r := c.Request()
+		ctx := r.Context()
+
+		m, admin, err := service.Authenticate(ctx, token, c.RealIP())
+		if err != nil {
+			return false, err
+		}
+
+		ctx = WithAdmin(ctx, &admin)
+		ctx = WithAPIToken(ctx, &m)
+		ctx = WithClaims(ctx, &Claims{
+			Subject: &admin,
+			Scheme:  TokenScheme,
+		})
+
+		c.SetRequest(r.WithContext(ctx))
+
+		return true, nil
+	}
+}
+
 func claimsValue(claims jwt.MapClaims, key string) any {
 	if v, ok := claims[key]; ok {
 		return v
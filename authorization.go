@@ -12,6 +12,7 @@ const (
 	UnknownScheme AuthScheme = iota
 	BasicScheme
 	JWTScheme
+	TokenScheme
 )
 
 type Decision int8
@@ -41,6 +42,9 @@ const (
 	AccessRead
 	AccessWrite
 	AccessAdmin
+	// AccessSelf allows the signed-in admins of any role, the calls are about the caller itself,
+	// e.g. the second factor of the admins granted the sites only.
+	AccessSelf
 )
 
 type Claims struct {
@@ -81,6 +85,8 @@ func (a *TargetAccess) Decide(_ context.Context, claims *Claims) (Decision, erro
 	role := model.RoleGuest
 	if claims.Subject != nil {
 		role = claims.Subject.Role
+	} else if a.Access == AccessSelf {
+		return DecisionDeny, nil
 	}
 
 	if role >= getRequiredRole(a.Access) {
@@ -89,6 +95,26 @@ func (a *TargetAccess) Decide(_ context.Context, claims *Claims) (Decision, erro
 	return DecisionDeny, nil
 }
 
+// TokenAccess limits a call authenticated with an api token to the scopes of the token
+// before deferring to the decider of the admin.
+type TokenAccess struct {
+	Decider
+	Resource string
+	Access   Access
+}
+
+func NewTokenAccess(resource string, access Access, decider Decider) *TokenAccess {
+	return &TokenAccess{Decider: decider, Resource: resource, Access: access}
+}
+
+func (a *TokenAccess) Decide(ctx context.Context, claims *Claims) (Decision, error) {
+	token := CtxAPIToken(ctx)
+	if token == nil || a.Access == AccessUnknown || !token.Allows(a.Resource, getRequiredRole(a.Access)) {
+		return DecisionDeny, nil
+	}
+	return a.Decider.Decide(ctx, claims)
+}
+
 type CallTarget struct {
 	OperationID string
 	Access      map[AuthScheme]Decider
@@ -100,10 +126,19 @@ func NewCallTarget(access Access) *CallTarget {
 		Access: map[AuthScheme]Decider{
 			BasicScheme: NewDecider(access, false),
 			JWTScheme:   NewDecider(access, true),
+			TokenScheme: NewTokenAccess("", access, NewDecider(access, false)),
 		},
 	}
 }
 
+// NewScopedCallTarget is the call target of a resource, api tokens reach it when their
+// resources include the resource.
+func NewScopedCallTarget(resource string, access Access) *CallTarget {
+	target := NewCallTarget(access)
+	target.Access[TokenScheme] = NewTokenAccess(resource, access, NewDecider(access, false))
+	return target
+}
+
 type Authorizer interface {
 	Authorize(ctx context.Context, claims *Claims, target *CallTarget) (Decision, error)
 }
@@ -128,7 +163,7 @@ func (*DefaultAuthorizer) Authorize(ctx context.Context, claims *Claims, target
 
 func getRequiredRole(access Access) model.Role {
 	switch access {
-	case AccessPublic:
+	case AccessPublic, AccessSelf:
 		return model.RoleGuest
 	case AccessPrivate, AccessRead:
 		return model.RoleReader
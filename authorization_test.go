@@ -0,0 +1,40 @@
+package cms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+)
+
+func TestTargetAccess_Decide(t *testing.T) {
+	guest := &model.Admin{Role: model.RoleGuest}
+	reader := &model.Admin{Role: model.RoleReader}
+
+	tests := []struct {
+		name    string
+		access  cms.Access
+		subject *model.Admin
+		want    cms.Decision
+	}{
+		{"public anonymous", cms.AccessPublic, nil, cms.DecisionAllow},
+		{"private guest", cms.AccessPrivate, guest, cms.DecisionDeny},
+		{"private reader", cms.AccessPrivate, reader, cms.DecisionAllow},
+		{"self anonymous", cms.AccessSelf, nil, cms.DecisionDeny},
+		{"self guest", cms.AccessSelf, guest, cms.DecisionAllow},
+		{"read guest", cms.AccessRead, guest, cms.DecisionDeny},
+		{"unknown reader", cms.AccessUnknown, reader, cms.DecisionDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cms.NewTargetAccess(tt.access, false).Decide(context.Background(), &cms.Claims{Subject: tt.subject})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
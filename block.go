@@ -0,0 +1,96 @@
+package cms
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+var _ BlockTypes = (*DefaultBlockTypes)(nil)
+
+type HTMLBlockSettings struct {
+	Content string `json:"content" yaml:"content" required:"true"`
+}
+
+type ContainerBlockSettings struct {
+	Class string `json:"class,omitempty" yaml:"class,omitempty" required:"false"`
+}
+
+// Built-in block types, their templates live in the templates/block directory.
+var (
+	HTMLBlock = BlockType{
+		Name:     "html",
+		Label:    "HTML",
+		Template: "@block/html.gohtml",
+		Settings: HTMLBlockSettings{},
+	}
+	ContainerBlock = BlockType{
+		Name:     "container",
+		Label:    "Container",
+		Template: "@block/container.gohtml",
+		Settings: ContainerBlockSettings{},
+	}
+)
+
+// BlockType describes a kind of content blocks. The template is rendered with
+// the block, its settings and the already rendered nested blocks as children.
+type BlockType struct {
+	Name     string
+	Label    string
+	Template string
+	// Settings is a value of the settings struct, its type describes
+	// the schema the settings of the blocks are validated against,
+	// nil allows any settings.
+	Settings any
+}
+
+type BlockTypes interface {
+	Register(types ...BlockType)
+	Get(name string) (BlockType, bool)
+	All() []BlockType
+}
+
+type DefaultBlockTypes struct {
+	mu    sync.RWMutex
+	types map[string]BlockType
+}
+
+// NewDefaultBlockTypes registers the built-in block types followed by the given ones,
+// a type registered later replaces the one with the same name.
+func NewDefaultBlockTypes(types ...BlockType) *DefaultBlockTypes {
+	r := &DefaultBlockTypes{types: make(map[string]BlockType)}
+	r.Register(HTMLBlock, ContainerBlock)
+	r.Register(types...)
+	return r
+}
+
+func (r *DefaultBlockTypes) Register(types ...BlockType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range types {
+		r.types[t.Name] = t
+	}
+}
+
+func (r *DefaultBlockTypes) Get(name string) (BlockType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.types[name]
+	return t, ok
+}
+
+func (r *DefaultBlockTypes) All() []BlockType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]BlockType, 0, len(r.types))
+	for _, t := range r.types {
+		types = append(types, t)
+	}
+	slices.SortFunc(types, func(a, b BlockType) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return types
+}
@@ -0,0 +1,126 @@
+package cms
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SitesTag is attached to every cached host lookup, so a site that becomes
+// visible is picked up even though it is not part of the cached list yet.
+const SitesTag = "cms::site:tag:hosts"
+
+// MenusTag is attached to every cached menu and menu tree, the nodes link to pages
+// by uri, so a page becoming visible or hidden changes any of them.
+const MenusTag = "cms::menu:tag:all"
+
+func SiteTag(id int64) string {
+	return fmt.Sprintf("cms::site:tag:%d", id)
+}
+
+func PageTag(id int64) string {
+	return fmt.Sprintf("cms::page:tag:%d", id)
+}
+
+// CacheCounter is implemented by the caches counting atomically, e.g. to reserve
+// the attempts of a throttled key across the instances.
+type CacheCounter interface {
+	// Incr adds delta to the counter of the key and returns the new value,
+	// the ttl of the context, see WithCacheTTL, is renewed by every increment.
+	Incr(ctx context.Context, key string, delta int64) (int64, error)
+}
+
+type (
+	cacheTagsKey struct{}
+	cacheTTLKey  struct{}
+)
+
+// CacheTags collects the tags of the cached entities read while handling a request,
+// e.g. to tag the rendered page with everything it depends on.
+type CacheTags struct {
+	mu     sync.Mutex
+	tags   map[string]struct{}
+	parent *CacheTags
+}
+
+func (t *CacheTags) Add(tags ...string) {
+	t.mu.Lock()
+	if t.tags == nil {
+		t.tags = make(map[string]struct{}, len(tags))
+	}
+	for _, tag := range tags {
+		t.tags[tag] = struct{}{}
+	}
+	t.mu.Unlock()
+
+	if t.parent != nil {
+		t.parent.Add(tags...)
+	}
+}
+
+func (t *CacheTags) All() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tags := make([]string, 0, len(t.tags))
+	for tag := range t.tags {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// WithCacheTags starts collecting the cache tags read with the returned context,
+// the collector of the context, if any, gets them as well.
+func WithCacheTags(ctx context.Context) (context.Context, *CacheTags) {
+	tags := &CacheTags{parent: CtxCacheTags(ctx)}
+	return context.WithValue(ctx, cacheTagsKey{}, tags), tags
+}
+
+func CtxCacheTags(ctx context.Context) *CacheTags {
+	tags, _ := ctx.Value(cacheTagsKey{}).(*CacheTags)
+	return tags
+}
+
+// AddCacheTags adds the tags to the collector of the context, if any.
+func AddCacheTags(ctx context.Context, tags ...string) {
+	if t := CtxCacheTags(ctx); t != nil {
+		t.Add(tags...)
+	}
+}
+
+// WithCacheTTL limits the life of the entries set with the returned context, a ttl
+// not above zero expires them at once. The caches keeping no expiry ignore it.
+func WithCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, cacheTTLKey{}, ttl)
+}
+
+func CtxCacheTTL(ctx context.Context) (time.Duration, bool) {
+	ttl, ok := ctx.Value(cacheTTLKey{}).(time.Duration)
+	return ttl, ok
+}
+
+// counterOf returns the cache itself when it counts atomically, otherwise a counter atomic within the process only.
+func counterOf(cache Cache) CacheCounter {
+	if counter, ok := cache.(CacheCounter); ok {
+		return counter
+	}
+	return &localCounter{cache: cache}
+}
+
+type localCounter struct {
+	mu    sync.Mutex
+	cache Cache
+}
+
+func (c *localCounter) Incr(ctx context.Context, key string, delta int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int64
+	_ = c.cache.Get(ctx, key, &n)
+	n += delta
+	return n, c.cache.Set(ctx, key, n)
+}
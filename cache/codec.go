@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gowool/cms"
+)
+
+// ErrMiss is returned by Get when the key is missing or expired.
+var ErrMiss = errors.New("cache: miss")
+
+// Codec encodes the values kept by the caches.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var _ Codec = JSONCodec{}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// entryTTL returns the ttl of the entry set with the context, see cms.WithCacheTTL.
+// The ttl of the context passed already expires the entry at once instead of keeping it.
+func entryTTL(ctx context.Context, ttl time.Duration) time.Duration {
+	if d, ok := cms.CtxCacheTTL(ctx); ok {
+		return max(d, time.Millisecond)
+	}
+	return ttl
+}
@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gowool/cms"
+)
+
+var (
+	_ cms.Cache        = (*LRU)(nil)
+	_ cms.CacheCounter = (*LRU)(nil)
+)
+
+type lruItem struct {
+	key     string
+	value   []byte
+	tags    []string
+	expires time.Time
+}
+
+// LRU keeps the encoded entries in the process, the least recently used ones
+// are evicted once the size is reached. The key of an entry set again stays
+// in the sets of its former tags, as it does in Redis.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	codec Codec
+	items map[string]*list.Element
+	order *list.List
+	tags  map[string]map[string]struct{}
+}
+
+// NewLRU returns the cache of the size entries, the ttl limits the life of the entries
+// set without cms.WithCacheTTL, zero keeps them until they are evicted or deleted.
+func NewLRU(size int, ttl time.Duration, codec Codec) *LRU {
+	if size <= 0 {
+		panic("cache size is not specified")
+	}
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &LRU{
+		size:  size,
+		ttl:   ttl,
+		codec: codec,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		tags:  make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value any, tags ...string) error {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.set(key, data, tags, entryTTL(ctx, c.ttl))
+	return nil
+}
+
+func (c *LRU) Get(_ context.Context, key string, value any) error {
+	data, ok := c.get(key)
+	if !ok {
+		return ErrMiss
+	}
+	return c.codec.Unmarshal(data, value)
+}
+
+func (c *LRU) DelByKey(_ context.Context, key string) error {
+	c.del(key)
+	return nil
+}
+
+func (c *LRU) DelByTag(_ context.Context, tag string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.tags[tag] {
+		if e, ok := c.items[key]; ok {
+			c.remove(e)
+		}
+	}
+	delete(c.tags, tag)
+	return nil
+}
+
+// Incr adds delta to the counter of the key, the counter keeps its tags.
+func (c *LRU) Incr(ctx context.Context, key string, delta int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int64
+	if e, ok := c.items[key]; ok {
+		item := e.Value.(*lruItem)
+		if item.expires.IsZero() || time.Now().Before(item.expires) {
+			if err := c.codec.Unmarshal(item.value, &n); err != nil {
+				return 0, err
+			}
+		}
+	}
+	n += delta
+
+	data, err := c.codec.Marshal(n)
+	if err != nil {
+		return 0, err
+	}
+	c.store(key, data, nil, entryTTL(ctx, c.ttl))
+	return n, nil
+}
+
+// Len returns the number of the entries kept, the expired ones included until they are read or evicted.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Clear drops every entry.
+func (c *LRU) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	clear(c.tags)
+	c.order.Init()
+}
+
+func (c *LRU) set(key string, data []byte, tags []string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, data, tags, ttl)
+}
+
+func (c *LRU) store(key string, data []byte, tags []string, ttl time.Duration) {
+	tags = slices.Clone(tags)
+	if e, ok := c.items[key]; ok {
+		for _, tag := range e.Value.(*lruItem).tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		c.remove(e)
+	}
+
+	item := &lruItem{key: key, value: data, tags: tags}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	c.items[key] = c.order.PushFront(item)
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRU) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := e.Value.(*lruItem)
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		c.remove(e)
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return item.value, true
+}
+
+func (c *LRU) del(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if e, ok := c.items[key]; ok {
+			c.remove(e)
+		}
+	}
+}
+
+func (c *LRU) remove(e *list.Element) {
+	item := c.order.Remove(e).(*lruItem)
+	delete(c.items, item.key)
+
+	for _, tag := range item.tags {
+		if keys, ok := c.tags[tag]; ok {
+			delete(keys, item.key)
+			if len(keys) == 0 {
+				delete(c.tags, tag)
+			}
+		}
+	}
+}
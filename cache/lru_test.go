@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gowool/cms"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2, 0, nil)
+
+	_ = c.Set(ctx, "a", 1)
+	_ = c.Set(ctx, "b", 2)
+
+	var v int
+	if err := c.Get(ctx, "a", &v); err != nil || v != 1 {
+		t.Fatalf("got %d, %v, want 1", v, err)
+	}
+
+	_ = c.Set(ctx, "c", 3)
+	if err := c.Get(ctx, "b", &v); !errors.Is(err, ErrMiss) {
+		t.Fatalf("the least recently used entry is kept: %v", err)
+	}
+	if err := c.Get(ctx, "a", &v); err != nil {
+		t.Fatalf("the recently used entry is evicted: %v", err)
+	}
+}
+
+func TestLRU_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Hour, nil)
+
+	_ = c.Set(cms.WithCacheTTL(ctx, 10*time.Millisecond), "short", 1)
+	_ = c.Set(cms.WithCacheTTL(ctx, -time.Second), "expired", 1)
+	_ = c.Set(ctx, "default", 1)
+
+	time.Sleep(20 * time.Millisecond)
+
+	var v int
+	for _, key := range []string{"short", "expired"} {
+		if err := c.Get(ctx, key, &v); !errors.Is(err, ErrMiss) {
+			t.Fatalf("entry %q did not expire: %v", key, err)
+		}
+	}
+	if err := c.Get(ctx, "default", &v); err != nil {
+		t.Fatalf("entry of the default ttl expired: %v", err)
+	}
+}
+
+func TestLRU_ReSetKeyStaysInFormerTags(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, 0, nil)
+
+	_ = c.Set(ctx, "key", 1, "a")
+	_ = c.Set(ctx, "key", 2, "b")
+
+	if err := c.DelByTag(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	var v int
+	if err := c.Get(ctx, "key", &v); !errors.Is(err, ErrMiss) {
+		t.Fatalf("the entry set again is not deleted by its former tag: %v", err)
+	}
+	if len(c.tags) != 0 {
+		t.Fatalf("the tag sets are left behind: %v", c.tags)
+	}
+}
+
+func TestLRU_ClonesTags(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, 0, nil)
+
+	tags := []string{"a"}
+	_ = c.Set(ctx, "key", 1, tags...)
+	tags[0] = "b"
+
+	_ = c.DelByKey(ctx, "key")
+
+	if c.Len() != 0 || len(c.tags) != 0 {
+		t.Fatalf("got %d entries and tags %v, want none", c.Len(), c.tags)
+	}
+}
+
+func TestLRU_Incr(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, 0, nil)
+
+	for i := range 3 {
+		n, err := c.Incr(ctx, "counter", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64(2 * (i + 1)); n != want {
+			t.Fatalf("got %d, want %d", n, want)
+		}
+	}
+
+	var n int64
+	if err := c.Get(ctx, "counter", &n); err != nil || n != 6 {
+		t.Fatalf("got %d, %v, want 6", n, err)
+	}
+}
@@ -0,0 +1,289 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gowool/cms"
+)
+
+var (
+	_ cms.Cache        = (*Redis)(nil)
+	_ cms.CacheCounter = (*Redis)(nil)
+)
+
+// setScript sets the value and adds its key to the tag sets, the tag sets live as long as their longest entry.
+var setScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local exists = redis.call('EXISTS', KEYS[i])
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl > 0 then
+		local left = redis.call('PTTL', KEYS[i])
+		if exists == 0 or (left >= 0 and left < ttl) then
+			redis.call('PEXPIRE', KEYS[i], ttl)
+		end
+	else
+		redis.call('PERSIST', KEYS[i])
+	end
+end
+if ARGV[3] ~= '' then
+	redis.call('PUBLISH', ARGV[3], KEYS[1])
+end
+return 1
+`)
+
+// incrScript increments the counter and renews its ttl.
+var incrScript = redis.NewScript(`
+local n = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+if ARGV[3] ~= '' then
+	redis.call('PUBLISH', ARGV[3], KEYS[1])
+end
+return n
+`)
+
+// delByTagScript deletes the keys of the tag set and the set itself.
+var delByTagScript = redis.NewScript(`
+local keys = redis.call('SMEMBERS', KEYS[1])
+for i = 1, #keys, 1000 do
+	redis.call('DEL', unpack(keys, i, math.min(i + 999, #keys)))
+end
+redis.call('DEL', KEYS[1])
+if ARGV[1] ~= '' and #keys > 0 then
+	redis.call('PUBLISH', ARGV[1], table.concat(keys, '\n'))
+end
+return keys
+`)
+
+// Redis keeps the entries in redis, the keys of a tag are kept in a set updated along with
+// the entry by a script, so the tags of an entry are never lost. The key of an entry set
+// again stays in the sets of its former tags, deleting them drops the entry anyway.
+//
+// With L1 the entries read are kept in the process as well, every change is published to
+// Channel and the instances listening drop their copies, see Start.
+// The scripts touch the keys out of their arguments, a cluster needs a Prefix with a hash tag,
+// e.g. "{cms}:", to keep the keys in one slot.
+type Redis struct {
+	client redis.UniversalClient
+	codec  Codec
+
+	// Prefix is prepended to the keys of the entries and the tag sets.
+	Prefix string
+	// TTL limits the life of the entries set without cms.WithCacheTTL.
+	// Zero keeps them until they are deleted.
+	TTL time.Duration
+	// L1 keeps the entries read in the process. Optional.
+	L1 *LRU
+	// Channel is the channel the changes are published to. Optional.
+	Channel string
+
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+	listening atomic.Bool
+	// generation changes with every invalidation received, a value read before it
+	// changed may be stale and is not kept in L1.
+	generation atomic.Uint64
+}
+
+func NewRedis(client redis.UniversalClient, codec Codec) *Redis {
+	if client == nil {
+		panic("redis client is not specified")
+	}
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &Redis{client: client, codec: codec}
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value any, tags ...string) error {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, c.Prefix+key)
+	for _, tag := range tags {
+		keys = append(keys, c.tagKey(tag))
+	}
+
+	ttl := entryTTL(ctx, c.TTL)
+
+	c.drop(key)
+	return setScript.Run(ctx, c.client, keys, data, ttl.Milliseconds(), c.Channel).Err()
+}
+
+func (c *Redis) Get(ctx context.Context, key string, value any) error {
+	l1 := c.L1 != nil && c.listening.Load()
+	if l1 {
+		if data, ok := c.L1.get(key); ok {
+			return c.codec.Unmarshal(data, value)
+		}
+	}
+
+	generation := c.generation.Load()
+
+	var (
+		get  *redis.StringCmd
+		pttl *redis.DurationCmd
+	)
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, c.Prefix+key)
+		if l1 {
+			pttl = pipe.PTTL(ctx, c.Prefix+key)
+		}
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return ErrMiss
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := get.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if l1 && c.generation.Load() == generation {
+		ttl := c.L1.ttl
+		if left := pttl.Val(); left > 0 && (ttl <= 0 || left < ttl) {
+			ttl = left
+		}
+		c.L1.set(key, data, nil, ttl)
+	}
+
+	return c.codec.Unmarshal(data, value)
+}
+
+// Incr adds delta to the counter of the key, the counter is kept as a decimal the JSONCodec reads.
+func (c *Redis) Incr(ctx context.Context, key string, delta int64) (int64, error) {
+	c.drop(key)
+	return incrScript.Run(ctx, c.client, []string{c.Prefix + key}, delta, entryTTL(ctx, c.TTL).Milliseconds(), c.Channel).Int64()
+}
+
+func (c *Redis) DelByKey(ctx context.Context, key string) error {
+	c.drop(key)
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, c.Prefix+key)
+		if c.Channel != "" {
+			pipe.Publish(ctx, c.Channel, c.Prefix+key)
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *Redis) DelByTag(ctx context.Context, tag string) error {
+	keys, err := delByTagScript.Run(ctx, c.client, []string{c.tagKey(tag)}, c.Channel).StringSlice()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		c.drop(strings.TrimPrefix(key, c.Prefix))
+	}
+	return nil
+}
+
+// Start listens to the changes published to Channel by the instances, L1 is used
+// only while listening. It does nothing without L1 or Channel.
+func (c *Redis) Start(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil || c.L1 == nil || c.Channel == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.listen(ctx, c.client.Subscribe(ctx, c.Channel), c.done)
+
+	return nil
+}
+
+func (c *Redis) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel, c.done = nil, nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Redis) listen(ctx context.Context, pubsub *redis.PubSub, done chan struct{}) {
+	defer close(done)
+	defer func() {
+		c.listening.Store(false)
+		c.L1.Clear()
+		_ = pubsub.Close()
+	}()
+
+	ch := pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			c.generation.Add(1)
+
+			switch m := msg.(type) {
+			case *redis.Subscription:
+				// the changes published while reconnecting are lost
+				c.L1.Clear()
+				c.listening.Store(m.Kind == "subscribe")
+			case *redis.Message:
+				for _, key := range strings.Split(m.Payload, "\n") {
+					c.L1.del(strings.TrimPrefix(key, c.Prefix))
+				}
+			}
+		}
+	}
+}
+
+// drop deletes the copy of the entry kept in the process.
+func (c *Redis) drop(key string) {
+	if c.L1 != nil {
+		c.generation.Add(1)
+		c.L1.del(key)
+	}
+}
+
+func (c *Redis) tagKey(tag string) string {
+	return c.Prefix + "tags:" + tag
+}
@@ -0,0 +1,182 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func TestRedis_SetGetDel(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newRedis(t)
+	c := cache.NewRedis(client, nil)
+	c.Prefix = "cms:"
+
+	if err := c.Set(ctx, "key", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if !mr.Exists("cms:key") {
+		t.Fatal("the prefix is not applied")
+	}
+
+	var v string
+	if err := c.Get(ctx, "key", &v); err != nil || v != "value" {
+		t.Fatalf("got %q, %v, want value", v, err)
+	}
+
+	if err := c.DelByKey(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, "key", &v); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("got %v, want ErrMiss", err)
+	}
+}
+
+func TestRedis_TTL(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newRedis(t)
+	c := cache.NewRedis(client, nil)
+	c.TTL = time.Hour
+
+	_ = c.Set(ctx, "default", 1, "tag")
+	_ = c.Set(cms.WithCacheTTL(ctx, time.Minute), "short", 1, "tag")
+
+	if ttl := mr.TTL("default"); ttl != time.Hour {
+		t.Fatalf("got ttl %s, want %s", ttl, time.Hour)
+	}
+	if ttl := mr.TTL("short"); ttl != time.Minute {
+		t.Fatalf("got ttl %s, want %s", ttl, time.Minute)
+	}
+	// the tag set lives as long as its longest entry
+	if ttl := mr.TTL("tags:tag"); ttl != time.Hour {
+		t.Fatalf("got tag ttl %s, want %s", ttl, time.Hour)
+	}
+
+	mr.FastForward(2 * time.Minute)
+
+	var v int
+	if err := c.Get(ctx, "short", &v); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("got %v, want ErrMiss", err)
+	}
+	if err := c.Get(ctx, "default", &v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedis_DelByTag(t *testing.T) {
+	ctx := context.Background()
+	_, client := newRedis(t)
+	c := cache.NewRedis(client, nil)
+
+	_ = c.Set(ctx, "a", 1, "x")
+	_ = c.Set(ctx, "b", 1, "x", "y")
+	_ = c.Set(ctx, "c", 1, "y")
+	// the key set again stays in the set of its former tag
+	_ = c.Set(ctx, "c", 2, "z")
+
+	if err := c.DelByTag(ctx, "y"); err != nil {
+		t.Fatal(err)
+	}
+
+	var v int
+	for key, miss := range map[string]bool{"a": false, "b": true, "c": true} {
+		err := c.Get(ctx, key, &v)
+		if miss != errors.Is(err, cache.ErrMiss) {
+			t.Fatalf("key %q: got %v, want miss %t", key, err, miss)
+		}
+	}
+}
+
+func TestRedis_Incr(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newRedis(t)
+	c := cache.NewRedis(client, nil)
+
+	const n = 32
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Incr(cms.WithCacheTTL(ctx, time.Minute), "counter", 1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var v int64
+	if err := c.Get(ctx, "counter", &v); err != nil || v != n {
+		t.Fatalf("got %d, %v, want %d", v, err, n)
+	}
+	if ttl := mr.TTL("counter"); ttl != time.Minute {
+		t.Fatalf("got ttl %s, want %s", ttl, time.Minute)
+	}
+}
+
+func TestRedis_L1Invalidation(t *testing.T) {
+	ctx := context.Background()
+	_, client := newRedis(t)
+
+	instances := make([]*cache.Redis, 2)
+	for i := range instances {
+		c := cache.NewRedis(client, nil)
+		c.L1 = cache.NewLRU(10, 0, nil)
+		c.Channel = "cms:invalidate"
+		if err := c.Start(ctx); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = c.Stop(ctx) })
+		instances[i] = c
+	}
+	a, b := instances[0], instances[1]
+
+	_ = b.Set(ctx, "key", "old", "tag")
+
+	eventually(t, func() bool {
+		var v string
+		// reading keeps the entry in l1 once a listens
+		return a.Get(ctx, "key", &v) == nil && v == "old" && a.L1.Len() == 1
+	})
+
+	_ = b.Set(ctx, "key", "new", "tag")
+	eventually(t, func() bool {
+		var v string
+		return a.Get(ctx, "key", &v) == nil && v == "new"
+	})
+
+	_ = b.DelByTag(ctx, "tag")
+	eventually(t, func() bool {
+		var v string
+		return errors.Is(a.Get(ctx, "key", &v), cache.ErrMiss)
+	})
+}
+
+func eventually(t *testing.T, fn func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !fn() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
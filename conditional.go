@@ -0,0 +1,117 @@
+package cms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type lastModifiedKey struct{}
+
+// LastModified keeps the latest modification time of the entities read while handling a request.
+type LastModified struct {
+	mu   sync.Mutex
+	time time.Time
+}
+
+func (m *LastModified) Add(times ...time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range times {
+		if t.After(m.time) {
+			m.time = t
+		}
+	}
+}
+
+func (m *LastModified) Time() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.time
+}
+
+// WithLastModified starts collecting the modification times read with the returned context.
+func WithLastModified(ctx context.Context) (context.Context, *LastModified) {
+	m := new(LastModified)
+	return context.WithValue(ctx, lastModifiedKey{}, m), m
+}
+
+// AddLastModified adds the times to the collector of the context, if any.
+func AddLastModified(ctx context.Context, times ...time.Time) {
+	if m, ok := ctx.Value(lastModifiedKey{}).(*LastModified); ok {
+		m.Add(times...)
+	}
+}
+
+// ETag returns the strong entity tag of the body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// ParseLastModified returns the time of the Last-Modified header, zero if it is missing or malformed.
+func ParseLastModified(header http.Header) time.Time {
+	t, _ := http.ParseTime(header.Get(echo.HeaderLastModified))
+	return t
+}
+
+// SetValidators sets the ETag and the Last-Modified headers, a zero time is not sent.
+func SetValidators(header http.Header, etag string, lastModified time.Time) {
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// NotModified reports whether the validators of the response match the conditional GET or HEAD request,
+// If-None-Match takes precedence over If-Modified-Since.
+func NotModified(r *http.Request, header http.Header) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETag(candidate) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	lastModified := ParseLastModified(header)
+	return !lastModified.IsZero() && !lastModified.Truncate(time.Second).After(ims)
+}
+
+// WriteNotModified answers 304 with the headers a 200 response would carry, except the ones of the body.
+func WriteNotModified(c echo.Context) error {
+	header := c.Response().Header()
+	header.Del(echo.HeaderContentType)
+	header.Del(echo.HeaderContentLength)
+	return c.NoContent(http.StatusNotModified)
+}
+
+// weakETag strips the weak indicator, If-None-Match uses the weak comparison.
+func weakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
@@ -20,6 +20,9 @@ type (
 	adminKey          struct{}
 	authClaimsKey     struct{}
 	urlKey            struct{}
+	previewKey        struct{}
+	auditKey          struct{}
+	apiTokenKey       struct{}
 )
 
 func WithDebug(ctx context.Context, debug bool) context.Context {
@@ -124,3 +127,32 @@ func CtxURL(ctx context.Context) url.URL {
 	u, _ := ctx.Value(urlKey{}).(url.URL)
 	return u
 }
+
+func WithPreview(ctx context.Context, preview bool) context.Context {
+	return context.WithValue(ctx, previewKey{}, preview)
+}
+
+func CtxPreview(ctx context.Context) bool {
+	preview, _ := ctx.Value(previewKey{}).(bool)
+	return preview
+}
+
+// WithAudit marks the context of an admin API call to be audited, the entry carries the
+// fields of the call shared by every change it makes.
+func WithAudit(ctx context.Context, entry model.AuditLog) context.Context {
+	return context.WithValue(ctx, auditKey{}, entry)
+}
+
+func CtxAudit(ctx context.Context) (model.AuditLog, bool) {
+	entry, ok := ctx.Value(auditKey{}).(model.AuditLog)
+	return entry, ok
+}
+
+func WithAPIToken(ctx context.Context, token *model.APIToken) context.Context {
+	return context.WithValue(ctx, apiTokenKey{}, token)
+}
+
+func CtxAPIToken(ctx context.Context) *model.APIToken {
+	token, _ := ctx.Value(apiTokenKey{}).(*model.APIToken)
+	return token
+}
@@ -0,0 +1,12 @@
+package fx
+
+import (
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+func NewAdminSessionService(repo repository.AdminSession, cfg JWTConfig) *cms.AdminSessionService {
+	cfg.InitDefaults()
+
+	return cms.NewAdminSessionService(repo, cfg.SessionDuration, cfg.RefreshTokenDuration)
+}
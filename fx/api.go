@@ -1,41 +1,176 @@
 package fx
 
 import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/gowool/cms"
 	"github.com/gowool/cms/api"
+	"github.com/gowool/cms/model"
 	"github.com/gowool/cms/repository"
 )
 
-func NewAuthAPI(cache cms.Cache, r repository.Admin, cfg JWTConfig, logger *zap.Logger) api.Auth {
-	return api.NewAuth(r, cache, cfg.Secret, cfg.AccessTokenDuration, logger)
+// APIPermissions restricts the admin APIs to the permissions of the caller when provided.
+type APIPermissions struct {
+	fx.In
+	Permissions cms.Permissions `optional:"true"`
 }
 
-func NewAdminAPI(r repository.Admin) api.Admin {
-	return api.NewAdmin(r, api.ErrorTransformer)
+type AuthAPIParams struct {
+	fx.In
+	Repository    repository.Admin
+	Sessions      *cms.AdminSessionService
+	IPExtractor   echo.IPExtractor
+	Config        JWTConfig
+	Throttle      *cms.Throttle             `optional:"true"`
+	AuditLog      repository.AuditLog       `optional:"true"`
+	WebAuthn      *cms.WebAuthnService      `optional:"true"`
+	RecoveryCodes *cms.RecoveryCodeService  `optional:"true"`
+	OIDC          *cms.OIDCService          `optional:"true"`
+	PasswordReset *cms.PasswordResetService `optional:"true"`
+	Logger        *zap.Logger
+}
+
+func NewAuthAPI(params AuthAPIParams) api.Auth {
+	h := api.NewAuth(params.Repository, params.Sessions, params.Config.Secret, params.Config.AccessTokenDuration, params.Logger)
+	h.IPExtractor = params.IPExtractor
+	h.Throttle = params.Throttle
+	h.AuditLog = params.AuditLog
+	h.WebAuthn = params.WebAuthn
+	h.RecoveryCodes = params.RecoveryCodes
+	h.OIDC = params.OIDC
+	h.PasswordReset = params.PasswordReset
+	return h
+}
+
+type AdminAPIParams struct {
+	fx.In
+	Repository repository.Admin
+	Sessions   *cms.AdminSessionService `optional:"true"`
+}
+
+func NewAdminAPI(params AdminAPIParams) api.Admin {
+	h := api.NewAdmin(params.Repository, api.ErrorTransformer)
+	h.Sessions = params.Sessions
+	return h
+}
+
+func NewInvitationAPI(r repository.AdminInvitation, service *cms.InvitationService) api.Invitation {
+	return api.NewInvitation(r, service, api.ErrorTransformer)
+}
+
+type MeAPIParams struct {
+	fx.In
+	Repository repository.Admin
+	Config     MeConfig                 `optional:"true"`
+	Sessions   *cms.AdminSessionService `optional:"true"`
+}
+
+func NewMeAPI(params MeAPIParams) api.Me {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	h := api.NewMe(params.Repository, cfg.OTPIssuer, api.ErrorTransformer)
+	h.Sessions = params.Sessions
+	h.AvatarMaxSize = cfg.AvatarMaxSize
+	return h
 }
 
 func NewConfigurationAPI(r repository.Configuration) api.Configuration {
 	return api.NewConfiguration(r, api.ErrorTransformer)
 }
 
-func NewPageAPI(r repository.Page, cfg repository.Configuration) api.Page {
-	return api.NewPage(r, cfg, api.ErrorTransformer)
+func NewPageAPI(r repository.Page, cfg repository.Configuration, p APIPermissions) api.Page {
+	h := api.NewPage(r, cfg, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
+}
+
+func NewSiteAPI(r repository.Site, p APIPermissions) api.Site {
+	h := api.NewSite(r, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
 }
 
-func NewSiteAPI(r repository.Site) api.Site {
-	return api.NewSite(r, api.ErrorTransformer)
+func NewTemplateAPI(r repository.Template, p APIPermissions) api.Template {
+	h := api.NewTemplate(r, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
 }
 
-func NewTemplateAPI(r repository.Template) api.Template {
-	return api.NewTemplate(r, api.ErrorTransformer)
+func NewMenuAPI(r repository.Menu, p APIPermissions) api.Menu {
+	h := api.NewMenu(r, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
 }
 
-func NewMenuAPI(r repository.Menu) api.Menu {
-	return api.NewMenu(r, api.ErrorTransformer)
+func NewNodeAPI(r repository.Node, p APIPermissions) api.Node {
+	h := api.NewNode(r, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
 }
 
-func NewNodeAPI(r repository.Node) api.Node {
-	return api.NewNode(r, api.ErrorTransformer)
+func NewRedirectAPI(r repository.Redirect, p APIPermissions) api.Redirect {
+	h := api.NewRedirect(r, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
+}
+
+func NewBlockAPI(r repository.Block, types cms.BlockTypes, p APIPermissions) api.Block {
+	h := api.NewBlock(r, types, api.ErrorTransformer)
+	h.Permissions = p.Permissions
+	return h
+}
+
+func NewMediaAPI(r repository.Media, s *cms.MediaService) api.Media {
+	return api.NewMedia(r, s, api.ErrorTransformer)
+}
+
+func NewTranslationGroupAPI(r repository.TranslationGroup, s *cms.TranslationService) api.TranslationGroup {
+	return api.NewTranslationGroup(r, s, api.ErrorTransformer)
+}
+
+func NewAuditLogAPI(r repository.AuditLog) api.AuditLog {
+	return api.NewAuditLog(r, api.ErrorTransformer)
+}
+
+func NewPermissionAPI(r repository.Permission) api.Permission {
+	return api.NewPermission(r, api.ErrorTransformer)
+}
+
+func NewAPITokenAPI(r repository.APIToken, s *cms.APITokenService) api.APIToken {
+	return api.NewAPIToken(r, s, api.ErrorTransformer)
+}
+
+func NewSearchAPI(r repository.Search, siteRepository repository.Site) api.Search {
+	return api.NewSearch(r, siteRepository, api.ErrorTransformer)
+}
+
+func NewPageRevisionAPI(revisions repository.PageRevision, r repository.Page) api.Revision[model.Page] {
+	return api.NewPageRevision(revisions, r, api.ErrorTransformer)
+}
+
+func NewTemplateRevisionAPI(revisions repository.TemplateRevision, r repository.Template) api.Revision[model.Template] {
+	return api.NewTemplateRevision(revisions, r, api.ErrorTransformer)
+}
+
+type PageDraftAPIParams struct {
+	fx.In
+	DraftRepository repository.PageDraft
+	PageRepository  repository.Page
+	Preview         PreviewConfig `optional:"true"`
+}
+
+func NewPageDraftAPI(params PageDraftAPIParams) api.PageDraft {
+	cfg := params.Preview
+	cfg.InitDefaults()
+
+	if cfg.Secret == "" {
+		panic("preview secret is not specified")
+	}
+
+	h := api.NewPageDraft(params.DraftRepository, params.PageRepository, cfg.Secret, cfg.Duration, api.ErrorTransformer)
+	h.PreviewParam = cfg.Param
+	return h
 }
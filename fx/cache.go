@@ -0,0 +1,47 @@
+package fx
+
+import (
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+)
+
+type LRUCacheParams struct {
+	fx.In
+	Config LRUCacheConfig `optional:"true"`
+	Codec  cache.Codec    `optional:"true"`
+}
+
+func NewLRUCache(params LRUCacheParams) cms.Cache {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	return cache.NewLRU(cfg.Size, cfg.TTL, params.Codec)
+}
+
+type RedisCacheParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Config    RedisCacheConfig `optional:"true"`
+	Codec     cache.Codec      `optional:"true"`
+	Client    redis.UniversalClient
+}
+
+func NewRedisCache(params RedisCacheParams) cms.Cache {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	c := cache.NewRedis(params.Client, params.Codec)
+	c.Prefix = cfg.Prefix
+	c.TTL = cfg.TTL
+	c.Channel = cfg.Channel
+	if cfg.L1.Size > 0 {
+		c.L1 = cache.NewLRU(cfg.L1.Size, cfg.L1.TTL, params.Codec)
+	}
+
+	params.Lifecycle.Append(fx.StartStopHook(c.Start, c.Stop))
+
+	return c
+}
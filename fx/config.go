@@ -361,6 +361,7 @@ type JWTConfig struct {
 	Secret               string        `json:"secret,omitempty" yaml:"secret,omitempty"`
 	AccessTokenDuration  time.Duration `json:"access_token_duration,omitempty" yaml:"access_token_duration,omitempty"`
 	RefreshTokenDuration time.Duration `json:"refresh_token_duration,omitempty" yaml:"refresh_token_duration,omitempty"`
+	SessionDuration      time.Duration `json:"session_duration,omitempty" yaml:"session_duration,omitempty"`
 }
 
 func (cfg *JWTConfig) InitDefaults() {
@@ -373,4 +374,259 @@ func (cfg *JWTConfig) InitDefaults() {
 	if cfg.RefreshTokenDuration == 0 {
 		cfg.RefreshTokenDuration = 60 * time.Minute
 	}
+	if cfg.SessionDuration == 0 {
+		cfg.SessionDuration = 7 * 24 * time.Hour
+	}
+}
+
+type PreviewConfig struct {
+	// Secret signs the preview links, it has no default since the instances have to share it.
+	Secret   string        `json:"secret,omitempty" yaml:"secret,omitempty"`
+	Param    string        `json:"param,omitempty" yaml:"param,omitempty"`
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+func (cfg *PreviewConfig) InitDefaults() {
+	if cfg.Param == "" {
+		cfg.Param = "preview"
+	}
+	if cfg.Duration == 0 {
+		cfg.Duration = 24 * time.Hour
+	}
+}
+
+type PageCacheConfig struct {
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// Vary lists the request headers the pages differ by, e.g. Accept-Language.
+	Vary []string `json:"vary,omitempty" yaml:"vary,omitempty"`
+	// Query lists the query parameters the pages differ by, e.g. page.
+	Query   []string `json:"query,omitempty" yaml:"query,omitempty"`
+	MaxSize int      `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+}
+
+func (cfg *PageCacheConfig) InitDefaults() {
+	if cfg.TTL == 0 {
+		cfg.TTL = 10 * time.Minute
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 1 << 20
+	}
+}
+
+type PurgeWebhookConfig struct {
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Header is sent with every request, e.g. the authorization of the webhook.
+	Header  map[string]string `json:"header,omitempty" yaml:"header,omitempty"`
+	Timeout time.Duration     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Interval is the time the keys are collected for before they are purged in batches of BatchSize.
+	Interval  time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+	BatchSize int           `json:"batch_size,omitempty" yaml:"batch_size,omitempty"`
+}
+
+func (cfg *PurgeWebhookConfig) InitDefaults() {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+}
+
+type LRUCacheConfig struct {
+	Size int           `json:"size,omitempty" yaml:"size,omitempty"`
+	TTL  time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+func (cfg *LRUCacheConfig) InitDefaults() {
+	if cfg.Size <= 0 {
+		cfg.Size = 10000
+	}
+}
+
+type RedisCacheConfig struct {
+	// Prefix is prepended to the redis keys, a cluster needs a hash tag, e.g. "{cms}:".
+	Prefix  string        `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	TTL     time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Channel string        `json:"channel,omitempty" yaml:"channel,omitempty"`
+	// L1 keeps the entries read in the process, a zero size disables it.
+	L1 LRUCacheConfig `json:"l1,omitempty" yaml:"l1,omitempty"`
+}
+
+func (cfg *RedisCacheConfig) InitDefaults() {
+	if cfg.Channel == "" {
+		cfg.Channel = "cms::cache:invalidate"
+	}
+	if cfg.L1.Size > 0 && cfg.L1.TTL <= 0 {
+		cfg.L1.TTL = time.Minute
+	}
+}
+
+type ThrottleConfig struct {
+	BaseDelay   time.Duration `json:"base_delay,omitempty" yaml:"base_delay,omitempty"`
+	MaxDelay    time.Duration `json:"max_delay,omitempty" yaml:"max_delay,omitempty"`
+	MaxFailures int           `json:"max_failures,omitempty" yaml:"max_failures,omitempty"`
+	Lockout     time.Duration `json:"lockout,omitempty" yaml:"lockout,omitempty"`
+	Window      time.Duration `json:"window,omitempty" yaml:"window,omitempty"`
+}
+
+func (cfg *ThrottleConfig) InitDefaults() {
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = time.Minute
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = 5
+	}
+	if cfg.Lockout <= 0 {
+		cfg.Lockout = 15 * time.Minute
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = time.Hour
+	}
+}
+
+type WebAuthnConfig struct {
+	// RPID is the domain of the admin panel without the scheme and the port.
+	RPID          string   `json:"rp_id,omitempty" yaml:"rp_id,omitempty"`
+	RPDisplayName string   `json:"rp_display_name,omitempty" yaml:"rp_display_name,omitempty"`
+	RPOrigins     []string `json:"rp_origins,omitempty" yaml:"rp_origins,omitempty"`
+	// Timeout limits the time between the two steps of a ceremony.
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func (cfg *WebAuthnConfig) InitDefaults() {
+	if cfg.RPDisplayName == "" {
+		cfg.RPDisplayName = "CMS"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Minute
+	}
+}
+
+type OIDCConfig struct {
+	Issuer       string   `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	ClientID     string   `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty" yaml:"client_secret,omitempty"`
+	RedirectURL  string   `json:"redirect_url,omitempty" yaml:"redirect_url,omitempty"`
+	Scopes       []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	// Domains restricts the emails to the domains when set.
+	Domains []string `json:"domains,omitempty" yaml:"domains,omitempty"`
+	// AutoProvision creates the admins signing in for the first time with DefaultRole.
+	AutoProvision bool   `json:"auto_provision,omitempty" yaml:"auto_provision,omitempty"`
+	DefaultRole   string `json:"default_role,omitempty" yaml:"default_role,omitempty"`
+	// TwoFAMethods are the values of the amr claim satisfying the second factor, e.g. mfa.
+	TwoFAMethods     []string      `json:"two_fa_methods,omitempty" yaml:"two_fa_methods,omitempty"`
+	StateTimeout     time.Duration `json:"state_timeout,omitempty" yaml:"state_timeout,omitempty"`
+	DiscoveryTimeout time.Duration `json:"discovery_timeout,omitempty" yaml:"discovery_timeout,omitempty"`
+}
+
+func (cfg *OIDCConfig) InitDefaults() {
+	if cfg.DefaultRole == "" {
+		cfg.DefaultRole = "reader"
+	}
+	if cfg.StateTimeout <= 0 {
+		cfg.StateTimeout = 10 * time.Minute
+	}
+	if cfg.DiscoveryTimeout <= 0 {
+		cfg.DiscoveryTimeout = 10 * time.Second
+	}
+}
+
+type MailerConfig struct {
+	// Dir keeps the mails as eml files when set, otherwise the mails are logged.
+	Dir  string `json:"dir,omitempty" yaml:"dir,omitempty"`
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+}
+
+type PasswordResetConfig struct {
+	// URL is the page of the admin panel the token is passed to in the token query parameter.
+	URL     string        `json:"url,omitempty" yaml:"url,omitempty"`
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func (cfg *PasswordResetConfig) InitDefaults() {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Hour
+	}
+}
+
+type InvitationConfig struct {
+	// URL is the page of the admin panel the token is passed to in the token query parameter,
+	// the invitations are mailed when set.
+	URL       string        `json:"url,omitempty" yaml:"url,omitempty"`
+	Timeout   time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	OTPIssuer string        `json:"otp_issuer,omitempty" yaml:"otp_issuer,omitempty"`
+}
+
+func (cfg *InvitationConfig) InitDefaults() {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 7 * 24 * time.Hour
+	}
+	if cfg.OTPIssuer == "" {
+		cfg.OTPIssuer = "CMS"
+	}
+}
+
+type MeConfig struct {
+	// OTPIssuer is the issuer shown by the authenticator apps.
+	OTPIssuer     string `json:"otp_issuer,omitempty" yaml:"otp_issuer,omitempty"`
+	AvatarMaxSize int64  `json:"avatar_max_size,omitempty" yaml:"avatar_max_size,omitempty"`
+}
+
+func (cfg *MeConfig) InitDefaults() {
+	if cfg.OTPIssuer == "" {
+		cfg.OTPIssuer = "CMS"
+	}
+	if cfg.AvatarMaxSize <= 0 {
+		cfg.AvatarMaxSize = 2 << 20
+	}
+}
+
+type SchedulerConfig struct {
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+func (cfg *SchedulerConfig) InitDefaults() {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+}
+
+type MediaConfig struct {
+	// Dir is the directory of the local media storage.
+	Dir string `json:"dir,omitempty" yaml:"dir,omitempty"`
+	// Path is the route of the media handler within the static area.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// BaseURL is the address media URLs start with, e.g. a CDN in front of the handler.
+	// Optional. Default value is the static area base path followed by Path.
+	BaseURL      string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	MaxSize      int64  `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	MaxDimension int    `json:"max_dimension,omitempty" yaml:"max_dimension,omitempty"`
+	Quality      int    `json:"quality,omitempty" yaml:"quality,omitempty"`
+	// Secret signs the sizes of the image derivatives in the media urls, it has to be
+	// the same on every instance. No derivatives are generated without it.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+func (cfg *MediaConfig) InitDefaults() {
+	if cfg.Dir == "" {
+		cfg.Dir = "media"
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/media"
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 32 << 20
+	}
+	if cfg.MaxDimension <= 0 {
+		cfg.MaxDimension = 2048
+	}
+	if cfg.Quality <= 0 || cfg.Quality > 100 {
+		cfg.Quality = 85
+	}
 }
@@ -55,3 +55,9 @@ func HumaAuthorizationMiddleware(authorizer cms.Authorizer, logger *zap.Logger)
 		Logger:     logger,
 	}))
 }
+
+func HumaAuditMiddleware(ipExtractor echo.IPExtractor) HumaMiddleware {
+	return NewHumaMiddleware("audit", api.Audit(api.AuditConfig{
+		IPExtractor: ipExtractor,
+	}))
+}
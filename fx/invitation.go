@@ -0,0 +1,31 @@
+package fx
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+type InvitationParams struct {
+	fx.In
+	Config      InvitationConfig `optional:"true"`
+	JWT         JWTConfig
+	Repository  repository.AdminInvitation
+	Admins      repository.Admin
+	Permissions repository.Permission
+	Cache       cms.Cache  `name:"repository-cache"`
+	Mailer      cms.Mailer `optional:"true"`
+}
+
+func NewInvitationService(params InvitationParams) *cms.InvitationService {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	service := cms.NewInvitationService(params.Repository, params.Admins, params.Permissions, params.Cache, params.JWT.Secret)
+	service.Timeout = cfg.Timeout
+	service.Issuer = cfg.OTPIssuer
+	service.Mailer = params.Mailer
+	service.URL = cfg.URL
+	return service
+}
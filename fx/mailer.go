@@ -0,0 +1,41 @@
+package fx
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+type MailerParams struct {
+	fx.In
+	Config MailerConfig `optional:"true"`
+	Logger *zap.Logger
+}
+
+func NewMailer(params MailerParams) cms.Mailer {
+	if params.Config.Dir != "" {
+		return cms.NewFileMailer(params.Config.Dir, params.Config.From)
+	}
+	return cms.NewLogMailer(params.Logger)
+}
+
+type PasswordResetParams struct {
+	fx.In
+	Config     PasswordResetConfig
+	Repository repository.Admin
+	Mailer     cms.Mailer
+	Cache      cms.Cache                `name:"repository-cache"`
+	Sessions   *cms.AdminSessionService `optional:"true"`
+}
+
+func NewPasswordResetService(params PasswordResetParams) *cms.PasswordResetService {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	service := cms.NewPasswordResetService(params.Repository, params.Mailer, params.Cache, cfg.URL)
+	service.Timeout = cfg.Timeout
+	service.Sessions = params.Sessions
+	return service
+}
@@ -0,0 +1,58 @@
+package fx
+
+import (
+	"strings"
+
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+type MediaStorageParams struct {
+	fx.In
+	Config MediaConfig `optional:"true"`
+}
+
+func NewMediaStorage(params MediaStorageParams) cms.Storage {
+	cfg := params.Config
+	cfg.InitDefaults()
+	return cms.NewLocalStorage(cfg.Dir)
+}
+
+type MediaServiceParams struct {
+	fx.In
+	Config     MediaConfig `optional:"true"`
+	Areas      AreasConfig
+	Repository repository.Media
+	Storage    cms.Storage
+}
+
+func NewMediaService(params MediaServiceParams) *cms.MediaService {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = strings.TrimRight(params.Areas.Static.BasePath, "/") + cfg.Path
+	}
+
+	service := cms.NewMediaService(params.Repository, params.Storage, baseURL)
+	service.MaxSize = cfg.MaxSize
+	service.MaxDimension = cfg.MaxDimension
+	service.Quality = cfg.Quality
+	service.Secret = []byte(cfg.Secret)
+	return service
+}
+
+type MediaHandlerParams struct {
+	fx.In
+	Config  MediaConfig `optional:"true"`
+	Service *cms.MediaService
+}
+
+func NewMediaHandler(params MediaHandlerParams) *cms.MediaHandler {
+	cfg := params.Config
+	cfg.InitDefaults()
+	return cms.NewMediaHandler(params.Service, cfg.Path)
+}
@@ -205,16 +205,47 @@ func BasicAuthMiddleware(repo repository.Admin) Middleware {
 	}))
 }
 
-func JWTAuthMiddleware(repo repository.Admin, cfg JWTConfig) Middleware {
+type JWTAuthParams struct {
+	fx.In
+	Repository repository.Admin
+	Sessions   *cms.AdminSessionService `optional:"true"`
+	Config     JWTConfig
+}
+
+func JWTAuthMiddleware(params JWTAuthParams) Middleware {
 	return NewMiddleware("jwt_auth", middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
 		Skipper: func(c echo.Context) bool {
-			h := c.Request().Header.Get(echo.HeaderAuthorization)
-			return !strings.HasPrefix(strings.ToLower(h), "bearer ")
+			token, ok := bearerToken(c)
+			return !ok || cms.IsAPIToken(token)
 		},
-		Validator: cms.JWTAuthValidator(repo, cfg.Secret),
+		Validator: cms.JWTSessionAuthValidator(params.Repository, params.Sessions, params.Config.Secret),
+	}))
+}
+
+func APITokenAuthMiddleware(service *cms.APITokenService) Middleware {
+	return NewMiddleware("api_token_auth", middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Skipper: func(c echo.Context) bool {
+			token, ok := bearerToken(c)
+			return !ok || !cms.IsAPIToken(token)
+		},
+		Validator: cms.APITokenAuthValidator(service),
+	}))
+}
+
+func ThrottleMiddleware(throttle *cms.Throttle) Middleware {
+	return NewMiddleware("throttle", cmsmiddleware.Throttle(cmsmiddleware.ThrottleConfig{
+		Throttle: throttle,
 	}))
 }
 
+func bearerToken(c echo.Context) (string, bool) {
+	h := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(strings.ToLower(h), "bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(h[len("bearer "):]), true
+}
+
 func SessionMiddleware(sessionManager *scs.SessionManager) Middleware {
 	return NewMiddleware("session", cmsmiddleware.Session(cmsmiddleware.SessionConfig{
 		SessionManager: sessionManager,
@@ -233,13 +264,93 @@ type PageSelectorParams struct {
 	PageHandler    cms.PageHandler
 	CfgRepository  repository.Configuration
 	PageRepository repository.Page
+	PageDraft      repository.PageDraft `optional:"true"`
+	Preview        PreviewConfig        `optional:"true"`
 }
 
 func PageSelectorMiddleware(params PageSelectorParams) Middleware {
+	cfg := params.Preview
+	cfg.InitDefaults()
+
+	// the previews of the drafts are signed with the secret the page draft api uses
+	if params.PageDraft != nil && cfg.Secret == "" {
+		panic("preview secret is not specified")
+	}
+
 	return NewMiddleware("page_selector", cmsmiddleware.PageSelector(cmsmiddleware.PageSelectorConfig{
-		PageHandler:    params.PageHandler,
-		CfgRepository:  params.CfgRepository,
-		PageRepository: params.PageRepository,
+		PageHandler:     params.PageHandler,
+		CfgRepository:   params.CfgRepository,
+		PageRepository:  params.PageRepository,
+		DraftRepository: params.PageDraft,
+		PreviewSecret:   cfg.Secret,
+		PreviewParam:    cfg.Param,
+	}))
+}
+
+type PageCacheParams struct {
+	fx.In
+	Config         PageCacheConfig     `optional:"true"`
+	Cache          cms.Cache           `name:"repository-cache"`
+	CSRF           CSRFConfig          `optional:"true"`
+	SessionManager *scs.SessionManager `optional:"true"`
+}
+
+func PageCacheMiddleware(params PageCacheParams) Middleware {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	// the default of echo
+	cookies := []string{"_csrf"}
+	if params.CSRF.Cookie.Name != "" {
+		cookies[0] = params.CSRF.Cookie.Name
+	}
+	if params.SessionManager != nil {
+		cookies = append(cookies, params.SessionManager.Cookie.Name)
+	}
+
+	return NewMiddleware("page_cache", cmsmiddleware.PageCache(cmsmiddleware.PageCacheConfig{
+		Cache:          params.Cache,
+		TTL:            cfg.TTL,
+		Vary:           cfg.Vary,
+		Query:          cfg.Query,
+		Cookies:        cookies,
+		CSRFContextKey: params.CSRF.ContextKey,
+		MaxSize:        cfg.MaxSize,
+	}))
+}
+
+func SitemapMiddleware(cfgRepository repository.Configuration, siteRepository repository.Site, pageRepository repository.Page) Middleware {
+	return NewMiddleware("sitemap", cmsmiddleware.Sitemap(cmsmiddleware.SitemapConfig{
+		CfgRepository:  cfgRepository,
+		SiteRepository: siteRepository,
+		PageRepository: pageRepository,
+	}))
+}
+
+func RobotsMiddleware(siteRepository repository.Site) Middleware {
+	return NewMiddleware("robots", cmsmiddleware.Robots(cmsmiddleware.RobotsConfig{
+		SiteRepository: siteRepository,
+	}))
+}
+
+func NewRedirectHits(lc fx.Lifecycle, redirectRepository repository.Redirect, logger *zap.Logger) *cms.RedirectHits {
+	hits := cms.NewRedirectHits(redirectRepository, logger.Named("redirect"))
+
+	lc.Append(fx.StartStopHook(hits.Start, hits.Stop))
+
+	return hits
+}
+
+func RedirectMiddleware(redirectRepository repository.Redirect, hits *cms.RedirectHits) Middleware {
+	return NewMiddleware("redirect", cmsmiddleware.Redirect(cmsmiddleware.RedirectConfig{
+		RedirectRepository: redirectRepository,
+		Hits:               hits,
+	}))
+}
+
+func SearchIndexMiddleware(searchRepository repository.Search) Middleware {
+	return NewMiddleware("search_index", cmsmiddleware.SearchIndex(cmsmiddleware.SearchIndexConfig{
+		SearchRepository: searchRepository,
 	}))
 }
 
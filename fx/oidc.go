@@ -0,0 +1,35 @@
+package fx
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type OIDCParams struct {
+	fx.In
+	Config     OIDCConfig
+	Repository repository.Admin
+	Cache      cms.Cache `name:"repository-cache"`
+}
+
+func NewOIDCService(params OIDCParams) *cms.OIDCService {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	return cms.NewOIDCService(cms.OIDCConfig{
+		Issuer:           cfg.Issuer,
+		ClientID:         cfg.ClientID,
+		ClientSecret:     cfg.ClientSecret,
+		RedirectURL:      cfg.RedirectURL,
+		Scopes:           cfg.Scopes,
+		Domains:          cfg.Domains,
+		AutoProvision:    cfg.AutoProvision,
+		DefaultRole:      model.NewRole(cfg.DefaultRole),
+		TwoFAMethods:     cfg.TwoFAMethods,
+		StateTimeout:     cfg.StateTimeout,
+		DiscoveryTimeout: cfg.DiscoveryTimeout,
+	}, params.Repository, params.Cache)
+}
@@ -41,14 +41,221 @@ var (
 			fx.ParamTags("", `name:"repository-cache"`),
 		),
 	)
-	OptionAdminRepository    = fx.Provide(NewAdminRepository)
-	OptionTemplateRepository = fx.Provide(NewTemplateRepository)
-	OptionThemeRepository    = fx.Provide(NewThemeRepository)
+	OptionRedirectRepository = fx.Provide(
+		fx.Annotate(
+			NewRedirectRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionBlockRepository = fx.Provide(
+		fx.Annotate(
+			NewBlockRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionMediaRepository = fx.Provide(
+		fx.Annotate(
+			NewMediaRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionAPITokenRepository = fx.Provide(
+		fx.Annotate(
+			NewAPITokenRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionPermissionRepository = fx.Provide(
+		fx.Annotate(
+			NewPermissionRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionAdminRepository              = fx.Provide(NewAdminRepository)
+	OptionAdminSessionRepository       = fx.Provide(NewAdminSessionRepository)
+	OptionWebAuthnCredentialRepository = fx.Provide(NewWebAuthnCredentialRepository)
+	OptionRecoveryCodeRepository       = fx.Provide(NewRecoveryCodeRepository)
+	OptionAdminInvitationRepository    = fx.Provide(NewAdminInvitationRepository)
+	OptionTemplateRepository           = fx.Provide(NewTemplateRepository)
+	OptionThemeRepository              = fx.Provide(NewThemeRepository)
+	OptionPageRevisionRepository       = fx.Provide(NewPageRevisionRepository)
+	OptionTemplateRevisionRepository   = fx.Provide(NewTemplateRevisionRepository)
+	OptionPageRevisions                = fx.Decorate(DecoratePageRevisions)
+	OptionTemplateRevisions            = fx.Decorate(DecorateTemplateRevisions)
+	OptionPageDraftRepository          = fx.Provide(NewPageDraftRepository)
+	OptionTranslationGroupRepository   = fx.Provide(NewTranslationGroupRepository)
+	OptionAuditLogRepository           = fx.Provide(NewAuditLogRepository)
+	OptionPageRedirects                = fx.Decorate(DecoratePageRedirects)
+	OptionSearchRepository             = fx.Provide(NewSearchRepository)
+	OptionPageSearch                   = fx.Decorate(DecoratePageSearch)
+	OptionPageAudit                    = fx.Decorate(DecoratePageAudit)
+	OptionSiteAudit                    = fx.Decorate(DecorateSiteAudit)
+	OptionTemplateAudit                = fx.Decorate(DecorateTemplateAudit)
+	OptionMenuAudit                    = fx.Decorate(DecorateMenuAudit)
+	OptionNodeAudit                    = fx.Decorate(DecorateNodeAudit)
+	OptionAdminAudit                   = fx.Decorate(DecorateAdminAudit)
+	OptionConfigurationAudit           = fx.Decorate(DecorateConfigurationAudit)
+	OptionRedirectAudit                = fx.Decorate(DecorateRedirectAudit)
+	OptionBlockAudit                   = fx.Decorate(DecorateBlockAudit)
+	OptionMediaAudit                   = fx.Decorate(DecorateMediaAudit)
+	OptionTranslationGroupAudit        = fx.Decorate(DecorateTranslationGroupAudit)
+	OptionAPITokenAudit                = fx.Decorate(DecorateAPITokenAudit)
+	OptionWebAuthnCredentialAudit      = fx.Decorate(DecorateWebAuthnCredentialAudit)
+	OptionAdminInvitationAudit         = fx.Decorate(DecorateAdminInvitationAudit)
+
+	OptionSQLiteConfigurationRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteConfigurationRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteSiteRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteSiteRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLitePageRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLitePageRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteMenuRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteMenuRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteNodeRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteNodeRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteRedirectRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteRedirectRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteBlockRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteBlockRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteMediaRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteMediaRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteAPITokenRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLiteAPITokenRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLitePermissionRepository = fx.Provide(
+		fx.Annotate(
+			NewSQLitePermissionRepository,
+			fx.ParamTags("", `name:"repository-cache"`),
+		),
+	)
+	OptionSQLiteAdminRepository              = fx.Provide(NewSQLiteAdminRepository)
+	OptionSQLiteAdminSessionRepository       = fx.Provide(NewSQLiteAdminSessionRepository)
+	OptionSQLiteWebAuthnCredentialRepository = fx.Provide(NewSQLiteWebAuthnCredentialRepository)
+	OptionSQLiteRecoveryCodeRepository       = fx.Provide(NewSQLiteRecoveryCodeRepository)
+	OptionSQLiteAdminInvitationRepository    = fx.Provide(NewSQLiteAdminInvitationRepository)
+	OptionSQLiteTemplateRepository           = fx.Provide(NewSQLiteTemplateRepository)
+	OptionSQLitePageRevisionRepository       = fx.Provide(NewSQLitePageRevisionRepository)
+	OptionSQLiteTemplateRevisionRepository   = fx.Provide(NewSQLiteTemplateRevisionRepository)
+	OptionSQLitePageDraftRepository          = fx.Provide(NewSQLitePageDraftRepository)
+	OptionSQLiteTranslationGroupRepository   = fx.Provide(NewSQLiteTranslationGroupRepository)
+	OptionSQLiteAuditLogRepository           = fx.Provide(NewSQLiteAuditLogRepository)
+	OptionSQLiteSessionStore                 = fx.Provide(NewSQLiteSessionStore)
+
+	OptionMemoryConfigurationRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryConfigurationRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemorySiteRepository = fx.Provide(
+		fx.Annotate(
+			NewMemorySiteRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryPageRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryPageRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryMenuRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryMenuRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryNodeRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryNodeRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryRedirectRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryRedirectRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryBlockRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryBlockRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryMediaRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryMediaRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryAPITokenRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryAPITokenRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryPermissionRepository = fx.Provide(
+		fx.Annotate(
+			NewMemoryPermissionRepository,
+			fx.ParamTags(`name:"repository-cache"`),
+		),
+	)
+	OptionMemoryAdminRepository              = fx.Provide(NewMemoryAdminRepository)
+	OptionMemoryAdminSessionRepository       = fx.Provide(NewMemoryAdminSessionRepository)
+	OptionMemoryWebAuthnCredentialRepository = fx.Provide(NewMemoryWebAuthnCredentialRepository)
+	OptionMemoryRecoveryCodeRepository       = fx.Provide(NewMemoryRecoveryCodeRepository)
+	OptionMemoryAdminInvitationRepository    = fx.Provide(NewMemoryAdminInvitationRepository)
+	OptionMemoryTemplateRepository           = fx.Provide(NewMemoryTemplateRepository)
+	OptionMemoryPageRevisionRepository       = fx.Provide(NewMemoryPageRevisionRepository)
+	OptionMemoryTemplateRevisionRepository   = fx.Provide(NewMemoryTemplateRevisionRepository)
+	OptionMemoryPageDraftRepository          = fx.Provide(NewMemoryPageDraftRepository)
+	OptionMemoryTranslationGroupRepository   = fx.Provide(NewMemoryTranslationGroupRepository)
+	OptionMemoryAuditLogRepository           = fx.Provide(NewMemoryAuditLogRepository)
+	OptionMemorySessionStore                 = fx.Provide(NewMemorySessionStore)
+	OptionMemorySearchRepository             = fx.Provide(NewMemorySearchRepository)
 
 	OptionAuthorizer     = fx.Provide(fx.Annotate(cms.NewDefaultAuthorizer, fx.As(new(cms.Authorizer))))
 	OptionSessionStore   = fx.Provide(NewSessionStore)
 	OptionSessionManager = fx.Provide(NewSessionManager)
 	OptionSeeder         = fx.Provide(NewSeeder)
+	OptionScheduler      = fx.Provide(NewScheduler)
+	OptionSearchReindex  = fx.Invoke(SearchReindex)
 	OptionMenu           = fx.Provide(fx.Annotate(cms.NewDefaultMenu, fx.As(new(cms.Menu))))
 	OptionMatcher        = fx.Provide(
 		fx.Annotate(
@@ -64,6 +271,13 @@ var (
 			fx.ResultTags(`group:"menu-voter"`),
 		),
 	)
+	OptionBlockTypes = fx.Provide(
+		fx.Annotate(
+			cms.NewDefaultBlockTypes,
+			fx.As(new(cms.BlockTypes)),
+			fx.ParamTags(`group:"block-type"`),
+		),
+	)
 
 	OptionSiteSelector = fx.Provide(
 		fx.Annotate(
@@ -78,13 +292,32 @@ var (
 		),
 	)
 	OptionPageCreateHandler = fx.Provide(cms.NewPageCreateHandler)
+	OptionMediaStorage      = fx.Provide(NewMediaStorage)
+	OptionMediaService      = fx.Provide(NewMediaService)
+	OptionMediaHandler      = fx.Provide(AsStatic(NewMediaHandler))
+	OptionTranslations      = fx.Provide(cms.NewTranslationService)
+	OptionAPITokens         = fx.Provide(cms.NewAPITokenService)
+	OptionAdminSessions     = fx.Provide(NewAdminSessionService)
+	OptionThrottle          = fx.Provide(NewThrottle)
+	OptionWebAuthn          = fx.Provide(NewWebAuthnService)
+	OptionRecoveryCodes     = fx.Provide(cms.NewRecoveryCodeService)
+	OptionOIDC              = fx.Provide(NewOIDCService)
+	OptionMailer            = fx.Provide(NewMailer)
+	OptionPasswordReset     = fx.Provide(NewPasswordResetService)
+	OptionInvitations       = fx.Provide(NewInvitationService)
+	OptionWebhookPurger     = fx.Provide(NewWebhookPurger)
+	OptionPermissions       = fx.Provide(fx.Annotate(cms.NewDefaultPermissions, fx.As(new(cms.Permissions))))
 	OptionErrorHandler      = fx.Provide(cms.NewErrorHandler)
 	OptionErrorResolver     = fx.Provide(cms.ErrorResolver)
-	OptionRenderer          = fx.Provide(fx.Annotate(cms.NewRenderer, fx.As(new(echo.Renderer))))
+	OptionRenderer          = fx.Provide(NewRenderer)
 	OptionIPExtractor       = fx.Provide(IPExtractor)
 	OptionEcho              = fx.Provide(NewEcho)
 	OptionHandler           = fx.Provide(func(e *echo.Echo) http.Handler { return e })
 
+	OptionLRUCache   = fx.Provide(fx.Annotate(NewLRUCache, fx.ResultTags(`name:"repository-cache"`)))
+	OptionRedisCache = fx.Provide(fx.Annotate(NewRedisCache, fx.ResultTags(`name:"repository-cache"`)))
+	OptionPurgeCache = fx.Decorate(fx.Annotate(DecoratePurgeCache, fx.ResultTags(`name:"repository-cache"`)))
+
 	OptionThemeFuncMap = fx.Provide(fx.Annotate(FuncMap, fx.ResultTags(`group:"theme-func-map"`)))
 	OptionThemeLoader  = fx.Provide(fx.Annotate(theme.NewRepositoryLoader, fx.As(new(theme.Loader))))
 
@@ -99,25 +332,40 @@ var (
 	OptionCSRFMiddleware         = fx.Provide(AsMiddleware(CSRFMiddleware))
 	OptionBasicAuthMiddleware    = fx.Provide(AsMiddleware(BasicAuthMiddleware))
 	OptionJWTAuthMiddleware      = fx.Provide(AsMiddleware(JWTAuthMiddleware))
+	OptionAPITokenAuthMiddleware = fx.Provide(AsMiddleware(APITokenAuthMiddleware))
+	OptionThrottleMiddleware     = fx.Provide(AsMiddleware(ThrottleMiddleware))
 	OptionSessionMiddleware      = fx.Provide(AsMiddleware(SessionMiddleware))
 	OptionSiteSelectorMiddleware = fx.Provide(AsMiddleware(SiteSelectorMiddleware))
 	OptionPageSelectorMiddleware = fx.Provide(AsMiddleware(PageSelectorMiddleware))
+	OptionPageCacheMiddleware    = fx.Provide(AsMiddleware(PageCacheMiddleware))
+	OptionSitemapMiddleware      = fx.Provide(AsMiddleware(SitemapMiddleware))
+	OptionRobotsMiddleware       = fx.Provide(AsMiddleware(RobotsMiddleware))
+	OptionRedirectMiddleware     = fx.Provide(NewRedirectHits, AsMiddleware(RedirectMiddleware))
+	OptionSearchIndexMiddleware  = fx.Provide(AsMiddleware(SearchIndexMiddleware))
 	OptionHybridPageMiddleware   = fx.Provide(AsMiddleware(HybridPageMiddleware))
 
 	OptionHumaAuthorizationMiddleware = fx.Provide(AsHumaMiddleware(HumaAuthorizationMiddleware))
-	OptionHumaAdminAuthAPI            = fx.Provide(
-		fx.Annotate(
-			NewAuthAPI,
-			fx.As(new(HumaAPI)),
-			fx.ParamTags(`name:"admin-auth-cache"`),
-			fx.ResultTags(`group:"huma-admin-api"`),
-		),
-	)
-	OptionHumaAdminAdminAPI         = fx.Provide(AsHumaAdminAPI(NewAdminAPI))
-	OptionHumaAdminConfigurationAPI = fx.Provide(AsHumaAdminAPI(NewConfigurationAPI))
-	OptionHumaAdminSiteAPI          = fx.Provide(AsHumaAdminAPI(NewSiteAPI))
-	OptionHumaAdminPageAPI          = fx.Provide(AsHumaAdminAPI(NewPageAPI))
-	OptionHumaAdminTemplateAPI      = fx.Provide(AsHumaAdminAPI(NewTemplateAPI))
-	OptionHumaAdminMenuAPI          = fx.Provide(AsHumaAdminAPI(NewMenuAPI))
-	OptionHumaAdminNodeAPI          = fx.Provide(AsHumaAdminAPI(NewNodeAPI))
+	OptionHumaAuditMiddleware         = fx.Provide(AsHumaMiddleware(HumaAuditMiddleware))
+	OptionHumaSearchAPI               = fx.Provide(AsHumaAPI(NewSearchAPI))
+	OptionHumaAdminAuthAPI            = fx.Provide(AsHumaAdminAPI(NewAuthAPI))
+	OptionHumaAdminAdminAPI           = fx.Provide(AsHumaAdminAPI(NewAdminAPI))
+	OptionHumaAdminMeAPI              = fx.Provide(AsHumaAdminAPI(NewMeAPI))
+	OptionHumaAdminInvitationAPI      = fx.Provide(AsHumaAdminAPI(NewInvitationAPI))
+	OptionHumaAdminConfigurationAPI   = fx.Provide(AsHumaAdminAPI(NewConfigurationAPI))
+	OptionHumaAdminSiteAPI            = fx.Provide(AsHumaAdminAPI(NewSiteAPI))
+	OptionHumaAdminPageAPI            = fx.Provide(AsHumaAdminAPI(NewPageAPI))
+	OptionHumaAdminTemplateAPI        = fx.Provide(AsHumaAdminAPI(NewTemplateAPI))
+	OptionHumaAdminMenuAPI            = fx.Provide(AsHumaAdminAPI(NewMenuAPI))
+	OptionHumaAdminNodeAPI            = fx.Provide(AsHumaAdminAPI(NewNodeAPI))
+	OptionHumaAdminRedirectAPI        = fx.Provide(AsHumaAdminAPI(NewRedirectAPI))
+	OptionHumaAdminBlockAPI           = fx.Provide(AsHumaAdminAPI(NewBlockAPI))
+	OptionHumaAdminMediaAPI           = fx.Provide(AsHumaAdminAPI(NewMediaAPI))
+	OptionHumaAdminTranslationAPI     = fx.Provide(AsHumaAdminAPI(NewTranslationGroupAPI))
+	OptionHumaAdminAuditLogAPI        = fx.Provide(AsHumaAdminAPI(NewAuditLogAPI))
+	OptionHumaAdminPermissionAPI      = fx.Provide(AsHumaAdminAPI(NewPermissionAPI))
+	OptionHumaAdminAPITokenAPI        = fx.Provide(AsHumaAdminAPI(NewAPITokenAPI))
+
+	OptionHumaAdminPageRevisionAPI     = fx.Provide(AsHumaAdminAPI(NewPageRevisionAPI))
+	OptionHumaAdminTemplateRevisionAPI = fx.Provide(AsHumaAdminAPI(NewTemplateRevisionAPI))
+	OptionHumaAdminPageDraftAPI        = fx.Provide(AsHumaAdminAPI(NewPageDraftAPI))
 )
@@ -0,0 +1,42 @@
+package fx
+
+import (
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+)
+
+func NewWebhookPurger(cfg PurgeWebhookConfig) cms.Purger {
+	cfg.InitDefaults()
+
+	purger := cms.NewWebhookPurger(cfg.URL, &http.Client{Timeout: cfg.Timeout})
+	for name, value := range cfg.Header {
+		purger.Header.Set(name, value)
+	}
+	return purger
+}
+
+type PurgeCacheParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Config    PurgeWebhookConfig `optional:"true"`
+	Cache     cms.Cache          `name:"repository-cache"`
+	Purger    cms.Purger
+	Logger    *zap.Logger
+}
+
+func DecoratePurgeCache(params PurgeCacheParams) cms.Cache {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	c := cms.NewPurgeCache(params.Cache, params.Purger, params.Logger)
+	c.Interval = cfg.Interval
+	c.BatchSize = cfg.BatchSize
+
+	params.Lifecycle.Append(fx.StartStopHook(c.Start, c.Stop))
+
+	return c
+}
@@ -6,14 +6,21 @@ import (
 	"io/fs"
 
 	"github.com/gowool/theme"
+	"go.uber.org/zap"
 
 	"github.com/gowool/cms"
 	"github.com/gowool/cms/model"
 	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/audit"
 	cacherepo "github.com/gowool/cms/repository/cache"
 	"github.com/gowool/cms/repository/fallback"
 	fsrepo "github.com/gowool/cms/repository/fs"
+	"github.com/gowool/cms/repository/memory"
+	"github.com/gowool/cms/repository/redirect"
+	"github.com/gowool/cms/repository/revision"
+	"github.com/gowool/cms/repository/search"
 	"github.com/gowool/cms/repository/sql/pg"
+	"github.com/gowool/cms/repository/sql/sqlite"
 )
 
 func NewAdminRepository(db *sql.DB) repository.Admin {
@@ -65,6 +72,357 @@ func NewNodeRepository(db *sql.DB, c cms.Cache) repository.Node {
 	return cacherepo.NewNodeRepository(r, c)
 }
 
+func NewSQLiteAdminRepository(db *sql.DB) repository.Admin {
+	return sqlite.NewAdminRepository(db)
+}
+
+func NewSQLiteTemplateRepository(params TemplateRepositoryParams) repository.Template {
+	var r repository.Template = sqlite.NewTemplateRepository(params.DB)
+	for _, fsys := range params.FSS {
+		r = fsrepo.NewTemplateRepository(r, fsys)
+	}
+
+	if params.Debug {
+		return r
+	}
+	return cacherepo.NewTemplateRepository(r, params.Cache)
+}
+
+func NewSQLiteSiteRepository(db *sql.DB, c cms.Cache) repository.Site {
+	r := sqlite.NewSiteRepository(db)
+	return cacherepo.NewSiteRepository(r, c)
+}
+
+func NewSQLitePageRepository(db *sql.DB, c cms.Cache) repository.Page {
+	r := sqlite.NewPageRepository(db)
+	return cacherepo.NewPageRepository(r, c)
+}
+
+func NewSQLiteConfigurationRepository(db *sql.DB, c cms.Cache) repository.Configuration {
+	var r repository.Configuration = sqlite.NewConfigurationRepository(db)
+	r = fallback.NewConfigurationRepository(r, model.NewConfiguration())
+	return cacherepo.NewConfigurationRepository(r, c)
+}
+
+func NewSQLiteMenuRepository(db *sql.DB, c cms.Cache) repository.Menu {
+	r := sqlite.NewMenuRepository(db)
+	return cacherepo.NewMenuRepository(r, c)
+}
+
+func NewSQLiteNodeRepository(db *sql.DB, c cms.Cache) repository.Node {
+	r := sqlite.NewNodeRepository(db)
+	return cacherepo.NewNodeRepository(r, c)
+}
+
+func NewMemoryAdminRepository() repository.Admin {
+	return memory.NewAdminRepository()
+}
+
+func NewMemoryTemplateRepository(params TemplateRepositoryParams) repository.Template {
+	var r repository.Template = memory.NewTemplateRepository()
+	for _, fsys := range params.FSS {
+		r = fsrepo.NewTemplateRepository(r, fsys)
+	}
+
+	if params.Debug {
+		return r
+	}
+	return cacherepo.NewTemplateRepository(r, params.Cache)
+}
+
+func NewMemorySiteRepository(c cms.Cache) repository.Site {
+	r := memory.NewSiteRepository()
+	return cacherepo.NewSiteRepository(r, c)
+}
+
+func NewMemoryPageRepository(c cms.Cache) repository.Page {
+	r := memory.NewPageRepository()
+	return cacherepo.NewPageRepository(r, c)
+}
+
+func NewMemoryConfigurationRepository(c cms.Cache) repository.Configuration {
+	var r repository.Configuration = memory.NewConfigurationRepository()
+	r = fallback.NewConfigurationRepository(r, model.NewConfiguration())
+	return cacherepo.NewConfigurationRepository(r, c)
+}
+
+func NewMemoryMenuRepository(c cms.Cache) repository.Menu {
+	r := memory.NewMenuRepository()
+	return cacherepo.NewMenuRepository(r, c)
+}
+
+func NewMemoryNodeRepository(c cms.Cache) repository.Node {
+	r := memory.NewNodeRepository()
+	return cacherepo.NewNodeRepository(r, c)
+}
+
+func NewPageRevisionRepository(db *sql.DB) repository.PageRevision {
+	return pg.NewPageRevisionRepository(db)
+}
+
+func NewTemplateRevisionRepository(db *sql.DB) repository.TemplateRevision {
+	return pg.NewTemplateRevisionRepository(db)
+}
+
+func NewSQLitePageRevisionRepository(db *sql.DB) repository.PageRevision {
+	return sqlite.NewPageRevisionRepository(db)
+}
+
+func NewSQLiteTemplateRevisionRepository(db *sql.DB) repository.TemplateRevision {
+	return sqlite.NewTemplateRevisionRepository(db)
+}
+
+func NewMemoryPageRevisionRepository() repository.PageRevision {
+	return memory.NewPageRevisionRepository()
+}
+
+func NewMemoryTemplateRevisionRepository() repository.TemplateRevision {
+	return memory.NewTemplateRevisionRepository()
+}
+
+func NewPageDraftRepository(db *sql.DB) repository.PageDraft {
+	return pg.NewPageDraftRepository(db)
+}
+
+func NewSQLitePageDraftRepository(db *sql.DB) repository.PageDraft {
+	return sqlite.NewPageDraftRepository(db)
+}
+
+func NewMemoryPageDraftRepository() repository.PageDraft {
+	return memory.NewPageDraftRepository()
+}
+
+func NewRedirectRepository(db *sql.DB, c cms.Cache) repository.Redirect {
+	r := pg.NewRedirectRepository(db)
+	return cacherepo.NewRedirectRepository(r, c)
+}
+
+func NewSQLiteRedirectRepository(db *sql.DB, c cms.Cache) repository.Redirect {
+	r := sqlite.NewRedirectRepository(db)
+	return cacherepo.NewRedirectRepository(r, c)
+}
+
+func NewMemoryRedirectRepository(c cms.Cache) repository.Redirect {
+	r := memory.NewRedirectRepository()
+	return cacherepo.NewRedirectRepository(r, c)
+}
+
+func NewBlockRepository(db *sql.DB, c cms.Cache) repository.Block {
+	r := pg.NewBlockRepository(db)
+	return cacherepo.NewBlockRepository(r, c)
+}
+
+func NewSQLiteBlockRepository(db *sql.DB, c cms.Cache) repository.Block {
+	r := sqlite.NewBlockRepository(db)
+	return cacherepo.NewBlockRepository(r, c)
+}
+
+func NewMemoryBlockRepository(c cms.Cache) repository.Block {
+	r := memory.NewBlockRepository()
+	return cacherepo.NewBlockRepository(r, c)
+}
+
+func NewMediaRepository(db *sql.DB, c cms.Cache) repository.Media {
+	r := pg.NewMediaRepository(db)
+	return cacherepo.NewMediaRepository(r, c)
+}
+
+func NewSQLiteMediaRepository(db *sql.DB, c cms.Cache) repository.Media {
+	r := sqlite.NewMediaRepository(db)
+	return cacherepo.NewMediaRepository(r, c)
+}
+
+func NewMemoryMediaRepository(c cms.Cache) repository.Media {
+	r := memory.NewMediaRepository()
+	return cacherepo.NewMediaRepository(r, c)
+}
+
+func NewPermissionRepository(db *sql.DB, c cms.Cache) repository.Permission {
+	r := pg.NewPermissionRepository(db)
+	return cacherepo.NewPermissionRepository(r, c)
+}
+
+func NewSQLitePermissionRepository(db *sql.DB, c cms.Cache) repository.Permission {
+	r := sqlite.NewPermissionRepository(db)
+	return cacherepo.NewPermissionRepository(r, c)
+}
+
+func NewMemoryPermissionRepository(c cms.Cache) repository.Permission {
+	r := memory.NewPermissionRepository()
+	return cacherepo.NewPermissionRepository(r, c)
+}
+
+func NewAPITokenRepository(db *sql.DB, c cms.Cache) repository.APIToken {
+	r := pg.NewAPITokenRepository(db)
+	return cacherepo.NewAPITokenRepository(r, c)
+}
+
+func NewSQLiteAPITokenRepository(db *sql.DB, c cms.Cache) repository.APIToken {
+	r := sqlite.NewAPITokenRepository(db)
+	return cacherepo.NewAPITokenRepository(r, c)
+}
+
+func NewMemoryAPITokenRepository(c cms.Cache) repository.APIToken {
+	r := memory.NewAPITokenRepository()
+	return cacherepo.NewAPITokenRepository(r, c)
+}
+
+func NewAdminSessionRepository(db *sql.DB) repository.AdminSession {
+	return pg.NewAdminSessionRepository(db)
+}
+
+func NewSQLiteAdminSessionRepository(db *sql.DB) repository.AdminSession {
+	return sqlite.NewAdminSessionRepository(db)
+}
+
+func NewMemoryAdminSessionRepository() repository.AdminSession {
+	return memory.NewAdminSessionRepository()
+}
+
+func NewWebAuthnCredentialRepository(db *sql.DB) repository.WebAuthnCredential {
+	return pg.NewWebAuthnCredentialRepository(db)
+}
+
+func NewSQLiteWebAuthnCredentialRepository(db *sql.DB) repository.WebAuthnCredential {
+	return sqlite.NewWebAuthnCredentialRepository(db)
+}
+
+func NewMemoryWebAuthnCredentialRepository() repository.WebAuthnCredential {
+	return memory.NewWebAuthnCredentialRepository()
+}
+
+func NewRecoveryCodeRepository(db *sql.DB) repository.RecoveryCode {
+	return pg.NewRecoveryCodeRepository(db)
+}
+
+func NewSQLiteRecoveryCodeRepository(db *sql.DB) repository.RecoveryCode {
+	return sqlite.NewRecoveryCodeRepository(db)
+}
+
+func NewMemoryRecoveryCodeRepository() repository.RecoveryCode {
+	return memory.NewRecoveryCodeRepository()
+}
+
+func NewAdminInvitationRepository(db *sql.DB) repository.AdminInvitation {
+	return pg.NewAdminInvitationRepository(db)
+}
+
+func NewSQLiteAdminInvitationRepository(db *sql.DB) repository.AdminInvitation {
+	return sqlite.NewAdminInvitationRepository(db)
+}
+
+func NewMemoryAdminInvitationRepository() repository.AdminInvitation {
+	return memory.NewAdminInvitationRepository()
+}
+
+func NewTranslationGroupRepository(db *sql.DB) repository.TranslationGroup {
+	return pg.NewTranslationGroupRepository(db)
+}
+
+func NewSQLiteTranslationGroupRepository(db *sql.DB) repository.TranslationGroup {
+	return sqlite.NewTranslationGroupRepository(db)
+}
+
+func NewMemoryTranslationGroupRepository() repository.TranslationGroup {
+	return memory.NewTranslationGroupRepository()
+}
+
+func NewAuditLogRepository(db *sql.DB) repository.AuditLog {
+	return pg.NewAuditLogRepository(db)
+}
+
+func NewSQLiteAuditLogRepository(db *sql.DB) repository.AuditLog {
+	return sqlite.NewAuditLogRepository(db)
+}
+
+func NewMemoryAuditLogRepository() repository.AuditLog {
+	return memory.NewAuditLogRepository()
+}
+
+func NewSearchRepository(db *sql.DB) repository.Search {
+	return pg.NewSearchRepository(db)
+}
+
+// NewMemorySearchRepository provides the in-process search index, it is not persisted,
+// so it is rebuilt on start, see SearchReindex.
+func NewMemorySearchRepository() repository.Search {
+	return memory.NewSearchRepository()
+}
+
+func DecoratePageSearch(r repository.Page, sites repository.Site, index repository.Search, logger *zap.Logger) repository.Page {
+	return search.NewPageRepository(r, sites, index, logger)
+}
+
+func DecoratePageRedirects(r repository.Page, redirects repository.Redirect, logger *zap.Logger) repository.Page {
+	return redirect.NewPageRepository(r, redirects, logger)
+}
+
+func DecoratePageRevisions(r repository.Page, revisions repository.PageRevision, logger *zap.Logger) repository.Page {
+	return revision.NewPageRepository(r, revisions, logger)
+}
+
+func DecorateTemplateRevisions(r repository.Template, revisions repository.TemplateRevision, logger *zap.Logger) repository.Template {
+	return revision.NewTemplateRepository(r, revisions, logger)
+}
+
+func DecoratePageAudit(r repository.Page, log repository.AuditLog, logger *zap.Logger) repository.Page {
+	return audit.NewPageRepository(r, log, logger)
+}
+
+func DecorateSiteAudit(r repository.Site, log repository.AuditLog, logger *zap.Logger) repository.Site {
+	return audit.NewSiteRepository(r, log, logger)
+}
+
+func DecorateTemplateAudit(r repository.Template, log repository.AuditLog, logger *zap.Logger) repository.Template {
+	return audit.NewTemplateRepository(r, log, logger)
+}
+
+func DecorateMenuAudit(r repository.Menu, log repository.AuditLog, logger *zap.Logger) repository.Menu {
+	return audit.NewMenuRepository(r, log, logger)
+}
+
+func DecorateNodeAudit(r repository.Node, log repository.AuditLog, logger *zap.Logger) repository.Node {
+	return audit.NewNodeRepository(r, log, logger)
+}
+
+func DecorateAdminAudit(r repository.Admin, log repository.AuditLog, logger *zap.Logger) repository.Admin {
+	return audit.NewAdminRepository(r, log, logger)
+}
+
+func DecorateConfigurationAudit(r repository.Configuration, log repository.AuditLog, logger *zap.Logger) repository.Configuration {
+	return audit.NewConfigurationRepository(r, log, logger)
+}
+
+func DecorateRedirectAudit(r repository.Redirect, log repository.AuditLog, logger *zap.Logger) repository.Redirect {
+	return audit.NewRedirectRepository(r, log, logger)
+}
+
+func DecorateBlockAudit(r repository.Block, log repository.AuditLog, logger *zap.Logger) repository.Block {
+	return audit.NewBlockRepository(r, log, logger)
+}
+
+func DecorateMediaAudit(r repository.Media, log repository.AuditLog, logger *zap.Logger) repository.Media {
+	return audit.NewMediaRepository(r, log, logger)
+}
+
+func DecorateTranslationGroupAudit(r repository.TranslationGroup, log repository.AuditLog, logger *zap.Logger) repository.TranslationGroup {
+	return audit.NewTranslationGroupRepository(r, log, logger)
+}
+
+func DecorateWebAuthnCredentialAudit(r repository.WebAuthnCredential, log repository.AuditLog, logger *zap.Logger) repository.WebAuthnCredential {
+	return audit.NewWebAuthnCredentialRepository(r, log, logger)
+}
+
+func DecorateAdminInvitationAudit(r repository.AdminInvitation, log repository.AuditLog, logger *zap.Logger) repository.AdminInvitation {
+	return audit.NewAdminInvitationRepository(r, log, logger)
+}
+
+func DecorateAPITokenAudit(r repository.APIToken, log repository.AuditLog, logger *zap.Logger) repository.APIToken {
+	return audit.NewAPITokenRepository(r, log, logger)
+}
+
+// ThemeRepository looks up the templates of the theme, every template a page is rendered
+// with is looked up on every render, so it adds their change times to Last-Modified.
 type ThemeRepository struct {
 	r repository.Template
 }
@@ -74,5 +432,11 @@ func NewThemeRepository(r repository.Template) theme.Repository {
 }
 
 func (r ThemeRepository) FindByName(ctx context.Context, name string) (theme.Template, error) {
-	return r.r.FindByName(ctx, name)
+	m, err := r.r.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cms.AddLastModified(ctx, m.Changed())
+	return m, nil
 }
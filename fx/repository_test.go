@@ -0,0 +1,28 @@
+package fx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestThemeRepository_AddsLastModified(t *testing.T) {
+	templates := memory.NewTemplateRepository()
+
+	m := model.Template{Name: "page.html", Content: "page", Enabled: true}
+	if err := templates.Create(context.Background(), &m); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, lastModified := cms.WithLastModified(context.Background())
+	if _, err := NewThemeRepository(templates).FindByName(ctx, m.Name); err != nil {
+		t.Fatal(err)
+	}
+
+	if !lastModified.Time().Equal(m.Changed()) {
+		t.Fatalf("last modified = %s, want the change of the template %s", lastModified.Time(), m.Changed())
+	}
+}
@@ -0,0 +1,43 @@
+package fx
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+func AsScheduleListener(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(cms.ScheduleListener)),
+		fx.ResultTags(`group:"schedule-listener"`),
+	)
+}
+
+type SchedulerParams struct {
+	fx.In
+	Lifecycle      fx.Lifecycle
+	Config         SchedulerConfig `optional:"true"`
+	Cache          cms.Cache       `name:"repository-cache"`
+	SiteRepository repository.Site
+	PageRepository repository.Page
+	Listeners      []cms.ScheduleListener `group:"schedule-listener"`
+	Logger         *zap.Logger
+}
+
+func NewScheduler(params SchedulerParams) cms.Scheduler {
+	scheduler := cms.NewDefaultScheduler(
+		params.SiteRepository,
+		params.PageRepository,
+		params.Cache,
+		params.Config.Interval,
+		params.Logger.Named("scheduler"),
+		params.Listeners...,
+	)
+
+	params.Lifecycle.Append(fx.StartStopHook(scheduler.Start, scheduler.Stop))
+
+	return scheduler
+}
@@ -0,0 +1,26 @@
+package fx
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/search"
+)
+
+type SearchReindexParams struct {
+	fx.In
+	Lifecycle        fx.Lifecycle
+	PageRepository   repository.Page
+	SiteRepository   repository.Site
+	SearchRepository repository.Search
+}
+
+// SearchReindex rebuilds the search index when the application starts,
+// it is required by the in-process index which is empty on every start.
+func SearchReindex(params SearchReindexParams) {
+	params.Lifecycle.Append(fx.StartHook(func(ctx context.Context) error {
+		return search.Reindex(ctx, params.PageRepository, params.SiteRepository, params.SearchRepository)
+	}))
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/alexedwards/scs/postgresstore"
+	"github.com/alexedwards/scs/sqlite3store"
 	"github.com/alexedwards/scs/v2"
+	"github.com/alexedwards/scs/v2/memstore"
 	"go.uber.org/fx"
 )
 
@@ -56,3 +58,19 @@ func NewSessionStore(cfg SessionConfig, lc fx.Lifecycle, db *sql.DB) scs.Store {
 
 	return store
 }
+
+func NewSQLiteSessionStore(cfg SessionConfig, lc fx.Lifecycle, db *sql.DB) scs.Store {
+	store := sqlite3store.NewWithCleanupInterval(db, cfg.CleanupInterval)
+
+	lc.Append(fx.StopHook(store.StopCleanup))
+
+	return store
+}
+
+func NewMemorySessionStore(cfg SessionConfig, lc fx.Lifecycle) scs.Store {
+	store := memstore.NewWithCleanupInterval(cfg.CleanupInterval)
+
+	lc.Append(fx.StopHook(store.StopCleanup))
+
+	return store
+}
@@ -2,12 +2,54 @@ package fx
 
 import (
 	"github.com/gowool/theme"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
 
 	"github.com/gowool/cms"
 	"github.com/gowool/cms/repository"
 	cmstheme "github.com/gowool/cms/theme"
 )
 
-func FuncMap(pageRepo repository.Page, menu cms.Menu, matcher cms.Matcher) theme.FuncMap {
-	return cmstheme.NewFuncMap(pageRepo, menu, matcher).FuncMap
+type FuncMapParams struct {
+	fx.In
+	PageRepository   repository.Page
+	SearchRepository repository.Search `optional:"true"`
+	BlockRepository  repository.Block  `optional:"true"`
+	BlockTypes       cms.BlockTypes    `optional:"true"`
+	MediaService     *cms.MediaService `optional:"true"`
+	Menu             cms.Menu
+	Matcher          cms.Matcher
+}
+
+func FuncMap(params FuncMapParams) theme.FuncMap {
+	return cmstheme.NewFuncMap(cmstheme.FuncMapConfig{
+		PageRepository:   params.PageRepository,
+		Menu:             params.Menu,
+		Matcher:          params.Matcher,
+		SearchRepository: params.SearchRepository,
+		BlockRepository:  params.BlockRepository,
+		BlockTypes:       params.BlockTypes,
+		MediaService:     params.MediaService,
+	}).FuncMap
+}
+
+type RendererParams struct {
+	fx.In
+	Theme                   theme.Theme
+	ConfigurationRepository repository.Configuration
+	Translations            *cms.TranslationService `optional:"true"`
+}
+
+func NewRenderer(params RendererParams) echo.Renderer {
+	renderer := cms.NewRenderer(params.Theme, params.ConfigurationRepository)
+	renderer.Translations = params.Translations
+	return renderer
+}
+
+// AsBlockType registers the cms.BlockType returned by f in the block type registry.
+func AsBlockType(f any) any {
+	return fx.Annotate(
+		f,
+		fx.ResultTags(`group:"block-type"`),
+	)
 }
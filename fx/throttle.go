@@ -0,0 +1,26 @@
+package fx
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+)
+
+type ThrottleParams struct {
+	fx.In
+	Config ThrottleConfig `optional:"true"`
+	Cache  cms.Cache      `name:"repository-cache"`
+}
+
+func NewThrottle(params ThrottleParams) *cms.Throttle {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	throttle := cms.NewThrottle(params.Cache)
+	throttle.BaseDelay = cfg.BaseDelay
+	throttle.MaxDelay = cfg.MaxDelay
+	throttle.MaxFailures = cfg.MaxFailures
+	throttle.Lockout = cfg.Lockout
+	throttle.Window = cfg.Window
+	return throttle
+}
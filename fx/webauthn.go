@@ -0,0 +1,33 @@
+package fx
+
+import (
+	"github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/fx"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository"
+)
+
+type WebAuthnParams struct {
+	fx.In
+	Config     WebAuthnConfig
+	Repository repository.WebAuthnCredential
+	Cache      cms.Cache `name:"repository-cache"`
+}
+
+func NewWebAuthnService(params WebAuthnParams) (*cms.WebAuthnService, error) {
+	cfg := params.Config
+	cfg.InitDefaults()
+
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: cfg.Timeout, TimeoutUVD: cfg.Timeout}
+
+	return cms.NewWebAuthnService(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.RPOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	}, params.Repository, params.Cache)
+}
@@ -0,0 +1,76 @@
+package cms
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms/repository"
+)
+
+type MediaHandler struct {
+	service *MediaService
+	path    string
+}
+
+func NewMediaHandler(service *MediaService, path string) *MediaHandler {
+	if service == nil {
+		panic("media service is not specified")
+	}
+	if path == "" {
+		path = "/media"
+	}
+	return &MediaHandler{
+		service: service,
+		path:    path,
+	}
+}
+
+func (h *MediaHandler) Register(_ *echo.Echo, g *echo.Group) {
+	g.GET(h.path+"/*", h.Handle)
+	g.HEAD(h.path+"/*", h.Handle)
+}
+
+func (h *MediaHandler) Handle(c echo.Context) error {
+	key, err := url.PathUnescape(c.Param("*"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	opts, err := ParseMediaOptions(c.QueryParams())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	rc, contentType, err := h.service.Open(c.Request().Context(), key, opts, c.QueryParam("s"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, fs.ErrNotExist) {
+			return echo.ErrNotFound.WithInternal(err)
+		}
+		if errors.Is(err, ErrMediaInvalidSign) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
+		}
+		return err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	// keys are never reused, so the content behind an address does not change
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderCacheControl, "public, max-age=31536000, immutable")
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set(echo.HeaderContentSecurityPolicy, "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+
+	if rs, ok := rc.(io.ReadSeeker); ok {
+		http.ServeContent(c.Response(), c.Request(), "", time.Time{}, rs)
+		return nil
+	}
+	return c.Stream(http.StatusOK, contentType, rc)
+}
@@ -1,6 +1,7 @@
 package cms
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 
@@ -39,5 +40,39 @@ func (h *DefaultPageHandler) Handle(c echo.Context) error {
 		status = s
 	}
 
-	return c.Render(status, page.Template, nil)
+	renderer := c.Echo().Renderer
+	if renderer == nil {
+		return echo.ErrRendererNotRegistered
+	}
+
+	// the validators of a decorated hybrid page are combined with the ones of the page
+	w := c.Response()
+	ctx, lastModified := WithLastModified(ctx)
+	lastModified.Add(ParseLastModified(w.Header()))
+
+	tags := CtxCacheTags(ctx)
+	if tags == nil {
+		ctx, tags = WithCacheTags(ctx)
+	}
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, page.Template, nil, c); err != nil {
+		return err
+	}
+
+	tags.Add(SiteTag(site.ID), PageTag(page.ID))
+	SetSurrogateKeys(w.Header(), tags.All()...)
+
+	if status != http.StatusOK {
+		w.Header().Del("ETag")
+		w.Header().Del(echo.HeaderLastModified)
+		return c.HTMLBlob(status, buf.Bytes())
+	}
+
+	SetValidators(w.Header(), ETag(buf.Bytes()), lastModified.Time())
+	if NotModified(c.Request(), w.Header()) {
+		return WriteNotModified(c)
+	}
+	return c.HTMLBlob(status, buf.Bytes())
 }
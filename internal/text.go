@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var skipTextTags = map[string]struct{}{
+	"head":     {},
+	"script":   {},
+	"style":    {},
+	"noscript": {},
+	"template": {},
+	"svg":      {},
+	"nav":      {},
+}
+
+// PlainText extracts the visible text of an HTML document, when the document
+// has a main element only its text is returned.
+func PlainText(r io.Reader) string {
+	var (
+		all, main strings.Builder
+		skip      int
+		inMain    int
+	)
+
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if text := strings.Join(strings.Fields(main.String()), " "); text != "" {
+				return text
+			}
+			return strings.Join(strings.Fields(all.String()), " ")
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if _, ok := skipTextTags[string(name)]; ok {
+				skip++
+			} else if string(name) == "main" {
+				inMain++
+			}
+			all.WriteByte(' ')
+			if inMain > 0 {
+				main.WriteByte(' ')
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if _, ok := skipTextTags[string(name)]; ok && skip > 0 {
+				skip--
+			} else if string(name) == "main" && inMain > 0 {
+				inMain--
+			}
+			all.WriteByte(' ')
+			if inMain > 0 {
+				main.WriteByte(' ')
+			}
+		case html.SelfClosingTagToken:
+			all.WriteByte(' ')
+			if inMain > 0 {
+				main.WriteByte(' ')
+			}
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			text := z.Text()
+			all.Write(text)
+			all.WriteByte(' ')
+			if inMain > 0 {
+				main.Write(text)
+				main.WriteByte(' ')
+			}
+		}
+	}
+}
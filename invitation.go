@@ -0,0 +1,269 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cast"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	ErrInvitationInvalid = errors.New("invitation is not valid")
+	ErrInvitationOTP     = errors.New("invitation: otp enrolment is not started")
+)
+
+// InvitationService lets the admins invite new admins. The invitee redeems the signed token
+// in two steps: the first one returns the otp secret to enrol, the second one sets the password
+// and proves the enrolment with a code.
+type InvitationService struct {
+	repo        repository.AdminInvitation
+	admins      repository.Admin
+	permissions repository.Permission
+	cache       Cache
+	secret      string
+
+	Timeout time.Duration
+	// Issuer is the otp issuer shown by the authenticator apps.
+	Issuer string
+	// Mailer mails the link to the invitee when set together with URL.
+	Mailer Mailer
+	// URL is the page of the admin panel the token is passed to in the token query parameter.
+	URL string
+}
+
+func NewInvitationService(
+	repo repository.AdminInvitation,
+	admins repository.Admin,
+	permissions repository.Permission,
+	cache Cache,
+	secret string,
+) *InvitationService {
+	if repo == nil {
+		panic("admin invitation repository is not specified")
+	}
+	if admins == nil {
+		panic("admin repository is not specified")
+	}
+	if permissions == nil {
+		panic("permission repository is not specified")
+	}
+	if cache == nil {
+		panic("cache is not specified")
+	}
+	if secret == "" {
+		panic("invitation secret is not specified")
+	}
+	return &InvitationService{
+		repo:        repo,
+		admins:      admins,
+		permissions: permissions,
+		cache:       cache,
+		secret:      secret,
+		Timeout:     7 * 24 * time.Hour,
+		Issuer:      "CMS",
+	}
+}
+
+// Invite creates the invitation and returns its token, the email must not belong to an admin.
+func (s *InvitationService) Invite(ctx context.Context, invitedBy int64, email string, role model.Role, siteIDs []int64) (model.AdminInvitation, string, error) {
+	if _, err := s.admins.FindByEmail(ctx, email); err == nil {
+		return model.AdminInvitation{}, "", repository.ErrUniqueViolation
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return model.AdminInvitation{}, "", err
+	}
+
+	m := model.AdminInvitation{
+		Email:     email,
+		Role:      role,
+		SiteIDs:   siteIDs,
+		InvitedBy: &invitedBy,
+		Expires:   time.Now().Add(s.Timeout),
+	}
+	if err := s.repo.Create(ctx, &m); err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+
+	token, err := NewJWT(
+		jwt.MapClaims{
+			"sub":   m.Email,
+			"model": reflect.TypeOf(m).Name(),
+			"iid":   m.ID,
+		},
+		s.secret,
+		s.Timeout,
+	)
+	if err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+
+	if s.Mailer != nil && s.URL != "" {
+		if err = s.mail(ctx, m, token); err != nil {
+			return model.AdminInvitation{}, "", err
+		}
+	}
+	return m, token, nil
+}
+
+// Begin starts the otp enrolment of the invitee and returns the key of the secret.
+func (s *InvitationService) Begin(ctx context.Context, token string) (model.AdminInvitation, string, error) {
+	m, err := s.invitation(ctx, token)
+	if err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+
+	otp, err := model.NewOTP()
+	if err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+
+	key, err := model.Admin{Email: m.Email, OTP: otp}.OTPKey(s.Issuer)
+	if err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+
+	if err = s.cache.Set(WithCacheTTL(ctx, time.Until(m.Expires)), s.key(m.ID), otp); err != nil {
+		return model.AdminInvitation{}, "", err
+	}
+	return m, key, nil
+}
+
+// Redeem creates the admin of the invitation once the code proves the otp enrolment.
+func (s *InvitationService) Redeem(ctx context.Context, token, password, code string) (model.Admin, error) {
+	m, err := s.invitation(ctx, token)
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	var otp model.OTP
+	if err = s.cache.Get(ctx, s.key(m.ID), &otp); err != nil {
+		return model.Admin{}, errors.Join(ErrInvitationOTP, err)
+	}
+	if err = otp.Validate(code); err != nil {
+		return model.Admin{}, err
+	}
+
+	pswd, err := model.NewPassword(password)
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	// the role is granted on the sites only, the admin has no role elsewhere
+	role := m.Role
+	if len(m.SiteIDs) > 0 {
+		role = model.RoleGuest
+	}
+
+	admin, err := newAdmin(m.Email, pswd, role)
+	if err != nil {
+		return model.Admin{}, err
+	}
+	admin.OTP = otp
+
+	// the invitation is claimed first, so the parallel redeems of it fail
+	now := time.Now()
+	accepted, err := s.repo.Accept(ctx, m.ID, now)
+	if err != nil {
+		return model.Admin{}, err
+	}
+	if !accepted {
+		return model.Admin{}, ErrInvitationInvalid
+	}
+
+	if err = s.redeem(ctx, m, &admin, now); err != nil {
+		return model.Admin{}, err
+	}
+
+	_ = s.cache.DelByKey(ctx, s.key(m.ID))
+	return admin, nil
+}
+
+// redeem creates the admin of the claimed invitation with its permissions, a failure
+// removes what is created already and releases the invitation, so it can be redeemed again.
+func (s *InvitationService) redeem(ctx context.Context, m model.AdminInvitation, admin *model.Admin, now time.Time) (err error) {
+	var (
+		created     bool
+		permissions []int64
+	)
+	defer func() {
+		if err == nil {
+			return
+		}
+		if len(permissions) > 0 {
+			err = errors.Join(err, s.permissions.Delete(ctx, permissions...))
+		}
+		if created {
+			err = errors.Join(err, s.admins.Delete(ctx, admin.ID))
+		}
+		m.Accepted, m.AdminID = nil, nil
+		err = errors.Join(err, s.repo.Update(ctx, &m))
+	}()
+
+	if err = s.admins.Create(ctx, admin); err != nil {
+		return err
+	}
+	created = true
+
+	for _, siteID := range m.SiteIDs {
+		permission := model.Permission{AdminID: admin.ID, Role: m.Role, SiteID: &siteID}
+		if err = s.permissions.Create(ctx, &permission); err != nil {
+			return err
+		}
+		permissions = append(permissions, permission.ID)
+	}
+
+	m.Accepted = &now
+	m.AdminID = &admin.ID
+	return s.repo.Update(ctx, &m)
+}
+
+// invitation returns the pending invitation of the token.
+func (s *InvitationService) invitation(ctx context.Context, token string) (model.AdminInvitation, error) {
+	claims, err := ParseJWT(token, s.secret)
+	if err != nil {
+		return model.AdminInvitation{}, errors.Join(ErrInvitationInvalid, err)
+	}
+	if cast.ToString(claimsValue(claims, "model")) != reflect.TypeOf(model.AdminInvitation{}).Name() {
+		return model.AdminInvitation{}, ErrInvitationInvalid
+	}
+
+	m, err := s.repo.FindByID(ctx, cast.ToInt64(claimsValue(claims, "iid")))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.AdminInvitation{}, errors.Join(ErrInvitationInvalid, err)
+		}
+		return model.AdminInvitation{}, err
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject != m.Email || !m.IsPending(time.Now()) {
+		return model.AdminInvitation{}, ErrInvitationInvalid
+	}
+	return m, nil
+}
+
+func (s *InvitationService) mail(ctx context.Context, m model.AdminInvitation, token string) error {
+	link, err := tokenLink(s.URL, token)
+	if err != nil {
+		return err
+	}
+
+	return s.Mailer.Send(ctx, Mail{
+		To:      m.Email,
+		Subject: "Invitation",
+		Text: fmt.Sprintf(
+			"You are invited to the admin panel as %s.\n\nFollow the link to set your password and the second factor, it expires on %s:\n\n%s\n",
+			m.Role, m.Expires.Format(time.RFC1123), link,
+		),
+	})
+}
+
+func (s *InvitationService) key(id int64) string {
+	return fmt.Sprintf("cms::invitation:otp:%d", id)
+}
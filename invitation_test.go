@@ -0,0 +1,120 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/memory"
+)
+
+type flakyPermissions struct {
+	repository.Permission
+	fail atomic.Bool
+}
+
+func (p *flakyPermissions) Create(ctx context.Context, m *model.Permission) error {
+	if p.fail.Load() {
+		return errors.New("permissions are down")
+	}
+	return p.Permission.Create(ctx, m)
+}
+
+func beginInvitation(t *testing.T, service *cms.InvitationService, token string) string {
+	t.Helper()
+
+	_, key, err := service.Begin(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := otp.NewKeyFromURL(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.GenerateCode(k.Secret(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestInvitationService_RedeemOnce(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+	service := cms.NewInvitationService(memory.NewAdminInvitationRepository(), admins,
+		memory.NewPermissionRepository(), cache.NewLRU(100, 0, nil), "secret")
+
+	_, token, err := service.Invite(ctx, 1, "new@example.com", model.RoleAdmin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := beginInvitation(t, service, token)
+
+	var (
+		wg       sync.WaitGroup
+		redeemed atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch _, err := service.Redeem(ctx, token, "password", code); {
+			case err == nil:
+				redeemed.Add(1)
+			case !errors.Is(err, cms.ErrInvitationInvalid) && !errors.Is(err, cms.ErrInvitationOTP):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := redeemed.Load(); n != 1 {
+		t.Fatalf("the invitation is redeemed %d times", n)
+	}
+}
+
+func TestInvitationService_RedeemAgainAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	invitations := memory.NewAdminInvitationRepository()
+	admins := memory.NewAdminRepository()
+	permissions := &flakyPermissions{Permission: memory.NewPermissionRepository()}
+	service := cms.NewInvitationService(invitations, admins, permissions, cache.NewLRU(100, 0, nil), "secret")
+
+	m, token, err := service.Invite(ctx, 1, "new@example.com", model.NewRole("editor"), []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := beginInvitation(t, service, token)
+
+	permissions.fail.Store(true)
+	if _, err = service.Redeem(ctx, token, "password", code); err == nil {
+		t.Fatal("the redeem does not fail")
+	}
+	if _, err = admins.FindByEmail(ctx, m.Email); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("err = %v, the admin of the failed redeem is kept", err)
+	}
+
+	permissions.fail.Store(false)
+	admin, err := service.Redeem(ctx, token, "password", code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := invitations.FindByID(ctx, m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Accepted == nil || saved.AdminID == nil || *saved.AdminID != admin.ID {
+		t.Fatalf("the invitation is not accepted by the admin: %+v", saved)
+	}
+}
@@ -0,0 +1,98 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/internal"
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer delivers the mails of the cms, e.g. the password reset links.
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+var (
+	_ Mailer = (*LogMailer)(nil)
+	_ Mailer = (*FileMailer)(nil)
+)
+
+// LogMailer writes the mails to the log instead of sending them, for the local use.
+type LogMailer struct {
+	logger *zap.Logger
+}
+
+func NewLogMailer(logger *zap.Logger) *LogMailer {
+	if logger == nil {
+		panic("logger is not specified")
+	}
+	return &LogMailer{logger: logger.Named("mailer")}
+}
+
+func (m *LogMailer) Send(_ context.Context, mail Mail) error {
+	m.logger.Info("mail", zap.String("to", mail.To), zap.String("subject", mail.Subject), zap.String("text", mail.Text))
+	return nil
+}
+
+// FileMailer writes the mails to the directory as eml files instead of sending them, for the local use.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	if dir == "" {
+		panic("mailer directory is not specified")
+	}
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(_ context.Context, mail Mail) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	var buf bytes.Buffer
+	if m.from != "" {
+		_, _ = fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	}
+	_, _ = fmt.Fprintf(&buf, "To: %s\r\n", mail.To)
+	_, _ = fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	_, _ = fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(mail.Text, "\n", "\r\n"))
+
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), internal.RandomStringWithAlphabet(8, mediaKeyAlphabet))
+	return os.WriteFile(filepath.Join(m.dir, name), buf.Bytes(), 0o644)
+}
+
+// tokenLink returns the page at rawURL with the token in the token query parameter.
+func tokenLink(rawURL, token string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
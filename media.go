@@ -0,0 +1,378 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const (
+	MediaFitContain = "contain"
+	MediaFitCover   = "cover"
+
+	mediaDerivativesPrefix = "_derivatives"
+	mediaKeyAlphabet       = "abcdefghijklmnopqrstuvwxyz0123456789"
+	mediaMaxPixels         = 50_000_000
+)
+
+var (
+	ErrMediaTooLarge      = errors.New("media is too large")
+	ErrMediaInvalidSize   = errors.New("invalid media size")
+	ErrMediaInvalidFit    = errors.New("invalid media fit")
+	ErrMediaInvalidSign   = errors.New("invalid media signature")
+	mediaResizableTypes   = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+	mediaGenericMIMETypes = []string{"application/octet-stream", "text/plain", "text/xml"}
+)
+
+// MediaOptions describes a derivative of an image: the bounding box and how the image fills it.
+// Contain scales the image to fit into the box, cover scales and crops it to fill the box.
+type MediaOptions struct {
+	Width  int
+	Height int
+	Fit    string
+}
+
+func ParseMediaOptions(query url.Values) (opts MediaOptions, err error) {
+	if w := query.Get("w"); w != "" {
+		if opts.Width, err = strconv.Atoi(w); err != nil || opts.Width < 0 {
+			return MediaOptions{}, ErrMediaInvalidSize
+		}
+	}
+	if h := query.Get("h"); h != "" {
+		if opts.Height, err = strconv.Atoi(h); err != nil || opts.Height < 0 {
+			return MediaOptions{}, ErrMediaInvalidSize
+		}
+	}
+	switch opts.Fit = query.Get("fit"); opts.Fit {
+	case "", MediaFitContain, MediaFitCover:
+	default:
+		return MediaOptions{}, ErrMediaInvalidFit
+	}
+	return opts, nil
+}
+
+func (o MediaOptions) IsZero() bool {
+	return o.Width == 0 && o.Height == 0
+}
+
+func (o MediaOptions) Query() url.Values {
+	query := url.Values{}
+	if o.Width > 0 {
+		query.Set("w", strconv.Itoa(o.Width))
+	}
+	if o.Height > 0 {
+		query.Set("h", strconv.Itoa(o.Height))
+	}
+	if o.Fit != "" && o.Fit != MediaFitContain && !o.IsZero() {
+		query.Set("fit", o.Fit)
+	}
+	return query
+}
+
+type MediaService struct {
+	repo    repository.Media
+	storage Storage
+	baseURL string
+
+	// MaxSize limits the size of an uploaded file in bytes.
+	MaxSize int64
+	// MaxDimension limits the width and height of a derivative.
+	MaxDimension int
+	// Quality of JPEG derivatives.
+	Quality int
+	// Secret signs the options of the derivatives in the addresses returned by URL, so only
+	// the derivatives of those addresses are generated. No derivatives are generated without it.
+	Secret []byte
+}
+
+func NewMediaService(repo repository.Media, storage Storage, baseURL string) *MediaService {
+	if repo == nil {
+		panic("media repository is not specified")
+	}
+	if storage == nil {
+		panic("media storage is not specified")
+	}
+	return &MediaService{
+		repo:         repo,
+		storage:      storage,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		MaxSize:      32 << 20,
+		MaxDimension: 2048,
+		Quality:      85,
+	}
+}
+
+func (s *MediaService) FindByID(ctx context.Context, id int64) (model.Media, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// URL returns the public address of the media object or of its derivative,
+// the options of the derivative are signed with the Secret.
+func (s *MediaService) URL(key string, opts MediaOptions) string {
+	key = strings.TrimLeft(key, "/")
+	u := s.baseURL + "/" + key
+	if len(s.Secret) == 0 {
+		return u
+	}
+
+	opts = s.normalize(opts)
+	if query := opts.Query(); len(query) > 0 {
+		query.Set("s", s.sign(key, opts))
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// Upload stores the content of r and creates the media record. Name, Folder, Alt and SiteID are
+// taken from m, everything else is detected from the content.
+func (s *MediaService) Upload(ctx context.Context, m *model.Media, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, s.MaxSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > s.MaxSize {
+		return ErrMediaTooLarge
+	}
+
+	m.Name = path.Base("/" + strings.ReplaceAll(m.Name, "\\", "/"))
+	if m.Name == "/" {
+		m.Name = "file"
+	}
+	m.Folder = model.MediaFolder(m.Folder)
+	m.MIME = detectMIME(data, m.Name)
+	m.Size = int64(len(data))
+	m.Width, m.Height = 0, 0
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		m.Width, m.Height = cfg.Width, cfg.Height
+	}
+	m.Key = mediaKey(m.Folder, m.Name)
+
+	if err = s.storage.Put(ctx, m.Key, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	if err = s.repo.Create(ctx, m); err != nil {
+		_ = s.storage.Delete(ctx, m.Key)
+		return err
+	}
+	return nil
+}
+
+// Delete removes the media records together with their files and derivatives.
+func (s *MediaService) Delete(ctx context.Context, ids ...int64) error {
+	items := make([]model.Media, 0, len(ids))
+	for _, id := range ids {
+		m, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		items = append(items, m)
+	}
+
+	if err := s.repo.Delete(ctx, ids...); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, m := range items {
+		errs = append(errs,
+			s.storage.Delete(ctx, m.Key),
+			s.storage.DeletePrefix(ctx, path.Join(mediaDerivativesPrefix, m.Key)),
+		)
+	}
+	return errors.Join(errs...)
+}
+
+// Open returns the content and the content type of the media object identified by key.
+// When opts are not zero and the object is an image, a derivative is generated once and
+// kept in the storage for subsequent calls. The signature of the options returned by URL
+// is required for a derivative, so the visitors cannot request any number of them.
+func (s *MediaService) Open(ctx context.Context, key string, opts MediaOptions, signature string) (io.ReadCloser, string, error) {
+	opts = s.normalize(opts)
+	if !opts.IsZero() {
+		if len(s.Secret) == 0 {
+			opts = MediaOptions{}
+		} else if !hmac.Equal([]byte(signature), []byte(s.sign(strings.TrimLeft(key, "/"), opts))) {
+			return nil, "", ErrMediaInvalidSign
+		}
+	}
+
+	m, err := s.repo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pixels := m.Width * m.Height
+	if opts.IsZero() || !slices.Contains(mediaResizableTypes, m.MIME) || pixels == 0 || pixels > mediaMaxPixels {
+		rc, err := s.storage.Open(ctx, m.Key)
+		return rc, m.MIME, err
+	}
+
+	contentType, ext := "image/png", ".png"
+	if m.MIME == "image/jpeg" {
+		contentType, ext = m.MIME, ".jpg"
+	}
+
+	derivativeKey := path.Join(mediaDerivativesPrefix, m.Key, fmt.Sprintf("%dx%d-%s%s", opts.Width, opts.Height, opts.Fit, ext))
+
+	rc, err := s.storage.Open(ctx, derivativeKey)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return rc, contentType, err
+	}
+
+	data, err := s.derivative(ctx, m, opts, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = s.storage.Put(ctx, derivativeKey, bytes.NewReader(data)); err != nil {
+		return nil, "", err
+	}
+	return bytesReadCloser{bytes.NewReader(data)}, contentType, nil
+}
+
+func (s *MediaService) derivative(ctx context.Context, m model.Media, opts MediaOptions, contentType string) ([]byte, error) {
+	rc, err := s.storage.Open(ctx, m.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	src, _, err := image.Decode(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := resizeImage(src, opts)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: s.Quality})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	return buf.Bytes(), err
+}
+
+// sign returns the signature of the options of the derivative of the key.
+func (s *MediaService) sign(key string, opts MediaOptions) string {
+	h := hmac.New(sha256.New, s.Secret)
+	_, _ = fmt.Fprintf(h, "%s\n%d\n%d\n%s", key, opts.Width, opts.Height, opts.Fit)
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:16])
+}
+
+func (s *MediaService) normalize(opts MediaOptions) MediaOptions {
+	opts.Width = min(max(opts.Width, 0), s.MaxDimension)
+	opts.Height = min(max(opts.Height, 0), s.MaxDimension)
+	if opts.Fit != MediaFitCover {
+		opts.Fit = MediaFitContain
+	}
+	return opts
+}
+
+func resizeImage(src image.Image, opts MediaOptions) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	if sw == 0 || sh == 0 {
+		return src
+	}
+
+	w, h := opts.Width, opts.Height
+	switch {
+	case w == 0:
+		w = max(sw*h/sh, 1)
+	case h == 0:
+		h = max(sh*w/sw, 1)
+	}
+
+	rect := bounds
+	if opts.Fit == MediaFitCover {
+		// crop the source to the aspect ratio of the box around its center
+		if sw*h > sh*w {
+			cw := max(sh*w/h, 1)
+			x := bounds.Min.X + (sw-cw)/2
+			rect = image.Rect(x, bounds.Min.Y, x+cw, bounds.Max.Y)
+		} else {
+			ch := max(sw*h/w, 1)
+			y := bounds.Min.Y + (sh-ch)/2
+			rect = image.Rect(bounds.Min.X, y, bounds.Max.X, y+ch)
+		}
+	} else if sw*h > sh*w {
+		h = max(sh*w/sw, 1)
+	} else {
+		w = max(sw*h/sh, 1)
+	}
+
+	// never upscale, keep the aspect ratio of the box instead
+	if w > rect.Dx() || h > rect.Dy() {
+		w, h = rect.Dx(), rect.Dy()
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Src, nil)
+	return dst
+}
+
+func detectMIME(data []byte, name string) string {
+	mimeType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+
+	for _, generic := range mediaGenericMIMETypes {
+		if mimeType != generic {
+			continue
+		}
+		if byExt, _, _ := mime.ParseMediaType(mime.TypeByExtension(strings.ToLower(path.Ext(name)))); byExt != "" {
+			return byExt
+		}
+		break
+	}
+	return mimeType
+}
+
+func mediaKey(folder, name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	base := slug.Make(strings.TrimSuffix(name, path.Ext(name)))
+	if base == "" {
+		base = "file"
+	}
+	if ext != "" && slug.Make(ext[1:]) != ext[1:] {
+		ext = ""
+	}
+
+	return path.Join(folder, internal.RandomStringWithAlphabet(8, mediaKeyAlphabet)+"-"+base+ext)
+}
+
+type bytesReadCloser struct {
+	*bytes.Reader
+}
+
+func (bytesReadCloser) Close() error {
+	return nil
+}
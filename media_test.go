@@ -0,0 +1,103 @@
+package cms_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func newMediaService(t *testing.T, secret string) (*cms.MediaService, model.Media) {
+	t.Helper()
+
+	service := cms.NewMediaService(memory.NewMediaRepository(), cms.NewLocalStorage(t.TempDir()), "/media")
+	service.Secret = []byte(secret)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 64, 32))); err != nil {
+		t.Fatal(err)
+	}
+
+	m := model.Media{Name: "image.png"}
+	if err := service.Upload(context.Background(), &m, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return service, m
+}
+
+func openMediaURL(service *cms.MediaService, raw string) (image.Config, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return image.Config{}, err
+	}
+	opts, err := cms.ParseMediaOptions(u.Query())
+	if err != nil {
+		return image.Config{}, err
+	}
+
+	rc, _, err := service.Open(context.Background(), u.Path[len("/media/"):], opts, u.Query().Get("s"))
+	if err != nil {
+		return image.Config{}, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return image.Config{}, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	return cfg, err
+}
+
+func TestMediaService_SignedDerivatives(t *testing.T) {
+	service, m := newMediaService(t, "secret")
+
+	signed := service.URL(m.Key, cms.MediaOptions{Width: 16})
+	cfg, err := openMediaURL(service, signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 16 || cfg.Height != 8 {
+		t.Fatalf("got %dx%d, want 16x8", cfg.Width, cfg.Height)
+	}
+
+	u, _ := url.Parse(signed)
+	query := u.Query()
+	query.Set("w", "17")
+	u.RawQuery = query.Encode()
+	if _, err = openMediaURL(service, u.String()); !errors.Is(err, cms.ErrMediaInvalidSign) {
+		t.Fatalf("got %v for a size out of the signed ones, want ErrMediaInvalidSign", err)
+	}
+
+	query.Del("s")
+	u.RawQuery = query.Encode()
+	if _, err = openMediaURL(service, u.String()); !errors.Is(err, cms.ErrMediaInvalidSign) {
+		t.Fatalf("got %v for an unsigned size, want ErrMediaInvalidSign", err)
+	}
+}
+
+func TestMediaService_NoSecretServesOriginal(t *testing.T) {
+	service, m := newMediaService(t, "")
+
+	if u := service.URL(m.Key, cms.MediaOptions{Width: 16}); u != "/media/"+m.Key {
+		t.Fatalf("got %q, want the address of the original", u)
+	}
+
+	cfg, err := openMediaURL(service, "/media/"+m.Key+"?w=16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 64 || cfg.Height != 32 {
+		t.Fatalf("got %dx%d, want the original 64x32", cfg.Width, cfg.Height)
+	}
+}
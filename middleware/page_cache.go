@@ -0,0 +1,251 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+)
+
+type PageCacheConfig struct {
+	Skipper middleware.Skipper
+	Cache   cms.Cache
+	// TTL limits the life of the pages depending on nothing the tags purge, e.g. the search results.
+	// Optional. Zero keeps the pages until they are purged.
+	TTL time.Duration
+	// Vary lists the request headers the pages differ by, the responses varying by
+	// other headers are not cached.
+	Vary []string
+	// Query lists the query parameters the pages differ by, the requests with other
+	// parameters bypass the cache, so random parameters do not fill it.
+	Query []string
+	// Cookies lists the request cookies of the visitors the pages are not cached for,
+	// e.g. the session cookie.
+	Cookies []string
+	// CSRFContextKey is the key the csrf middleware stores the token of the visitor with.
+	// Optional. Default value "csrf".
+	CSRFContextKey string
+	// MaxSize is the size of the largest body cached.
+	// Optional. Default value 1MB.
+	MaxSize int
+}
+
+type pageCacheEntry struct {
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+	Expires time.Time   `json:"expires"`
+}
+
+// PageCache serves the rendered cms pages from the cache, the pages are tagged with
+// the tags of everything read while rendering them, so the invalidation of the cached
+// repositories purges them as well. It goes after the site selector and before the page selector.
+// The editors, the visitors with sessions or csrf tokens and the previews bypass the cache.
+func PageCache(cfg PageCacheConfig) echo.MiddlewareFunc {
+	if cfg.Cache == nil {
+		panic("cache is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.CSRFContextKey == "" {
+		cfg.CSRFContextKey = "csrf"
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 1 << 20
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			if cfg.Skipper(c) || bypassPageCache(c, cfg) {
+				return next(c)
+			}
+
+			site := cms.CtxSite(r.Context())
+			if site == nil {
+				return next(c)
+			}
+
+			key, ok := pageCacheKey(r, site.ID, cfg.Vary, cfg.Query)
+			if !ok {
+				return next(c)
+			}
+
+			var entry pageCacheEntry
+			if err := cfg.Cache.Get(r.Context(), key, &entry); err == nil {
+				if entry.Expires.IsZero() || time.Now().Before(entry.Expires) {
+					return writePageCacheEntry(c, entry)
+				}
+				_ = cfg.Cache.DelByKey(r.Context(), key)
+			}
+
+			ctx, tags := cms.WithCacheTags(r.Context())
+			c.SetRequest(r.WithContext(ctx))
+
+			w := c.Response()
+			header := w.Header().Clone()
+			recorder := &pageRecorder{ResponseWriter: w.Writer, max: cfg.MaxSize}
+			w.Writer = recorder
+
+			err := next(c)
+			w.Writer = recorder.ResponseWriter
+			if err != nil {
+				return err
+			}
+
+			ctx = c.Request().Context()
+			page := cms.CtxPage(ctx)
+			if page == nil ||
+				recorder.overflow ||
+				w.Status != http.StatusOK ||
+				bypassPageCache(c, cfg) ||
+				!storablePageResponse(w.Header(), cfg.Vary) {
+				return nil
+			}
+
+			entry = pageCacheEntry{
+				Status: w.Status,
+				Header: pageCacheHeader(header, w.Header()),
+				Body:   recorder.body.Bytes(),
+			}
+			if cfg.TTL > 0 {
+				entry.Expires = time.Now().Add(cfg.TTL)
+				ctx = cms.WithCacheTTL(ctx, cfg.TTL)
+			}
+
+			tags.Add(cms.SiteTag(site.ID), cms.PageTag(page.ID))
+
+			_ = cfg.Cache.Set(ctx, key, entry, tags.All()...)
+			return nil
+		}
+	}
+}
+
+func bypassPageCache(c echo.Context, cfg PageCacheConfig) bool {
+	r := c.Request()
+	ctx := r.Context()
+
+	if r.Method != http.MethodGet ||
+		r.Header.Get(echo.HeaderAuthorization) != "" ||
+		cms.IsAjax(r) ||
+		cms.SkipSelectSite(ctx) ||
+		cms.SkipSelectPage(ctx) ||
+		cms.CtxEditor(ctx) ||
+		cms.CtxPreview(ctx) ||
+		c.Get(cfg.CSRFContextKey) != nil {
+		return true
+	}
+
+	for _, name := range cfg.Cookies {
+		if _, err := r.Cookie(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pageCacheKey keys the page by the path and the allowed query parameters in their canonical order,
+// it reports false when the request has a parameter out of the allowed ones.
+func pageCacheKey(r *http.Request, siteID int64, vary, allowed []string) (string, bool) {
+	query := r.URL.Query()
+	for name := range query {
+		if !slices.Contains(allowed, name) {
+			return "", false
+		}
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\n%s\n%s?%s\n", cms.Scheme(r), r.Host, r.URL.EscapedPath(), query.Encode())
+	for _, name := range vary {
+		_, _ = fmt.Fprintf(h, "%s: %s\n", http.CanonicalHeaderKey(name), strings.Join(r.Header.Values(name), ","))
+	}
+	return fmt.Sprintf("cms::page-cache:%d:%s", siteID, hex.EncodeToString(h.Sum(nil))), true
+}
+
+// storablePageResponse rejects the responses of a visitor and the ones varying by the headers out of the key.
+// The encoded responses are rejected as well, so the entries fit any Accept-Encoding.
+func storablePageResponse(header http.Header, vary []string) bool {
+	if header.Get(echo.HeaderSetCookie) != "" || header.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+
+	cacheControl := strings.ToLower(header.Get(echo.HeaderCacheControl))
+	if strings.Contains(cacheControl, "no-store") || strings.Contains(cacheControl, "private") {
+		return false
+	}
+
+	for _, value := range header.Values(echo.HeaderVary) {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || strings.EqualFold(name, echo.HeaderAcceptEncoding) {
+				continue
+			}
+			if name == "*" || !slices.ContainsFunc(vary, func(v string) bool { return strings.EqualFold(v, name) }) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pageCacheHeader keeps the headers set while rendering the page, the ones set by the
+// middlewares before are set again when the page is served from the cache.
+func pageCacheHeader(before, after http.Header) http.Header {
+	header := make(http.Header)
+	for name, values := range after {
+		if name == echo.HeaderContentLength || slices.Equal(before[name], values) {
+			continue
+		}
+		header[name] = slices.Clone(values)
+	}
+	return header
+}
+
+func writePageCacheEntry(c echo.Context, entry pageCacheEntry) error {
+	w := c.Response()
+	for name, values := range entry.Header {
+		w.Header()[name] = values
+	}
+	if entry.Status == http.StatusOK && cms.NotModified(c.Request(), w.Header()) {
+		return cms.WriteNotModified(c)
+	}
+	w.WriteHeader(entry.Status)
+	_, err := w.Write(entry.Body)
+	return err
+}
+
+// pageRecorder copies the body written to the client up to the max size.
+type pageRecorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	max      int
+	overflow bool
+}
+
+func (r *pageRecorder) Write(b []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(b) > r.max {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter, see http.ResponseController.
+func (r *pageRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/middleware"
+	"github.com/gowool/cms/model"
+)
+
+type ttlCache struct {
+	cms.Cache
+	ttl time.Duration
+}
+
+func (c *ttlCache) Set(ctx context.Context, key string, value any, tags ...string) error {
+	c.ttl, _ = cms.CtxCacheTTL(ctx)
+	return c.Cache.Set(ctx, key, value, tags...)
+}
+
+func newPageCacheServer(t *testing.T, c cms.Cache, encoding string) (*echo.Echo, *int) {
+	t.Helper()
+
+	renders := new(int)
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := cms.WithSite(c.Request().Context(), &model.Site{ID: 1})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.Use(middleware.PageCache(middleware.PageCacheConfig{
+		Cache: c,
+		TTL:   time.Minute,
+		Query: []string{"page", "sort"},
+	}))
+	e.GET("/*", func(c echo.Context) error {
+		*renders++
+		ctx := cms.WithPage(c.Request().Context(), &model.Page{ID: 1})
+		c.SetRequest(c.Request().WithContext(ctx))
+		if encoding != "" {
+			c.Response().Header().Set(echo.HeaderContentEncoding, encoding)
+		}
+		return c.String(http.StatusOK, "page")
+	})
+	return e, renders
+}
+
+func get(e *echo.Echo, target string) {
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+func TestPageCache_KeysByAllowedQuery(t *testing.T) {
+	c := &ttlCache{Cache: cache.NewLRU(100, 0, nil)}
+	e, renders := newPageCacheServer(t, c, "")
+
+	get(e, "/news?page=2&sort=date")
+	get(e, "/news?sort=date&page=2")
+	if *renders != 1 {
+		t.Fatalf("the reordered query rendered the page again, renders %d", *renders)
+	}
+	if c.ttl != time.Minute {
+		t.Fatalf("the entry is set with ttl %s, want %s", c.ttl, time.Minute)
+	}
+
+	get(e, "/news?page=3")
+	if *renders != 2 {
+		t.Fatalf("an allowed parameter did not make a new entry, renders %d", *renders)
+	}
+
+	get(e, "/news?utm=1")
+	get(e, "/news?utm=1")
+	if *renders != 4 {
+		t.Fatalf("a parameter out of the allowed ones was cached, renders %d", *renders)
+	}
+}
+
+func TestPageCache_SkipsEncodedResponses(t *testing.T) {
+	e, renders := newPageCacheServer(t, cache.NewLRU(100, 0, nil), "gzip")
+
+	get(e, "/news")
+	get(e, "/news")
+	if *renders != 2 {
+		t.Fatalf("an encoded response was cached, renders %d", *renders)
+	}
+}
@@ -85,6 +85,18 @@ func HybridPage(cfg HybridPageConfig) echo.MiddlewareFunc {
 			response.Reset(w.Writer, buffer)
 			w.Writer = response
 
+			// the surrogate keys of the page include the ones of the inner handler
+			if cms.CtxCacheTags(r.Context()) == nil {
+				ctx, _ := cms.WithCacheTags(r.Context())
+				r = r.WithContext(ctx)
+				c.SetRequest(r)
+			}
+
+			// the validators of the inner handler are answered together with the ones of the page
+			conditional := r.Header.Clone()
+			r.Header.Del("If-None-Match")
+			r.Header.Del("If-Modified-Since")
+
 			if err = next(c); err != nil {
 				w.Writer = response.Writer
 				return err
@@ -92,13 +104,21 @@ func HybridPage(cfg HybridPageConfig) echo.MiddlewareFunc {
 			w.Writer = response.Writer
 			w.Committed = false
 
+			r = c.Request()
+			r.Header = conditional
+
 			if !cms.IsTextHTML(c.Response().Header()) || response.Status != http.StatusOK || cms.PageNotDecorate(c.Response()) {
+				if response.Status == http.StatusOK && cms.NotModified(r, w.Header()) {
+					return cms.WriteNotModified(c)
+				}
 				if response.Committed || response.Buffer.Len() > 0 {
 					_, err = w.Write(response.Buffer.Bytes())
 				}
 				return err
 			}
 
+			w.Header().Del("ETag")
+
 			data := cms.CtxData(r.Context())
 			data["content"] = template.HTML(internal.String(buffer.Bytes()))
 			ctx := cms.WithData(r.Context(), data)
@@ -13,10 +13,13 @@ import (
 )
 
 type PageSelectorConfig struct {
-	Skipper        middleware.Skipper
-	PageHandler    cms.PageHandler
-	CfgRepository  repository.Configuration
-	PageRepository repository.Page
+	Skipper         middleware.Skipper
+	PageHandler     cms.PageHandler
+	CfgRepository   repository.Configuration
+	PageRepository  repository.Page
+	DraftRepository repository.PageDraft
+	PreviewSecret   string
+	PreviewParam    string
 }
 
 func PageSelector(cfg PageSelectorConfig) echo.MiddlewareFunc {
@@ -32,6 +35,9 @@ func PageSelector(cfg PageSelectorConfig) echo.MiddlewareFunc {
 	if cfg.Skipper == nil {
 		cfg.Skipper = middleware.DefaultSkipper
 	}
+	if cfg.PreviewParam == "" {
+		cfg.PreviewParam = "preview"
+	}
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -55,6 +61,22 @@ func PageSelector(cfg PageSelectorConfig) echo.MiddlewareFunc {
 				return errors.Join(repository.ErrSiteNotFound, cms.ErrInternal)
 			}
 
+			if token := r.URL.Query().Get(cfg.PreviewParam); token != "" && cfg.DraftRepository != nil && cfg.PreviewSecret != "" {
+				live, page, err := preview(c, cfg, site, token)
+				if err != nil {
+					return err
+				}
+
+				if live != nil {
+					if live.IsCMS() && live.URL == r.URL.Path {
+						return withPage(c, cfg.PageHandler.Handle, *page)
+					}
+					if live.IsHybrid() && live.Pattern == r.Pattern && !configuration.IgnorePattern(r.Pattern) {
+						return withPage(c, next, *page)
+					}
+				}
+			}
+
 			var now time.Time
 			if !cms.CtxEditor(r.Context()) {
 				now = time.Now()
@@ -87,6 +109,42 @@ func PageSelector(cfg PageSelectorConfig) echo.MiddlewareFunc {
 	}
 }
 
+// preview resolves the page the token was issued for and its draft,
+// an invalid token or a page of another site is ignored.
+func preview(c echo.Context, cfg PageSelectorConfig, site *model.Site, token string) (*model.Page, *model.Page, error) {
+	r := c.Request()
+
+	pageID, err := cms.ParsePreviewToken(token, cfg.PreviewSecret)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	live, err := cfg.PageRepository.FindByID(r.Context(), pageID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if live.SiteID != site.ID {
+		return nil, nil, nil
+	}
+
+	page := live
+	if draft, err := cfg.DraftRepository.FindByPageID(r.Context(), pageID); err == nil {
+		page = draft.Live()
+		page.URL = live.URL
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	c.Response().Header().Set("X-Robots-Tag", "noindex")
+	c.SetRequest(r.WithContext(cms.WithPreview(r.Context(), true)))
+
+	return &live, &page, nil
+}
+
 func withPage(c echo.Context, next echo.HandlerFunc, page model.Page) error {
 	r := c.Request()
 	ctx := cms.WithPage(r.Context(), &page)
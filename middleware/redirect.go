@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/dlclark/regexp2"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type RedirectConfig struct {
+	Skipper            middleware.Skipper
+	RedirectRepository repository.Redirect
+	// Hits counts the hits of the rules, see cms.RedirectHits. Optional.
+	Hits *cms.RedirectHits
+}
+
+// Redirect applies the redirect rules of the selected site, it must be placed
+// after the SiteSelector middleware and before the PageSelector one.
+// Exact rules take precedence over prefix rules, the longest prefix wins,
+// regex rules are tried last in the order they were created.
+func Redirect(cfg RedirectConfig) echo.MiddlewareFunc {
+	if cfg.RedirectRepository == nil {
+		panic("redirect repository is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.Hits == nil {
+		cfg.Hits = cms.NewRedirectHits(cfg.RedirectRepository, nil)
+	}
+
+	regexps := &redirectRegexps{items: make(map[int64]redirectRegexp)}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			if cfg.Skipper(c) || cms.SkipSelectSite(r.Context()) {
+				return next(c)
+			}
+
+			site := cms.CtxSite(r.Context())
+			if site == nil {
+				return errors.Join(repository.ErrSiteNotFound, cms.ErrInternal)
+			}
+
+			redirects, err := cfg.RedirectRepository.FindBySiteID(r.Context(), site.ID)
+			if err != nil {
+				return err
+			}
+
+			rule, location, ok := matchRedirect(redirects, r.URL.Path, regexps)
+			if !ok {
+				return next(c)
+			}
+
+			cfg.Hits.Hit(r.Context(), rule.ID)
+
+			if rule.IsGone() {
+				return echo.ErrGone
+			}
+
+			if strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+				location = strings.TrimRight(site.RelativePath, "/") + location
+			}
+			if r.URL.RawQuery != "" {
+				if strings.Contains(location, "?") {
+					location += "&" + r.URL.RawQuery
+				} else {
+					location += "?" + r.URL.RawQuery
+				}
+			}
+
+			return c.Redirect(rule.Status, location)
+		}
+	}
+}
+
+func matchRedirect(redirects []model.Redirect, path string, regexps *redirectRegexps) (rule model.Redirect, location string, ok bool) {
+	var prefix int
+
+	for _, item := range redirects {
+		if item.Type == model.RedirectExact {
+			if location, ok = item.Location(path); ok {
+				return item, location, true
+			}
+		}
+	}
+
+	for _, item := range redirects {
+		if item.Type == model.RedirectPrefix && len(item.Source) > prefix {
+			if target, found := item.Location(path); found {
+				rule, location, ok, prefix = item, target, true, len(item.Source)
+			}
+		}
+	}
+	if ok {
+		return
+	}
+
+	for _, item := range redirects {
+		if item.Type == model.RedirectRegex {
+			re, compiled := regexps.get(item)
+			if !compiled {
+				continue
+			}
+			if location, ok = item.RegexpLocation(re, path); ok {
+				return item, location, true
+			}
+		}
+	}
+	return model.Redirect{}, "", false
+}
+
+type redirectRegexp struct {
+	source string
+	re     *regexp2.Regexp
+	ok     bool
+}
+
+// redirectRegexps keeps the expressions of the regex rules compiled once per rule,
+// a rule is compiled again when its source changes.
+type redirectRegexps struct {
+	mu    sync.RWMutex
+	items map[int64]redirectRegexp
+}
+
+func (c *redirectRegexps) get(rule model.Redirect) (*regexp2.Regexp, bool) {
+	c.mu.RLock()
+	item, found := c.items[rule.ID]
+	c.mu.RUnlock()
+
+	if !found || item.source != rule.Source {
+		item.source = rule.Source
+		item.re, item.ok = rule.Regexp()
+
+		c.mu.Lock()
+		c.items[rule.ID] = item
+		c.mu.Unlock()
+	}
+	return item.re, item.ok
+}
@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/middleware"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func newRedirectServer(t *testing.T, rules ...model.Redirect) (*echo.Echo, *memory.RedirectRepository, *cms.RedirectHits) {
+	t.Helper()
+
+	repo := memory.NewRedirectRepository()
+	for _, rule := range rules {
+		if err := repo.Create(context.Background(), &rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hits := cms.NewRedirectHits(repo, nil)
+	hits.Interval = time.Hour
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := cms.WithSite(c.Request().Context(), &model.Site{ID: 1})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.Use(middleware.Redirect(middleware.RedirectConfig{RedirectRepository: repo, Hits: hits}))
+	e.GET("/*", func(c echo.Context) error {
+		return c.String(http.StatusOK, "page")
+	})
+	return e, repo, hits
+}
+
+func TestRedirect_RegexTimesOut(t *testing.T) {
+	e, _, _ := newRedirectServer(t, model.Redirect{
+		Type:    model.RedirectRegex,
+		Source:  "^/(a+)+$",
+		Target:  "/b",
+		Status:  http.StatusMovedPermanently,
+		Enabled: true,
+	})
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+strings.Repeat("a", 64)+"!", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want the page", rec.Code)
+	}
+	if elapsed := time.Since(start); elapsed > 10*model.RedirectMatchTimeout {
+		t.Fatalf("the match took %s", elapsed)
+	}
+}
+
+func TestRedirect_CountsHitsInBatches(t *testing.T) {
+	ctx := context.Background()
+	e, repo, hits := newRedirectServer(t, model.Redirect{
+		Type:    model.RedirectRegex,
+		Source:  "^/old/(.*)$",
+		Target:  "/new/$1",
+		Status:  http.StatusMovedPermanently,
+		Enabled: true,
+	})
+	if err := hits.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/old/x", nil))
+		if rec.Code != http.StatusMovedPermanently || rec.Header().Get(echo.HeaderLocation) != "/new/x" {
+			t.Fatalf("status = %d, location = %q", rec.Code, rec.Header().Get(echo.HeaderLocation))
+		}
+	}
+
+	rules, err := repo.FindBySiteID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules[0].Hits != 0 {
+		t.Fatalf("hits = %d before the flush, want them counted in the process", rules[0].Hits)
+	}
+
+	if err = hits.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if rules, err = repo.FindBySiteID(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if rules[0].Hits != 3 || rules[0].LastHit == nil {
+		t.Fatalf("hits = %d, last hit = %v, want 3 written on stop", rules[0].Hits, rules[0].LastHit)
+	}
+}
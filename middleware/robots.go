@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const (
+	robotsAllow    = "User-agent: *\nAllow: /\n"
+	robotsDisallow = "User-agent: *\nDisallow: /\n"
+)
+
+type RobotsConfig struct {
+	Skipper        middleware.Skipper
+	SiteRepository repository.Site
+
+	// SitemapPath is the sitemap path relative to a site announced in robots.txt.
+	// Optional. Default value "/sitemap.xml".
+	SitemapPath string
+}
+
+// Robots serves /robots.txt of the requested host, it must be placed before
+// the SiteSelector middleware since robots.txt always lives at the host root
+// while sites may be bound to a relative path.
+func Robots(cfg RobotsConfig) echo.MiddlewareFunc {
+	if cfg.SiteRepository == nil {
+		panic("site repository is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.SitemapPath == "" {
+		cfg.SitemapPath = "/sitemap.xml"
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			if cfg.Skipper(c) ||
+				(r.Method != http.MethodGet && r.Method != http.MethodHead) ||
+				r.URL.Path != "/robots.txt" {
+				return next(c)
+			}
+
+			host := cms.Host(r)
+			sites, err := cfg.SiteRepository.FindByHosts(r.Context(), []string{host, "localhost", "127.0.0.1"}, time.Now())
+			if err != nil {
+				return err
+			}
+
+			// sites bound to the requested host take precedence over the localhost ones
+			if slices.ContainsFunc(sites, func(item model.Site) bool { return !item.IsLocalhost() }) {
+				sites = slices.DeleteFunc(sites, model.Site.IsLocalhost)
+			}
+
+			if len(sites) == 0 {
+				return c.String(http.StatusOK, robotsDisallow)
+			}
+
+			site := sites[0]
+			if index := slices.IndexFunc(sites, func(item model.Site) bool {
+				return item.RelativePath == "" || item.RelativePath == "/"
+			}); index >= 0 {
+				site = sites[index]
+			}
+
+			var body strings.Builder
+			if robots := strings.TrimSpace(site.Robots); robots != "" {
+				body.WriteString(robots)
+				body.WriteByte('\n')
+			} else {
+				body.WriteString(robotsAllow)
+			}
+
+			var sitemaps []string
+			for _, item := range sites {
+				line := "Sitemap: " + strings.TrimRight(item.WithHost(cms.Scheme(r), host).URL(), "/") + cfg.SitemapPath
+				if !slices.Contains(sitemaps, line) && !strings.Contains(body.String(), line) {
+					sitemaps = append(sitemaps, line)
+				}
+			}
+			if len(sitemaps) > 0 {
+				body.WriteByte('\n')
+				body.WriteString(strings.Join(sitemaps, "\n"))
+				body.WriteByte('\n')
+			}
+
+			return c.String(http.StatusOK, body.String())
+		}
+	}
+}
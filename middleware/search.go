@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"bytes"
+	"hash/fnv"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/repository"
+)
+
+type SearchIndexConfig struct {
+	Skipper          middleware.Skipper
+	SearchRepository repository.Search
+
+	// Limit is the maximum size of a rendered page which is indexed.
+	// Optional. Default value 2MB.
+	Limit int
+}
+
+// SearchIndex feeds the search index with the text of the rendered CMS pages,
+// it must be placed before the PageSelector middleware. The content of a page
+// is written to the index only when it changes.
+func SearchIndex(cfg SearchIndexConfig) echo.MiddlewareFunc {
+	if cfg.SearchRepository == nil {
+		panic("search repository is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = 2 << 20
+	}
+
+	var hashes sync.Map
+
+	bPool := sync.Pool{
+		New: func() any { return new(bytes.Buffer) },
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			if cfg.Skipper(c) || r.Method != http.MethodGet || cms.IsAjax(r) {
+				return next(c)
+			}
+
+			buffer := bPool.Get().(*bytes.Buffer)
+			buffer.Reset()
+			defer bPool.Put(buffer)
+
+			w := c.Response()
+			tee := &teeWriter{ResponseWriter: w.Writer, buffer: buffer, limit: cfg.Limit}
+			w.Writer = tee
+
+			err := next(c)
+			w.Writer = tee.ResponseWriter
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Request().Context()
+			page := cms.CtxPage(ctx)
+			if page == nil || page.ID <= 0 || !page.IsCMS() || !page.InSearch() ||
+				cms.CtxPreview(ctx) ||
+				tee.overflow ||
+				w.Status != http.StatusOK ||
+				!cms.IsTextHTML(w.Header()) ||
+				w.Header().Get(echo.HeaderContentEncoding) != "" {
+				return nil
+			}
+
+			content := internal.PlainText(buffer)
+
+			h := fnv.New64a()
+			_, _ = h.Write(internal.Bytes(content))
+			sum := h.Sum64()
+
+			if old, ok := hashes.Load(page.ID); ok && old == sum {
+				return nil
+			}
+			if err = cfg.SearchRepository.IndexContent(ctx, page.ID, content); err == nil {
+				hashes.Store(page.ID, sum)
+			}
+			return nil
+		}
+	}
+}
+
+type teeWriter struct {
+	http.ResponseWriter
+	buffer   *bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	if !w.overflow {
+		if w.buffer.Len()+len(b) > w.limit {
+			w.overflow = true
+		} else {
+			w.buffer.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter.
+func (w *teeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
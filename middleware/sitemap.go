@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gowool/cr"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type SitemapConfig struct {
+	Skipper        middleware.Skipper
+	CfgRepository  repository.Configuration
+	SiteRepository repository.Site
+	PageRepository repository.Page
+
+	// Path of the sitemap relative to the selected site.
+	// Optional. Default value "/sitemap.xml".
+	Path string
+
+	// Param is the query parameter selecting a chunk of a large sitemap.
+	// Optional. Default value "page".
+	Param string
+
+	// Limit is the maximum number of URLs in a single sitemap, when a site
+	// has more pages the sitemap becomes an index of chunks.
+	// Optional. Default value 50000.
+	Limit int
+}
+
+// Sitemap serves the XML sitemap of the selected site, it must be placed
+// after the SiteSelector middleware and before the PageSelector one.
+func Sitemap(cfg SitemapConfig) echo.MiddlewareFunc {
+	if cfg.CfgRepository == nil {
+		panic("configuration repository is not specified")
+	}
+	if cfg.SiteRepository == nil {
+		panic("site repository is not specified")
+	}
+	if cfg.PageRepository == nil {
+		panic("page repository is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/sitemap.xml"
+	}
+	if cfg.Param == "" {
+		cfg.Param = "page"
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = 50000
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+
+			if cfg.Skipper(c) ||
+				(r.Method != http.MethodGet && r.Method != http.MethodHead) ||
+				r.URL.Path != cfg.Path ||
+				cms.SkipSelectSite(r.Context()) {
+				return next(c)
+			}
+
+			site := cms.CtxSite(r.Context())
+			if site == nil {
+				return errors.Join(repository.ErrSiteNotFound, cms.ErrInternal)
+			}
+
+			urls, err := sitemapURLs(r, cfg, site)
+			if err != nil {
+				return err
+			}
+
+			var chunks [][]model.SitemapURL
+			for i := 0; i < len(urls); i += cfg.Limit {
+				chunks = append(chunks, urls[i:min(i+cfg.Limit, len(urls))])
+			}
+
+			param := r.URL.Query().Get(cfg.Param)
+			if param == "" {
+				if len(chunks) <= 1 {
+					return c.XML(http.StatusOK, urlSet(urls))
+				}
+
+				base := strings.TrimRight(site.URL(), "/") + cfg.Path
+				index := model.SitemapIndex{XMLNS: model.SitemapNS}
+				for i, chunk := range chunks {
+					index.Sitemaps = append(index.Sitemaps, model.SitemapRef{
+						Loc:     base + "?" + url.Values{cfg.Param: {strconv.Itoa(i + 1)}}.Encode(),
+						LastMod: lastMod(chunk),
+					})
+				}
+				return c.XML(http.StatusOK, index)
+			}
+
+			n, err := strconv.Atoi(param)
+			if err != nil || n < 1 || n > len(chunks) {
+				return echo.ErrNotFound
+			}
+			return c.XML(http.StatusOK, urlSet(chunks[n-1]))
+		}
+	}
+}
+
+func sitemapURLs(r *http.Request, cfg SitemapConfig, site *model.Site) ([]model.SitemapURL, error) {
+	ctx := r.Context()
+	now := time.Now()
+
+	pages, err := sitemapPages(ctx, cfg.PageRepository, site.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	alternates, err := sitemapAlternates(r, cfg, site, now)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimRight(site.URL(), "/")
+
+	urls := make([]model.SitemapURL, 0, len(pages))
+	for _, page := range pages {
+		urls = append(urls, model.SitemapURL{
+			Loc:        base + page.URL,
+			LastMod:    model.SitemapTime(page.Updated),
+			ChangeFreq: page.SitemapChangeFreq(),
+			Priority:   page.SitemapPriority(),
+			Alternates: alternates[page.URL],
+		})
+	}
+	return urls, nil
+}
+
+func sitemapPages(ctx context.Context, repo repository.Page, siteID int64, now time.Time) ([]model.Page, error) {
+	conditions := []any{cr.Condition{Column: "site_id", Value: siteID}}
+	conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+
+	pages, err := repo.Find(ctx, cr.New().
+		SetFilter(cr.Filter{Conditions: conditions}).
+		SetSortBy(cr.Sort{Column: "url", Order: "ASC"}))
+	if err != nil {
+		return nil, err
+	}
+	return internal.Filter(pages, model.Page.InSitemap), nil
+}
+
+// sitemapAlternates maps page URLs to their hreflang alternates, pages with
+// the same URL on the localized sites sharing the host are alternates of
+// each other, it is used only by the locale multisite strategies.
+func sitemapAlternates(r *http.Request, cfg SitemapConfig, site *model.Site, now time.Time) (map[string][]model.SitemapAlternate, error) {
+	ctx := r.Context()
+
+	configuration, err := cfg.CfgRepository.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if configuration.Multisite != model.HostByLocale && configuration.Multisite != model.HostWithPathByLocale {
+		return nil, nil
+	}
+
+	hosts := []string{site.Host}
+	if site.IsLocalhost() {
+		hosts = append(hosts, "localhost")
+	}
+
+	sites, err := cfg.SiteRepository.FindByHosts(ctx, hosts, now)
+	if err != nil {
+		return nil, err
+	}
+
+	sites = internal.Filter(sites, func(item model.Site) bool {
+		return item.Locale != ""
+	})
+	if len(sites) < 2 {
+		return nil, nil
+	}
+
+	alternates := make(map[string][]model.SitemapAlternate)
+	for _, item := range sites {
+		if item.ID == site.ID {
+			item = *site
+		} else {
+			item = item.WithHost(cms.Scheme(r), site.Host)
+		}
+
+		pages, err := sitemapPages(ctx, cfg.PageRepository, item.ID, now)
+		if err != nil {
+			return nil, fmt.Errorf("sitemap: alternates of site %d: %w", item.ID, err)
+		}
+
+		base := strings.TrimRight(item.URL(), "/")
+		for _, page := range pages {
+			alternates[page.URL] = append(alternates[page.URL], model.SitemapAlternate{
+				Rel:      "alternate",
+				Hreflang: model.Hreflang(item.Locale),
+				Href:     base + page.URL,
+			})
+			if item.IsDefault {
+				alternates[page.URL] = append(alternates[page.URL], model.SitemapAlternate{
+					Rel:      "alternate",
+					Hreflang: "x-default",
+					Href:     base + page.URL,
+				})
+			}
+		}
+	}
+
+	for key, items := range alternates {
+		if len(internal.Filter(items, func(item model.SitemapAlternate) bool { return item.Hreflang != "x-default" })) < 2 {
+			delete(alternates, key)
+		}
+	}
+	return alternates, nil
+}
+
+func urlSet(urls []model.SitemapURL) model.SitemapURLSet {
+	set := model.SitemapURLSet{XMLNS: model.SitemapNS, URLs: urls}
+	for _, u := range set.URLs {
+		if len(u.Alternates) > 0 {
+			set.XHTMLNS = model.SitemapXHTMLNS
+			break
+		}
+	}
+	return set
+}
+
+func lastMod(urls []model.SitemapURL) string {
+	var result string
+	for _, u := range urls {
+		// RFC 3339 times in UTC compare lexicographically.
+		result = max(result, u.LastMod)
+	}
+	return result
+}
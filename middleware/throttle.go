@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/gowool/cms"
+)
+
+type ThrottleConfig struct {
+	Skipper  middleware.Skipper
+	Throttle *cms.Throttle
+	// KeyFunc returns the keys of the request, the real ip of the client by default.
+	KeyFunc func(echo.Context) []string
+	// Failed reports whether the response counts as a failed attempt, 401 and 403 by default.
+	Failed func(c echo.Context, status int) bool
+}
+
+// Throttle rejects the requests of the keys with too many failed attempts with 429.
+func Throttle(cfg ThrottleConfig) echo.MiddlewareFunc {
+	if cfg.Throttle == nil {
+		panic("throttle is not specified")
+	}
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = func(c echo.Context) []string {
+			return []string{cms.ThrottleIPKey(c.RealIP())}
+		}
+	}
+	if cfg.Failed == nil {
+		cfg.Failed = func(_ echo.Context, status int) bool {
+			return status == http.StatusUnauthorized || status == http.StatusForbidden
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if cfg.Skipper(c) {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			keys := cfg.KeyFunc(c)
+
+			if err := cfg.Throttle.Allow(ctx, keys...); err != nil {
+				var throttledErr *cms.ThrottledError
+				if errors.As(err, &throttledErr) {
+					c.Response().Header().Set("Retry-After", throttledErr.RetryAfterHeader())
+					return echo.NewHTTPError(http.StatusTooManyRequests).WithInternal(err)
+				}
+				return err
+			}
+
+			err := next(c)
+
+			// the error is not handled yet, so its code takes precedence over the status
+			status := c.Response().Status
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+			}
+
+			if cfg.Failed(c, status) {
+				_, _ = cfg.Throttle.Fail(ctx, keys...)
+			}
+			return err
+		}
+	}
+}
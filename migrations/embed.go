@@ -10,4 +10,7 @@ import (
 //go:embed *
 var FS embed.FS
 
-var PgFS = internal.Must(fs.Sub(FS, "pg"))
+var (
+	PgFS     = internal.Must(fs.Sub(FS, "pg"))
+	SqliteFS = internal.Must(fs.Sub(FS, "sqlite"))
+)
@@ -0,0 +1,10 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+DROP TABLE IF EXISTS "template_revisions" CASCADE;
+
+--bun:split
+
+DROP TABLE IF EXISTS "page_revisions" CASCADE;
@@ -0,0 +1,34 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "page_revisions" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "record_id" integer NOT NULL REFERENCES "pages"("id") ON DELETE CASCADE,
+    "version" integer NOT NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" jsonb NOT NULL DEFAULT '{}',
+    "created" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "page_revisions_created_idx" ON "page_revisions" ("created");
+CREATE UNIQUE INDEX "page_revisions_record_id_version_unq" ON "page_revisions" ("record_id", "version");
+
+--bun:split
+
+CREATE TABLE "template_revisions" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "record_id" integer NOT NULL REFERENCES "templates"("id") ON DELETE CASCADE,
+    "version" integer NOT NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" jsonb NOT NULL DEFAULT '{}',
+    "created" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "template_revisions_created_idx" ON "template_revisions" ("created");
+CREATE UNIQUE INDEX "template_revisions_record_id_version_unq" ON "template_revisions" ("record_id", "version");
@@ -0,0 +1,21 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "page_drafts" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "page_id" integer NOT NULL REFERENCES "pages"("id") ON DELETE CASCADE,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" jsonb NOT NULL DEFAULT '{}',
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "page_drafts_created_updated_idx" ON "page_drafts" ("created", "updated");
+
+--bun:split
+
+CREATE UNIQUE INDEX "page_drafts_page_id_unq" ON "page_drafts" ("page_id");
@@ -0,0 +1,31 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "redirects" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE SET NULL,
+    "type" varchar NOT NULL DEFAULT 'exact',
+    "source" varchar NOT NULL,
+    "target" varchar,
+    "status" integer NOT NULL DEFAULT 301,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "hits" bigint NOT NULL DEFAULT 0,
+    "last_hit" timestamptz,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "redirects_created_updated_idx" ON "redirects" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "redirects_site_id_enabled_idx" ON "redirects" ("site_id", "enabled");
+
+--bun:split
+
+CREATE UNIQUE INDEX "redirects_site_id_type_source_unq" ON "redirects" (COALESCE("site_id", 0), "type", "source");
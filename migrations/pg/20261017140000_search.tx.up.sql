@@ -0,0 +1,35 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "search_documents" (
+    "page_id" integer PRIMARY KEY REFERENCES "pages"("id") ON DELETE CASCADE,
+    "site_id" integer NOT NULL REFERENCES "sites"("id") ON DELETE CASCADE,
+    "locale" varchar NOT NULL DEFAULT '',
+    "config" regconfig NOT NULL DEFAULT 'simple',
+    "url" varchar NOT NULL,
+    "title" varchar NOT NULL DEFAULT '',
+    "name" varchar NOT NULL DEFAULT '',
+    "description" text NOT NULL DEFAULT '',
+    "keywords" text NOT NULL DEFAULT '',
+    "metadata" text NOT NULL DEFAULT '',
+    "content" text NOT NULL DEFAULT '',
+    "published" timestamptz,
+    "expired" timestamptz,
+    "updated" timestamptz NOT NULL DEFAULT now(),
+    "document" tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector("config", "title" || ' ' || "name"), 'A') ||
+        setweight(to_tsvector("config", "description" || ' ' || "keywords"), 'B') ||
+        setweight(to_tsvector("config", "metadata"), 'C') ||
+        setweight(to_tsvector("config", "content"), 'D')
+    ) STORED
+);
+
+--bun:split
+
+CREATE INDEX "search_documents_document_idx" ON "search_documents" USING GIN ("document");
+
+--bun:split
+
+CREATE INDEX "search_documents_site_id_locale_idx" ON "search_documents" ("site_id", "locale");
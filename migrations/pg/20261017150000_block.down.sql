@@ -0,0 +1,6 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+DROP TABLE IF EXISTS "blocks" CASCADE;
@@ -0,0 +1,36 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "blocks" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE CASCADE,
+    "parent_id" integer REFERENCES "blocks"("id") ON DELETE CASCADE,
+    "region" varchar NOT NULL,
+    "type" varchar NOT NULL,
+    "name" varchar,
+    "settings" jsonb NOT NULL DEFAULT '{}',
+    "position" integer NOT NULL DEFAULT 0,
+    "shared" boolean NOT NULL DEFAULT false,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "blocks_created_updated_idx" ON "blocks" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "blocks_page_id_idx" ON "blocks" ("page_id");
+
+--bun:split
+
+CREATE INDEX "blocks_parent_id_idx" ON "blocks" ("parent_id");
+
+--bun:split
+
+CREATE INDEX "blocks_shared_site_id_idx" ON "blocks" ("shared", "site_id");
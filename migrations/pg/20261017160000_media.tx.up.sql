@@ -0,0 +1,35 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "media" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE SET NULL,
+    "folder" varchar NOT NULL DEFAULT '',
+    "name" varchar NOT NULL,
+    "key" varchar NOT NULL,
+    "alt" varchar NOT NULL DEFAULT '',
+    "mime" varchar NOT NULL,
+    "size" bigint NOT NULL DEFAULT 0,
+    "width" integer NOT NULL DEFAULT 0,
+    "height" integer NOT NULL DEFAULT 0,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "media_key_idx" ON "media" ("key");
+
+--bun:split
+
+CREATE INDEX "media_created_updated_idx" ON "media" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "media_folder_idx" ON "media" ("folder");
+
+--bun:split
+
+CREATE INDEX "media_site_id_idx" ON "media" ("site_id");
@@ -0,0 +1,10 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+ALTER TABLE "pages" DROP COLUMN IF EXISTS "translation_group_id";
+
+--bun:split
+
+DROP TABLE IF EXISTS "translation_groups" CASCADE;
@@ -0,0 +1,23 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "translation_groups" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "name" varchar NOT NULL,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "translation_groups_created_updated_idx" ON "translation_groups" ("created", "updated");
+
+--bun:split
+
+ALTER TABLE "pages" ADD COLUMN "translation_group_id" integer REFERENCES "translation_groups"("id") ON DELETE SET NULL;
+
+--bun:split
+
+CREATE UNIQUE INDEX "pages_translation_group_id_site_id_idx" ON "pages" ("translation_group_id", "site_id");
@@ -0,0 +1,34 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "audit_log" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "operation_id" varchar NOT NULL DEFAULT '',
+    "admin_id" integer,
+    "admin_email" varchar NOT NULL DEFAULT '',
+    "action" varchar NOT NULL,
+    "target_type" varchar NOT NULL,
+    "target_id" varchar NOT NULL DEFAULT '',
+    "data_before" jsonb,
+    "data_after" jsonb,
+    "ip" varchar NOT NULL DEFAULT '',
+    "created" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "audit_log_created_idx" ON "audit_log" ("created");
+
+--bun:split
+
+CREATE INDEX "audit_log_target_idx" ON "audit_log" ("target_type", "target_id");
+
+--bun:split
+
+CREATE INDEX "audit_log_admin_id_idx" ON "audit_log" ("admin_id");
+
+--bun:split
+
+CREATE INDEX "audit_log_operation_id_idx" ON "audit_log" ("operation_id");
@@ -0,0 +1,19 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "permissions" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "role" varchar NOT NULL,
+    "resource" varchar NOT NULL DEFAULT '',
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE CASCADE,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "permissions_admin_id_idx" ON "permissions" ("admin_id");
@@ -0,0 +1,28 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "api_tokens" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "name" varchar NOT NULL,
+    "prefix" varchar NOT NULL,
+    "hash" varchar NOT NULL,
+    "role" varchar NOT NULL,
+    "resources" jsonb NOT NULL,
+    "expires" timestamptz,
+    "revoked" timestamptz,
+    "last_used" timestamptz,
+    "last_used_ip" varchar NOT NULL DEFAULT '',
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "api_tokens_hash_idx" ON "api_tokens" ("hash");
+
+--bun:split
+
+CREATE INDEX "api_tokens_admin_id_idx" ON "api_tokens" ("admin_id");
@@ -0,0 +1,36 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "admin_sessions" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "family_id" varchar NOT NULL,
+    "token_hash" varchar NOT NULL,
+    "two_fa" boolean NOT NULL DEFAULT false,
+    "ip" varchar NOT NULL DEFAULT '',
+    "user_agent" varchar NOT NULL DEFAULT '',
+    "started" timestamptz NOT NULL,
+    "expires" timestamptz NOT NULL,
+    "rotated" timestamptz,
+    "revoked" timestamptz,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "admin_sessions_token_hash_idx" ON "admin_sessions" ("token_hash");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_family_id_idx" ON "admin_sessions" ("family_id");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_admin_id_idx" ON "admin_sessions" ("admin_id");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_expires_idx" ON "admin_sessions" ("expires");
@@ -0,0 +1,10 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+DROP TABLE IF EXISTS "recovery_codes" CASCADE;
+
+--bun:split
+
+DROP TABLE IF EXISTS "webauthn_credentials" CASCADE;
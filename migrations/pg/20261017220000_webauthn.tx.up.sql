@@ -0,0 +1,47 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "webauthn_credentials" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "name" varchar NOT NULL,
+    "credential_id" varchar NOT NULL,
+    "public_key" bytea NOT NULL,
+    "attestation_type" varchar NOT NULL DEFAULT '',
+    "aaguid" bytea,
+    "sign_count" bigint NOT NULL DEFAULT 0,
+    "transports" jsonb NOT NULL,
+    "backup_eligible" boolean NOT NULL DEFAULT false,
+    "backup_state" boolean NOT NULL DEFAULT false,
+    "last_used" timestamptz,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "webauthn_credentials_credential_id_idx" ON "webauthn_credentials" ("credential_id");
+
+--bun:split
+
+CREATE INDEX "webauthn_credentials_admin_id_idx" ON "webauthn_credentials" ("admin_id");
+
+--bun:split
+
+CREATE TABLE "recovery_codes" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "hash" varchar NOT NULL,
+    "used" timestamptz,
+    "created" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "recovery_codes_hash_idx" ON "recovery_codes" ("hash");
+
+--bun:split
+
+CREATE INDEX "recovery_codes_admin_id_idx" ON "recovery_codes" ("admin_id");
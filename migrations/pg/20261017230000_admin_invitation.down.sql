@@ -0,0 +1,10 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+DROP TABLE IF EXISTS "admin_invitations" CASCADE;
+
+--bun:split
+
+ALTER TABLE "admins" DROP COLUMN IF EXISTS "disabled";
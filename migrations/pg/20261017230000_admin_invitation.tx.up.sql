@@ -0,0 +1,25 @@
+SET statement_timeout = 0;
+
+--==============================================================================
+--bun:split
+
+ALTER TABLE "admins" ADD COLUMN "disabled" timestamptz;
+
+--bun:split
+
+CREATE TABLE "admin_invitations" (
+    "id" integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    "email" varchar NOT NULL,
+    "role" varchar NOT NULL,
+    "site_ids" jsonb NOT NULL,
+    "invited_by" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "expires" timestamptz NOT NULL,
+    "accepted" timestamptz,
+    "created" timestamptz NOT NULL DEFAULT now(),
+    "updated" timestamptz NOT NULL DEFAULT now()
+);
+
+--bun:split
+
+CREATE INDEX "admin_invitations_email_idx" ON "admin_invitations" ("email");
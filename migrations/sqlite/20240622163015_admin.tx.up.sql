@@ -0,0 +1,19 @@
+CREATE TABLE "admins" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "avatar" text NOT NULL,
+    "email" text NOT NULL,
+    "role" text NOT NULL,
+    "password" blob NOT NULL,
+    "salt" text NOT NULL,
+    "otp" blob NOT NULL,
+    "created" datetime NOT NULL,
+    "updated" datetime NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX "admins_created_updated_idx" ON "admins" ("created", "updated");
+
+--bun:split
+
+CREATE UNIQUE INDEX "admins_email_unq" ON "admins" (lower("email"));
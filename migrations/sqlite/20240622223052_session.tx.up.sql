@@ -0,0 +1,9 @@
+CREATE TABLE "sessions" (
+    "token" text PRIMARY KEY,
+    "data" blob NOT NULL,
+    "expiry" real NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX "sessions_expiry_idx" ON "sessions" ("expiry");
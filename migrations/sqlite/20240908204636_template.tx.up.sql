@@ -0,0 +1,13 @@
+CREATE TABLE "templates" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" text NOT NULL,
+    "content" text NOT NULL DEFAULT '',
+    "enabled" boolean NOT NULL DEFAULT false,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "templates_created_updated_idx" ON "templates" ("created", "updated");
+CREATE INDEX "templates_name_enabled_idx" ON "templates" ("name", "enabled");
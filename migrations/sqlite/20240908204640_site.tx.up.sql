@@ -0,0 +1,32 @@
+CREATE TABLE "sites" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" text NOT NULL,
+    "title" text,
+    "separator" text NOT NULL DEFAULT ' - ',
+    "host" text NOT NULL,
+    "locale" text,
+    "relative_path" text,
+    "is_default" boolean NOT NULL DEFAULT false,
+    "javascript" text,
+    "stylesheet" text,
+    "metas" text NOT NULL DEFAULT '[]',
+    "metadata" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "published" datetime,
+    "expired" datetime
+);
+
+--bun:split
+
+CREATE INDEX "sites_created_updated_idx" ON "sites" ("created", "updated");
+CREATE INDEX "sites_published_expired_idx" ON "sites" ("published", "expired");
+CREATE INDEX "sites_host_is_default_idx" ON "sites" ("host", "is_default");
+
+--bun:split
+
+CREATE UNIQUE INDEX "sites_host_lifespan_unq" ON "sites" (lower("host"),
+                                                          lower(coalesce("locale", '')),
+                                                          lower(coalesce("relative_path", '')),
+                                                          coalesce("published", ''),
+                                                          coalesce("expired", ''));
@@ -0,0 +1,35 @@
+CREATE TABLE "pages" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "parent_id" integer REFERENCES "pages"("id") ON DELETE SET NULL,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "name" text NOT NULL,
+    "title" text,
+    "pattern" text NOT NULL DEFAULT '_page_cms',
+    "alias" text,
+    "slug" text,
+    "url" text,
+    "custom_url" text,
+    "template" text NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    "decorate" boolean NOT NULL DEFAULT true,
+    "javascript" text,
+    "stylesheet" text,
+    "headers" text NOT NULL DEFAULT '{}',
+    "metas" text NOT NULL DEFAULT '[]',
+    "metadata" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "published" datetime,
+    "expired" datetime
+);
+
+--bun:split
+
+CREATE INDEX "pages_created_updated_idx" ON "pages" ("created", "updated");
+CREATE INDEX "pages_published_expired_idx" ON "pages" ("published", "expired");
+CREATE INDEX "pages_pattern_idx" ON "pages" ("pattern");
+CREATE INDEX "pages_alias_idx" ON "pages" ("alias");
+CREATE INDEX "pages_slug_idx" ON "pages" ("slug");
+CREATE INDEX "pages_url_idx" ON "pages" ("url");
+CREATE INDEX "pages_custom_url_idx" ON "pages" ("custom_url");
+CREATE INDEX "pages_position_idx" ON "pages" ("position");
@@ -0,0 +1,4 @@
+CREATE TABLE "pages_configuration" (
+    "key" text PRIMARY KEY,
+    "value" text NOT NULL
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "nodes";
+
+--==============================================================================
+--bun:split
+
+DROP TABLE IF EXISTS "sequence_nodes";
@@ -0,0 +1,32 @@
+CREATE TABLE "sequence_nodes" (
+    "id" integer PRIMARY KEY AUTOINCREMENT
+);
+
+--==============================================================================
+--bun:split
+
+CREATE TABLE "nodes" (
+    "id" integer PRIMARY KEY REFERENCES "sequence_nodes"("id") ON DELETE CASCADE,
+    "parent_id" integer NOT NULL DEFAULT 0,
+    "name" text NOT NULL,
+    "label" text,
+    "uri" text,
+    "path" text NOT NULL,
+    "level" integer NOT NULL DEFAULT 0,
+    "position" integer NOT NULL DEFAULT 0,
+    "display_children" boolean NOT NULL DEFAULT true,
+    "display" boolean NOT NULL DEFAULT true,
+    "attributes" text NOT NULL DEFAULT '{}',
+    "link_attributes" text NOT NULL DEFAULT '{}',
+    "children_attributes" text NOT NULL DEFAULT '{}',
+    "label_attributes" text NOT NULL DEFAULT '{}',
+    "metadata" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "nodes_created_updated_idx" ON "nodes" ("created", "updated");
+CREATE INDEX "nodes_path_idx" ON "nodes" ("path");
+CREATE INDEX "nodes_level_idx" ON "nodes" ("level");
@@ -0,0 +1,18 @@
+CREATE TABLE "menus" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "node_id" integer REFERENCES "nodes"("id") ON DELETE SET NULL,
+    "name" text NOT NULL,
+    "handle" text NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "menus_created_updated_idx" ON "menus" ("created", "updated");
+CREATE INDEX "menus_enabled_idx" ON "menus" ("enabled");
+
+--bun:split
+
+CREATE UNIQUE INDEX "menus_handle_unq" ON "menus" (lower("handle"));
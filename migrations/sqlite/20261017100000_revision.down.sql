@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "template_revisions";
+
+--bun:split
+
+DROP TABLE IF EXISTS "page_revisions";
@@ -0,0 +1,29 @@
+CREATE TABLE "page_revisions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "record_id" integer NOT NULL REFERENCES "pages"("id") ON DELETE CASCADE,
+    "version" integer NOT NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "page_revisions_created_idx" ON "page_revisions" ("created");
+CREATE UNIQUE INDEX "page_revisions_record_id_version_unq" ON "page_revisions" ("record_id", "version");
+
+--bun:split
+
+CREATE TABLE "template_revisions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "record_id" integer NOT NULL REFERENCES "templates"("id") ON DELETE CASCADE,
+    "version" integer NOT NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "template_revisions_created_idx" ON "template_revisions" ("created");
+CREATE UNIQUE INDEX "template_revisions_record_id_version_unq" ON "template_revisions" ("record_id", "version");
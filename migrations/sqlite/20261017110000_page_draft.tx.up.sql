@@ -0,0 +1,16 @@
+CREATE TABLE "page_drafts" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "page_id" integer NOT NULL REFERENCES "pages"("id") ON DELETE CASCADE,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "data" text NOT NULL DEFAULT '{}',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "page_drafts_created_updated_idx" ON "page_drafts" ("created", "updated");
+
+--bun:split
+
+CREATE UNIQUE INDEX "page_drafts_page_id_unq" ON "page_drafts" ("page_id");
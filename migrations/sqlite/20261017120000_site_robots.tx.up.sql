@@ -0,0 +1 @@
+ALTER TABLE "sites" ADD COLUMN "robots" text;
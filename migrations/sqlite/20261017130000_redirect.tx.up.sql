@@ -0,0 +1,26 @@
+CREATE TABLE "redirects" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE SET NULL,
+    "type" text NOT NULL DEFAULT 'exact',
+    "source" text NOT NULL,
+    "target" text,
+    "status" integer NOT NULL DEFAULT 301,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "hits" integer NOT NULL DEFAULT 0,
+    "last_hit" datetime,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "redirects_created_updated_idx" ON "redirects" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "redirects_site_id_enabled_idx" ON "redirects" ("site_id", "enabled");
+
+--bun:split
+
+CREATE UNIQUE INDEX "redirects_site_id_type_source_unq" ON "redirects" (COALESCE("site_id", 0), "type", "source");
@@ -0,0 +1,31 @@
+CREATE TABLE "blocks" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE CASCADE,
+    "parent_id" integer REFERENCES "blocks"("id") ON DELETE CASCADE,
+    "region" text NOT NULL,
+    "type" text NOT NULL,
+    "name" text,
+    "settings" text NOT NULL DEFAULT '{}',
+    "position" integer NOT NULL DEFAULT 0,
+    "shared" boolean NOT NULL DEFAULT false,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "blocks_created_updated_idx" ON "blocks" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "blocks_page_id_idx" ON "blocks" ("page_id");
+
+--bun:split
+
+CREATE INDEX "blocks_parent_id_idx" ON "blocks" ("parent_id");
+
+--bun:split
+
+CREATE INDEX "blocks_shared_site_id_idx" ON "blocks" ("shared", "site_id");
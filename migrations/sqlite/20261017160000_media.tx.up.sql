@@ -0,0 +1,30 @@
+CREATE TABLE "media" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "site_id" integer REFERENCES "sites"("id") ON DELETE SET NULL,
+    "folder" text NOT NULL DEFAULT '',
+    "name" text NOT NULL,
+    "key" text NOT NULL,
+    "alt" text NOT NULL DEFAULT '',
+    "mime" text NOT NULL,
+    "size" integer NOT NULL DEFAULT 0,
+    "width" integer NOT NULL DEFAULT 0,
+    "height" integer NOT NULL DEFAULT 0,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "media_key_idx" ON "media" ("key");
+
+--bun:split
+
+CREATE INDEX "media_created_updated_idx" ON "media" ("created", "updated");
+
+--bun:split
+
+CREATE INDEX "media_folder_idx" ON "media" ("folder");
+
+--bun:split
+
+CREATE INDEX "media_site_id_idx" ON "media" ("site_id");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "pages_translation_group_id_site_id_idx";
+
+--bun:split
+
+-- SQLite cannot drop a column taking part in a foreign key, "pages"."translation_group_id" stays unused.
+DROP TABLE IF EXISTS "translation_groups";
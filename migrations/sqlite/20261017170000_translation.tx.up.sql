@@ -0,0 +1,18 @@
+CREATE TABLE "translation_groups" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" text NOT NULL,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "translation_groups_created_updated_idx" ON "translation_groups" ("created", "updated");
+
+--bun:split
+
+ALTER TABLE "pages" ADD COLUMN "translation_group_id" integer REFERENCES "translation_groups"("id") ON DELETE SET NULL;
+
+--bun:split
+
+CREATE UNIQUE INDEX "pages_translation_group_id_site_id_idx" ON "pages" ("translation_group_id", "site_id");
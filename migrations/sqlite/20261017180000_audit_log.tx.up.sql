@@ -0,0 +1,29 @@
+CREATE TABLE "audit_log" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "operation_id" text NOT NULL DEFAULT '',
+    "admin_id" integer,
+    "admin_email" text NOT NULL DEFAULT '',
+    "action" text NOT NULL,
+    "target_type" text NOT NULL,
+    "target_id" text NOT NULL DEFAULT '',
+    "data_before" text,
+    "data_after" text,
+    "ip" text NOT NULL DEFAULT '',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "audit_log_created_idx" ON "audit_log" ("created");
+
+--bun:split
+
+CREATE INDEX "audit_log_target_idx" ON "audit_log" ("target_type", "target_id");
+
+--bun:split
+
+CREATE INDEX "audit_log_admin_id_idx" ON "audit_log" ("admin_id");
+
+--bun:split
+
+CREATE INDEX "audit_log_operation_id_idx" ON "audit_log" ("operation_id");
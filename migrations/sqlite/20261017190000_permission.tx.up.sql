@@ -0,0 +1,14 @@
+CREATE TABLE "permissions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "role" text NOT NULL,
+    "resource" text NOT NULL DEFAULT '',
+    "site_id" integer REFERENCES "sites"("id") ON DELETE CASCADE,
+    "page_id" integer REFERENCES "pages"("id") ON DELETE CASCADE,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "permissions_admin_id_idx" ON "permissions" ("admin_id");
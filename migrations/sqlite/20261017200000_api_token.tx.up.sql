@@ -0,0 +1,23 @@
+CREATE TABLE "api_tokens" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "hash" text NOT NULL,
+    "role" text NOT NULL,
+    "resources" text NOT NULL,
+    "expires" datetime,
+    "revoked" datetime,
+    "last_used" datetime,
+    "last_used_ip" text NOT NULL DEFAULT '',
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "api_tokens_hash_idx" ON "api_tokens" ("hash");
+
+--bun:split
+
+CREATE INDEX "api_tokens_admin_id_idx" ON "api_tokens" ("admin_id");
@@ -0,0 +1,31 @@
+CREATE TABLE "admin_sessions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "family_id" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "two_fa" boolean NOT NULL DEFAULT false,
+    "ip" text NOT NULL DEFAULT '',
+    "user_agent" text NOT NULL DEFAULT '',
+    "started" datetime NOT NULL,
+    "expires" datetime NOT NULL,
+    "rotated" datetime,
+    "revoked" datetime,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "admin_sessions_token_hash_idx" ON "admin_sessions" ("token_hash");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_family_id_idx" ON "admin_sessions" ("family_id");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_admin_id_idx" ON "admin_sessions" ("admin_id");
+
+--bun:split
+
+CREATE INDEX "admin_sessions_expires_idx" ON "admin_sessions" ("expires");
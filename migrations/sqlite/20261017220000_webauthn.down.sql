@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "recovery_codes";
+
+--bun:split
+
+DROP TABLE IF EXISTS "webauthn_credentials";
@@ -0,0 +1,42 @@
+CREATE TABLE "webauthn_credentials" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "name" text NOT NULL,
+    "credential_id" text NOT NULL,
+    "public_key" blob NOT NULL,
+    "attestation_type" text NOT NULL DEFAULT '',
+    "aaguid" blob,
+    "sign_count" integer NOT NULL DEFAULT 0,
+    "transports" text NOT NULL,
+    "backup_eligible" boolean NOT NULL DEFAULT false,
+    "backup_state" boolean NOT NULL DEFAULT false,
+    "last_used" datetime,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "webauthn_credentials_credential_id_idx" ON "webauthn_credentials" ("credential_id");
+
+--bun:split
+
+CREATE INDEX "webauthn_credentials_admin_id_idx" ON "webauthn_credentials" ("admin_id");
+
+--bun:split
+
+CREATE TABLE "recovery_codes" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "admin_id" integer NOT NULL REFERENCES "admins"("id") ON DELETE CASCADE,
+    "hash" text NOT NULL,
+    "used" datetime,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX "recovery_codes_hash_idx" ON "recovery_codes" ("hash");
+
+--bun:split
+
+CREATE INDEX "recovery_codes_admin_id_idx" ON "recovery_codes" ("admin_id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "admin_invitations";
+
+--bun:split
+
+ALTER TABLE "admins" DROP COLUMN "disabled";
@@ -0,0 +1,20 @@
+ALTER TABLE "admins" ADD COLUMN "disabled" datetime;
+
+--bun:split
+
+CREATE TABLE "admin_invitations" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "email" text NOT NULL,
+    "role" text NOT NULL,
+    "site_ids" text NOT NULL,
+    "invited_by" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "admin_id" integer REFERENCES "admins"("id") ON DELETE SET NULL,
+    "expires" datetime NOT NULL,
+    "accepted" datetime,
+    "created" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated" datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX "admin_invitations_email_idx" ON "admin_invitations" ("email");
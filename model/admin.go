@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"math"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/gowool/cms/internal"
 )
 
+var ErrAdminDisabled = errors.New("admin: is disabled")
+
 type Role int8
 
 const (
@@ -50,21 +53,26 @@ func (b Role) String() string {
 }
 
 type Admin struct {
-	ID       int64     `json:"id,omitempty" required:"true"`
-	Avatar   string    `json:"avatar,omitempty" required:"true"`
-	Email    string    `json:"email,omitempty" required:"true" format:"email"`
-	Role     Role      `json:"role,omitempty" required:"true"`
-	Salt     string    `json:"_" hidden:"true"`
-	Password Password  `json:"-" hidden:"true"`
-	OTP      OTP       `json:"-" hidden:"true"`
-	Created  time.Time `json:"created,omitempty" required:"true"`
-	Updated  time.Time `json:"updated,omitempty" required:"true"`
+	ID       int64      `json:"id,omitempty" required:"true"`
+	Avatar   string     `json:"avatar,omitempty" required:"true"`
+	Email    string     `json:"email,omitempty" required:"true" format:"email"`
+	Role     Role       `json:"role,omitempty" required:"true"`
+	Salt     string     `json:"_" hidden:"true"`
+	Password Password   `json:"-" hidden:"true"`
+	OTP      OTP        `json:"-" hidden:"true"`
+	Disabled *time.Time `json:"disabled,omitempty" required:"false"`
+	Created  time.Time  `json:"created,omitempty" required:"true"`
+	Updated  time.Time  `json:"updated,omitempty" required:"true"`
 }
 
 func (a Admin) GetID() int64 {
 	return a.ID
 }
 
+func (a Admin) IsDisabled() bool {
+	return a.Disabled != nil
+}
+
 func (a Admin) WithRandomSalt() Admin {
 	a.Salt = internal.RandomString(50)
 	return a
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// AdminInvitation lets the invitee create the admin with the role, the role is granted
+// on the sites only when they are set.
+type AdminInvitation struct {
+	ID        int64      `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	Email     string     `json:"email,omitempty" yaml:"email,omitempty" required:"true" format:"email"`
+	Role      Role       `json:"role,omitempty" yaml:"role,omitempty" required:"true"`
+	SiteIDs   []int64    `json:"site_ids,omitempty" yaml:"site_ids,omitempty" required:"false"`
+	InvitedBy *int64     `json:"invited_by,omitempty" yaml:"invited_by,omitempty" required:"false"`
+	AdminID   *int64     `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"false"`
+	Expires   time.Time  `json:"expires,omitempty" yaml:"expires,omitempty" required:"true"`
+	Accepted  *time.Time `json:"accepted,omitempty" yaml:"accepted,omitempty" required:"false"`
+	Created   time.Time  `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated   time.Time  `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (i AdminInvitation) GetID() int64 {
+	return i.ID
+}
+
+func (i AdminInvitation) IsPending(now time.Time) bool {
+	return i.Accepted == nil && i.Expires.After(now)
+}
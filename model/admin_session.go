@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// AdminSession is a refresh token of an admin. A refresh rotates the token within its family,
+// the family is the session as seen by the admin and spans from sign-in until Expires.
+type AdminSession struct {
+	ID        int64      `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	AdminID   int64      `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"true"`
+	FamilyID  string     `json:"family_id,omitempty" yaml:"family_id,omitempty" required:"true"`
+	TokenHash string     `json:"-" yaml:"-" hidden:"true"`
+	TwoFA     bool       `json:"two_fa,omitempty" yaml:"two_fa,omitempty" required:"false"`
+	IP        string     `json:"ip,omitempty" yaml:"ip,omitempty" required:"false"`
+	UserAgent string     `json:"user_agent,omitempty" yaml:"user_agent,omitempty" required:"false"`
+	Started   time.Time  `json:"started,omitempty" yaml:"started,omitempty" required:"true"`
+	Expires   time.Time  `json:"expires,omitempty" yaml:"expires,omitempty" required:"true"`
+	Rotated   *time.Time `json:"rotated,omitempty" yaml:"rotated,omitempty" required:"false"`
+	Revoked   *time.Time `json:"revoked,omitempty" yaml:"revoked,omitempty" required:"false"`
+	Created   time.Time  `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated   time.Time  `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (s AdminSession) GetID() int64 {
+	return s.ID
+}
+
+// IsActive reports whether the token may be exchanged, idle is measured from the issue of the token.
+func (s AdminSession) IsActive(now time.Time, idle time.Duration) bool {
+	return s.Rotated == nil && s.Revoked == nil && now.Before(s.Expires) && (idle <= 0 || now.Before(s.Created.Add(idle)))
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// APIToken is a personal access token of an admin for machine clients. Only the hash of
+// the secret is stored, the prefix identifies the token in listings.
+type APIToken struct {
+	ID         int64      `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	AdminID    int64      `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"true"`
+	Name       string     `json:"name,omitempty" yaml:"name,omitempty" required:"true"`
+	Prefix     string     `json:"prefix,omitempty" yaml:"prefix,omitempty" required:"true"`
+	Hash       string     `json:"-" yaml:"-" hidden:"true"`
+	Role       Role       `json:"role,omitempty" yaml:"role,omitempty" required:"true"`
+	Resources  []string   `json:"resources,omitempty" yaml:"resources,omitempty" required:"false"`
+	Expires    *time.Time `json:"expires,omitempty" yaml:"expires,omitempty" required:"false"`
+	Revoked    *time.Time `json:"revoked,omitempty" yaml:"revoked,omitempty" required:"false"`
+	LastUsed   *time.Time `json:"last_used,omitempty" yaml:"last_used,omitempty" required:"false"`
+	LastUsedIP string     `json:"last_used_ip,omitempty" yaml:"last_used_ip,omitempty" required:"false"`
+	Created    time.Time  `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated    time.Time  `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (t APIToken) GetID() int64 {
+	return t.ID
+}
+
+func (t APIToken) IsActive(now time.Time) bool {
+	return t.Revoked == nil && (t.Expires == nil || t.Expires.After(now))
+}
+
+// Allows reports whether the scopes of the token cover the role on the resource,
+// an empty resource stands for the calls outside any resource.
+func (t APIToken) Allows(resource string, role Role) bool {
+	if role > t.Role {
+		return false
+	}
+	return len(t.Resources) == 0 || (resource != "" && slices.Contains(t.Resources, resource))
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate = AuditAction("create")
+	AuditUpdate = AuditAction("update")
+	AuditDelete = AuditAction("delete")
+
+	AuditLoginFailed = AuditAction("login_failed")
+	AuditLockout     = AuditAction("lockout")
+)
+
+// AuditLog records a change of a target made through the admin API, Before is null
+// for a created target and After is null for a deleted one. The failed sign-ins are
+// recorded against the auth target.
+type AuditLog struct {
+	ID          int64           `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	OperationID string          `json:"operation_id,omitempty" yaml:"operation_id,omitempty" required:"false"`
+	AdminID     *int64          `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"false"`
+	AdminEmail  string          `json:"admin_email,omitempty" yaml:"admin_email,omitempty" required:"false"`
+	Action      AuditAction     `json:"action,omitempty" yaml:"action,omitempty" required:"true" enum:"create,update,delete,login_failed,lockout"`
+	TargetType  string          `json:"target_type,omitempty" yaml:"target_type,omitempty" required:"true"`
+	TargetID    string          `json:"target_id,omitempty" yaml:"target_id,omitempty" required:"false"`
+	Before      json.RawMessage `json:"before,omitempty" yaml:"before,omitempty" required:"false"`
+	After       json.RawMessage `json:"after,omitempty" yaml:"after,omitempty" required:"false"`
+	IP          string          `json:"ip,omitempty" yaml:"ip,omitempty" required:"false"`
+	Created     time.Time       `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+}
+
+func (l AuditLog) GetID() int64 {
+	return l.ID
+}
+
+func (l AuditLog) String() string {
+	return string(l.Action) + " " + l.TargetType + " " + l.TargetID
+}
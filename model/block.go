@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+type Block struct {
+	ID       int64          `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	SiteID   *int64         `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID   *int64         `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false"`
+	ParentID *int64         `json:"parent_id,omitempty" yaml:"parent_id,omitempty" required:"false"`
+	Region   string         `json:"region,omitempty" yaml:"region,omitempty" required:"true"`
+	Type     string         `json:"type,omitempty" yaml:"type,omitempty" required:"true"`
+	Name     string         `json:"name,omitempty" yaml:"name,omitempty" required:"false"`
+	Settings map[string]any `json:"settings,omitempty" yaml:"settings,omitempty" required:"false"`
+	Position int            `json:"position,omitempty" yaml:"position,omitempty" required:"false"`
+	Shared   bool           `json:"shared,omitempty" yaml:"shared,omitempty" required:"false"`
+	Enabled  bool           `json:"enabled,omitempty" yaml:"enabled,omitempty" required:"true"`
+	Created  time.Time      `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated  time.Time      `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+	Children []Block        `json:"-" yaml:"-"`
+}
+
+func (b Block) GetID() int64 {
+	return b.ID
+}
+
+func (b Block) Scope() Scope {
+	return Scope{Resource: ResourceBlock, SiteID: b.SiteID, ParentID: b.PageID}
+}
+
+func (b Block) String() string {
+	if b.Name == "" {
+		return b.Type
+	}
+	return b.Name
+}
+
+// BlockTree returns the top level blocks of the region with their nested
+// blocks attached, children belong to the region of their parent.
+func BlockTree(blocks []Block, region string) []Block {
+	ids := make(map[int64]struct{}, len(blocks))
+	for _, b := range blocks {
+		ids[b.ID] = struct{}{}
+	}
+
+	children := make(map[int64][]Block)
+	var roots []Block
+	for _, b := range blocks {
+		if b.ParentID != nil {
+			if _, ok := ids[*b.ParentID]; ok {
+				children[*b.ParentID] = append(children[*b.ParentID], b)
+				continue
+			}
+		}
+		if b.Region == region {
+			roots = append(roots, b)
+		}
+	}
+
+	var attach func([]Block, int) []Block
+	attach = func(items []Block, depth int) []Block {
+		for i := range items {
+			// guard against cycles of parent references
+			if depth < 16 {
+				items[i].Children = attach(children[items[i].ID], depth+1)
+			}
+		}
+		return items
+	}
+	return attach(roots, 0)
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type PageDraft struct {
+	ID      int64     `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	PageID  int64     `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"true"`
+	AdminID *int64    `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"false"`
+	Page    Page      `json:"page" yaml:"page" required:"true"`
+	Created time.Time `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated time.Time `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (d PageDraft) GetID() int64 {
+	return d.ID
+}
+
+// Live returns the draft content as the page it is staged for.
+func (d PageDraft) Live() Page {
+	p := d.Page
+	p.ID = d.PageID
+	return p
+}
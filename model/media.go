@@ -0,0 +1,56 @@
+package model
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+)
+
+type Media struct {
+	ID      int64     `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	SiteID  *int64    `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	Folder  string    `json:"folder,omitempty" yaml:"folder,omitempty" required:"false"`
+	Name    string    `json:"name,omitempty" yaml:"name,omitempty" required:"true"`
+	Key     string    `json:"key,omitempty" yaml:"key,omitempty" required:"true"`
+	Alt     string    `json:"alt,omitempty" yaml:"alt,omitempty" required:"false"`
+	MIME    string    `json:"mime,omitempty" yaml:"mime,omitempty" required:"true"`
+	Size    int64     `json:"size,omitempty" yaml:"size,omitempty" required:"true"`
+	Width   int       `json:"width,omitempty" yaml:"width,omitempty" required:"false"`
+	Height  int       `json:"height,omitempty" yaml:"height,omitempty" required:"false"`
+	Created time.Time `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated time.Time `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (m Media) GetID() int64 {
+	return m.ID
+}
+
+func (m Media) Scope() Scope {
+	return Scope{Resource: ResourceMedia, SiteID: m.SiteID}
+}
+
+func (m Media) String() string {
+	if m.Name == "" {
+		return m.Key
+	}
+	return m.Name
+}
+
+func (m Media) IsImage() bool {
+	return strings.HasPrefix(m.MIME, "image/")
+}
+
+// MediaFolder normalizes a folder path to slash separated slugs without leading or trailing slashes.
+func MediaFolder(folder string) string {
+	segments := strings.Split(path.Clean("/"+folder), "/")
+
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment = slug.Make(segment); segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+	return strings.Join(parts, "/")
+}
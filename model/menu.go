@@ -21,6 +21,10 @@ func (m Menu) GetID() int64 {
 	return m.ID
 }
 
+func (m Menu) Scope() Scope {
+	return Scope{Resource: ResourceMenu}
+}
+
 func (m Menu) String() string {
 	if m.Name == "" {
 		return "n/a"
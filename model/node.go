@@ -34,6 +34,10 @@ func (n Node) GetID() int64 {
 	return n.ID
 }
 
+func (n Node) Scope() Scope {
+	return Scope{Resource: ResourceNode}
+}
+
 func (n Node) String() string {
 	if n.Name == "" {
 		return "n/a"
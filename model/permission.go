@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+const (
+	ResourcePage     = "page"
+	ResourceSite     = "site"
+	ResourceTemplate = "template"
+	ResourceMenu     = "menu"
+	ResourceNode     = "node"
+	ResourceBlock    = "block"
+	ResourceRedirect = "redirect"
+	ResourceMedia    = "media"
+)
+
+// Scope locates a record for the permission checks. PageID is set for a page record,
+// ParentID is the page the record belongs to, the parent page for a page record.
+type Scope struct {
+	Resource string
+	SiteID   *int64
+	PageID   *int64
+	ParentID *int64
+}
+
+// Scoped is implemented by the models access to which can be granted with permissions.
+type Scoped interface {
+	Scope() Scope
+}
+
+// Permission grants the role to the admin on the records within its scope in addition
+// to the role of the admin. An empty resource matches every resource, a missing site
+// matches every site and a page narrows the scope to the page and its descendants.
+type Permission struct {
+	ID       int64     `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	AdminID  int64     `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"true"`
+	Role     Role      `json:"role,omitempty" yaml:"role,omitempty" required:"true"`
+	Resource string    `json:"resource,omitempty" yaml:"resource,omitempty" required:"false"`
+	SiteID   *int64    `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID   *int64    `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false"`
+	Created  time.Time `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated  time.Time `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (p Permission) GetID() int64 {
+	return p.ID
+}
+
+func (p Permission) String() string {
+	resource := p.Resource
+	if resource == "" {
+		resource = "*"
+	}
+	return p.Role.String() + " " + resource
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2"
+
+	"github.com/gowool/cms/internal"
+)
+
+// RedirectMatchTimeout limits the time a regex rule may take to match a path,
+// the rules are written by the admins and regexp2 backtracks.
+var RedirectMatchTimeout = 100 * time.Millisecond
+
+var RedirectTypes = []RedirectType{RedirectExact, RedirectPrefix, RedirectRegex}
+
+const (
+	RedirectExact  = RedirectType("exact")
+	RedirectPrefix = RedirectType("prefix")
+	RedirectRegex  = RedirectType("regex")
+)
+
+type RedirectType string
+
+func (t RedirectType) IsZero() bool {
+	return t == ""
+}
+
+func (t RedirectType) String() string {
+	return string(t)
+}
+
+type Redirect struct {
+	ID      int64        `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	SiteID  *int64       `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"false"`
+	PageID  *int64       `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"false"`
+	Type    RedirectType `json:"type,omitempty" yaml:"type,omitempty" required:"true" enum:"exact,prefix,regex"`
+	Source  string       `json:"source,omitempty" yaml:"source,omitempty" required:"true"`
+	Target  string       `json:"target,omitempty" yaml:"target,omitempty" required:"false"`
+	Status  int          `json:"status,omitempty" yaml:"status,omitempty" required:"true" enum:"301,302,307,308,410"`
+	Enabled bool         `json:"enabled,omitempty" yaml:"enabled,omitempty" required:"true"`
+	Hits    int64        `json:"hits,omitempty" yaml:"hits,omitempty" required:"false"`
+	LastHit *time.Time   `json:"last_hit,omitempty" yaml:"last_hit,omitempty" required:"false"`
+	Created time.Time    `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated time.Time    `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (r Redirect) GetID() int64 {
+	return r.ID
+}
+
+func (r Redirect) Scope() Scope {
+	return Scope{Resource: ResourceRedirect, SiteID: r.SiteID, ParentID: r.PageID}
+}
+
+func (r Redirect) String() string {
+	return r.Source
+}
+
+func (r Redirect) IsGone() bool {
+	return r.Status == http.StatusGone
+}
+
+// Regexp compiles the source of a regex rule limited by RedirectMatchTimeout.
+func (r Redirect) Regexp() (*regexp2.Regexp, bool) {
+	re, ok := internal.Regexp(r.Source)
+	if ok {
+		re.MatchTimeout = RedirectMatchTimeout
+	}
+	return re, ok
+}
+
+// Location returns the target for the given path when the rule matches it,
+// the target of a prefix rule gets the rest of the path, the target of a
+// regex rule may refer to the groups of the source expression like $1.
+func (r Redirect) Location(path string) (string, bool) {
+	if r.Type == RedirectRegex {
+		re, ok := r.Regexp()
+		if !ok {
+			return "", false
+		}
+		return r.RegexpLocation(re, path)
+	}
+
+	switch r.Type {
+	case RedirectExact:
+		return r.Target, path == r.Source
+	case RedirectPrefix:
+		if !strings.HasPrefix(path, r.Source) {
+			return "", false
+		}
+		return r.Target + path[len(r.Source):], true
+	default:
+		return "", false
+	}
+}
+
+// RegexpLocation is Location of a regex rule with its source compiled by Regexp,
+// so the expression may be compiled once and used for many paths.
+func (r Redirect) RegexpLocation(re *regexp2.Regexp, path string) (string, bool) {
+	if ok, _ := re.MatchString(path); !ok {
+		return "", false
+	}
+	if r.IsGone() {
+		return "", true
+	}
+	target, err := re.Replace(path, r.Target, -1, 1)
+	if err != nil {
+		return "", false
+	}
+	return target, true
+}
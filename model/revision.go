@@ -0,0 +1,68 @@
+package model
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+type Revision[T any] struct {
+	ID       int64     `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	RecordID int64     `json:"record_id,omitempty" yaml:"record_id,omitempty" required:"true"`
+	Version  int       `json:"version,omitempty" yaml:"version,omitempty" required:"true"`
+	AdminID  *int64    `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"false"`
+	Data     T         `json:"data" yaml:"data" required:"true"`
+	Created  time.Time `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+}
+
+func (r Revision[T]) GetID() int64 {
+	return r.ID
+}
+
+type RevisionChange struct {
+	Field string `json:"field" yaml:"field" required:"true"`
+	From  any    `json:"from,omitempty" yaml:"from,omitempty" required:"false"`
+	To    any    `json:"to,omitempty" yaml:"to,omitempty" required:"false"`
+}
+
+// Diff compares the JSON representation of both snapshots and returns
+// the changed top level fields sorted by name.
+func (r Revision[T]) Diff(to Revision[T]) ([]RevisionChange, error) {
+	from, err := fields(r.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := fields(to.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	union := maps.Clone(from)
+	maps.Copy(union, next)
+	keys := slices.Sorted(maps.Keys(union))
+
+	changes := make([]RevisionChange, 0, len(keys))
+	for _, key := range keys {
+		if reflect.DeepEqual(from[key], next[key]) {
+			continue
+		}
+		changes = append(changes, RevisionChange{Field: key, From: from[key], To: next[key]})
+	}
+	return slices.Clip(changes), nil
+}
+
+func fields(data any) (map[string]any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err = json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
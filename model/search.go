@@ -0,0 +1,101 @@
+package model
+
+import (
+	"html/template"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page.Metadata key used to keep a page out of the search index.
+const SearchMetaExclude = "search_exclude"
+
+type SearchDocument struct {
+	PageID      int64      `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"true"`
+	SiteID      int64      `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"true"`
+	Locale      string     `json:"locale,omitempty" yaml:"locale,omitempty" required:"false"`
+	URL         string     `json:"url,omitempty" yaml:"url,omitempty" required:"true"`
+	Title       string     `json:"title,omitempty" yaml:"title,omitempty" required:"false"`
+	Name        string     `json:"name,omitempty" yaml:"name,omitempty" required:"false"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty" required:"false"`
+	Keywords    string     `json:"keywords,omitempty" yaml:"keywords,omitempty" required:"false"`
+	Metadata    string     `json:"metadata,omitempty" yaml:"metadata,omitempty" required:"false"`
+	Content     string     `json:"content,omitempty" yaml:"content,omitempty" required:"false"`
+	Published   *time.Time `json:"published,omitempty" yaml:"published,omitempty" required:"false"`
+	Expired     *time.Time `json:"expired,omitempty" yaml:"expired,omitempty" required:"false"`
+	Updated     time.Time  `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+// NewSearchDocument builds the indexed representation of the page, the
+// rendered content is not known here and is indexed separately.
+func NewSearchDocument(page Page, site Site) SearchDocument {
+	doc := SearchDocument{
+		PageID:    page.ID,
+		SiteID:    page.SiteID,
+		Locale:    site.Locale,
+		URL:       page.URL,
+		Title:     page.Title,
+		Name:      page.Name,
+		Published: page.Published,
+		Expired:   page.Expired,
+		Updated:   page.Updated,
+	}
+
+	for _, meta := range page.Metas {
+		switch strings.ToLower(meta.Key) {
+		case "description", "og:description", "twitter:description":
+			if doc.Description == "" {
+				doc.Description = meta.Content
+			}
+		case "keywords":
+			doc.Keywords = meta.Content
+		}
+	}
+
+	values := make([]string, 0, len(page.Metadata))
+	for _, key := range slices.Sorted(maps.Keys(page.Metadata)) {
+		if !strings.HasPrefix(key, "sitemap_") && !strings.HasPrefix(key, "search_") {
+			values = append(values, page.Metadata[key])
+		}
+	}
+	doc.Metadata = strings.Join(values, " ")
+
+	return doc
+}
+
+func (d SearchDocument) IsEnabled(now time.Time) bool {
+	return Page{Published: d.Published, Expired: d.Expired}.IsEnabled(now)
+}
+
+type SearchQuery struct {
+	SiteID int64
+	Locale string
+	Text   string
+	// Now filters out documents of pages which are not published at the moment,
+	// zero value disables lifespan filtering.
+	Now    time.Time
+	Offset int
+	Limit  int
+}
+
+type SearchHit struct {
+	PageID  int64         `json:"page_id,omitempty" yaml:"page_id,omitempty" required:"true"`
+	SiteID  int64         `json:"site_id,omitempty" yaml:"site_id,omitempty" required:"true"`
+	Locale  string        `json:"locale,omitempty" yaml:"locale,omitempty" required:"false"`
+	URL     string        `json:"url,omitempty" yaml:"url,omitempty" required:"true"`
+	Title   string        `json:"title,omitempty" yaml:"title,omitempty" required:"false"`
+	Snippet template.HTML `json:"snippet,omitempty" yaml:"snippet,omitempty" required:"false"`
+	Rank    float64       `json:"rank,omitempty" yaml:"rank,omitempty" required:"false"`
+}
+
+// InSearch reports whether the page can be indexed, internal pages,
+// pages without URL and dynamic hybrid pages are never indexed.
+func (p Page) InSearch() bool {
+	if p.IsInternal() || p.IsDynamic() || p.URL == "" {
+		return false
+	}
+	exclude, _ := strconv.ParseBool(p.Metadata[SearchMetaExclude])
+	return !exclude
+}
@@ -16,6 +16,7 @@ type Site struct {
 	IsDefault    bool              `json:"is_default,omitempty" yaml:"is_default,omitempty" required:"false"`
 	Javascript   string            `json:"javascript,omitempty" yaml:"javascript,omitempty" required:"false"`
 	Stylesheet   string            `json:"stylesheet,omitempty" yaml:"stylesheet,omitempty" required:"false"`
+	Robots       string            `json:"robots,omitempty" yaml:"robots,omitempty" required:"false"`
 	Metas        []Meta            `json:"metas,omitempty" yaml:"metas,omitempty" required:"false"`
 	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty" required:"false"`
 	Created      time.Time         `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
@@ -31,6 +32,10 @@ func (s Site) GetID() int64 {
 	return s.ID
 }
 
+func (s Site) Scope() Scope {
+	return Scope{Resource: ResourceSite, SiteID: &s.ID}
+}
+
 func (s Site) String() string {
 	if s.Name == "" {
 		return "n/a"
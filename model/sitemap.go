@@ -0,0 +1,93 @@
+package model
+
+import (
+	"encoding/xml"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SitemapNS      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	SitemapXHTMLNS = "http://www.w3.org/1999/xhtml"
+)
+
+// Page.Metadata keys used to tune the sitemap entry of a page.
+const (
+	SitemapMetaExclude    = "sitemap_exclude"
+	SitemapMetaPriority   = "sitemap_priority"
+	SitemapMetaChangeFreq = "sitemap_changefreq"
+)
+
+var SitemapChangeFreqs = []string{"always", "hourly", "daily", "weekly", "monthly", "yearly", "never"}
+
+type SitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	XHTMLNS string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+type SitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	ChangeFreq string             `xml:"changefreq,omitempty"`
+	Priority   string             `xml:"priority,omitempty"`
+	Alternates []SitemapAlternate `xml:"xhtml:link"`
+}
+
+type SitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapRef `xml:"sitemap"`
+}
+
+type SitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func SitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Hreflang converts a locale like en_US to the en-US form used by hreflang.
+func Hreflang(locale string) string {
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// InSitemap reports whether the page can be listed in the sitemap, internal
+// pages, pages without URL and dynamic hybrid pages are never listed.
+func (p Page) InSitemap() bool {
+	if p.IsInternal() || p.IsDynamic() || p.URL == "" {
+		return false
+	}
+	exclude, _ := strconv.ParseBool(p.Metadata[SitemapMetaExclude])
+	return !exclude
+}
+
+func (p Page) SitemapPriority() string {
+	value := p.Metadata[SitemapMetaPriority]
+	if priority, err := strconv.ParseFloat(value, 64); err != nil || priority < 0 || priority > 1 {
+		return ""
+	}
+	return value
+}
+
+func (p Page) SitemapChangeFreq() string {
+	value := strings.ToLower(p.Metadata[SitemapMetaChangeFreq])
+	if !slices.Contains(SitemapChangeFreqs, value) {
+		return ""
+	}
+	return value
+}
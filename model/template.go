@@ -37,6 +37,10 @@ func (t Template) GetID() int64 {
 	return t.ID
 }
 
+func (t Template) Scope() Scope {
+	return Scope{Resource: ResourceTemplate}
+}
+
 func (t Template) String() string {
 	if t.Name == "" {
 		return "n/a"
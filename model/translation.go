@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// TranslationGroup ties together the pages holding the same content in the languages of different sites.
+type TranslationGroup struct {
+	ID      int64     `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	Name    string    `json:"name,omitempty" yaml:"name,omitempty" required:"true"`
+	Created time.Time `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated time.Time `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (g TranslationGroup) GetID() int64 {
+	return g.ID
+}
+
+func (g TranslationGroup) String() string {
+	if g.Name == "" {
+		return "n/a"
+	}
+	return g.Name
+}
+
+// PageTranslation is an entry of a language switcher.
+type PageTranslation struct {
+	Locale  string `json:"locale,omitempty" yaml:"locale,omitempty"`
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+	Site    *Site  `json:"-" yaml:"-"`
+	Page    *Page  `json:"-" yaml:"-"`
+	Current bool   `json:"current,omitempty" yaml:"current,omitempty"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// WebAuthnCredential is a passkey or a security key of an admin used as the second factor,
+// CredentialID is the id of the credential in raw url base64.
+type WebAuthnCredential struct {
+	ID              int64      `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	AdminID         int64      `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"true"`
+	Name            string     `json:"name,omitempty" yaml:"name,omitempty" required:"true"`
+	CredentialID    string     `json:"credential_id,omitempty" yaml:"credential_id,omitempty" required:"true"`
+	PublicKey       []byte     `json:"-" yaml:"-" hidden:"true"`
+	AttestationType string     `json:"attestation_type,omitempty" yaml:"attestation_type,omitempty" required:"false"`
+	AAGUID          []byte     `json:"-" yaml:"-" hidden:"true"`
+	SignCount       uint32     `json:"sign_count" yaml:"sign_count" required:"true"`
+	Transports      []string   `json:"transports,omitempty" yaml:"transports,omitempty" required:"false"`
+	BackupEligible  bool       `json:"backup_eligible,omitempty" yaml:"backup_eligible,omitempty" required:"false"`
+	BackupState     bool       `json:"backup_state,omitempty" yaml:"backup_state,omitempty" required:"false"`
+	LastUsed        *time.Time `json:"last_used,omitempty" yaml:"last_used,omitempty" required:"false"`
+	Created         time.Time  `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+	Updated         time.Time  `json:"updated,omitempty" yaml:"updated,omitempty" required:"true"`
+}
+
+func (c WebAuthnCredential) GetID() int64 {
+	return c.ID
+}
+
+// RecoveryCode is a single-use second factor of an admin, only the hash of the code is kept.
+type RecoveryCode struct {
+	ID      int64      `json:"id,omitempty" yaml:"id,omitempty" required:"true"`
+	AdminID int64      `json:"admin_id,omitempty" yaml:"admin_id,omitempty" required:"true"`
+	Hash    string     `json:"-" yaml:"-" hidden:"true"`
+	Used    *time.Time `json:"used,omitempty" yaml:"used,omitempty" required:"false"`
+	Created time.Time  `json:"created,omitempty" yaml:"created,omitempty" required:"true"`
+}
+
+func (c RecoveryCode) GetID() int64 {
+	return c.ID
+}
@@ -0,0 +1,275 @@
+package cms
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	ErrOIDCState            = errors.New("oidc: state is not valid")
+	ErrOIDCNonce            = errors.New("oidc: nonce is not valid")
+	ErrOIDCEmailNotVerified = errors.New("oidc: email is not verified")
+	ErrOIDCEmailNotAllowed  = errors.New("oidc: email is not allowed")
+)
+
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes are requested in addition to openid and email.
+	Scopes []string
+	// Domains restricts the emails to the domains when set.
+	Domains []string
+	// AutoProvision creates the admins signing in for the first time with DefaultRole.
+	AutoProvision bool
+	DefaultRole   model.Role
+	// TwoFAMethods are the authentication methods of the amr claim satisfying the second factor.
+	TwoFAMethods []string
+	// StateTimeout limits the time the admin has to sign in at the provider.
+	StateTimeout time.Duration
+	// DiscoveryTimeout limits the discovery of the provider.
+	DiscoveryTimeout time.Duration
+}
+
+// oidcLogin is the state of a sign-in kept in the cache until the callback.
+type oidcLogin struct {
+	Verifier string    `json:"verifier"`
+	Nonce    string    `json:"nonce"`
+	Binding  string    `json:"binding"`
+	Expires  time.Time `json:"expires"`
+}
+
+// OIDCService signs the admins in through an OpenID Connect provider with the authorization code flow and PKCE.
+// The provider is discovered on the first sign-in, so an unreachable provider does not stop the start.
+type OIDCService struct {
+	cfg     OIDCConfig
+	repo    repository.Admin
+	admins  *AdminService
+	cache   Cache
+	counter CacheCounter
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewOIDCService(cfg OIDCConfig, repo repository.Admin, cache Cache) *OIDCService {
+	if repo == nil {
+		panic("admin repository is not specified")
+	}
+	if cache == nil {
+		panic("cache is not specified")
+	}
+	if cfg.StateTimeout <= 0 {
+		cfg.StateTimeout = 10 * time.Minute
+	}
+	if cfg.DiscoveryTimeout <= 0 {
+		cfg.DiscoveryTimeout = 10 * time.Second
+	}
+	for i := range cfg.Domains {
+		cfg.Domains[i] = strings.ToLower(cfg.Domains[i])
+	}
+
+	return &OIDCService{
+		cfg:     cfg,
+		repo:    repo,
+		admins:  NewAdminService(repo),
+		cache:   cache,
+		counter: counterOf(cache),
+	}
+}
+
+// StateTimeout returns the time the admin has to sign in at the provider.
+func (s *OIDCService) StateTimeout() time.Duration {
+	return s.cfg.StateTimeout
+}
+
+// AuthURL returns the address of the provider the admin signs in at and the binding of the sign-in
+// to the browser, e.g. kept in a cookie, the callback is accepted only with the same binding.
+func (s *OIDCService) AuthURL(ctx context.Context) (string, string, error) {
+	config, _, err := s.provider(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	binding, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+
+	login := oidcLogin{
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    nonce,
+		Binding:  hashToken(binding),
+		Expires:  time.Now().Add(s.cfg.StateTimeout),
+	}
+	if err = s.cache.Set(WithCacheTTL(ctx, s.cfg.StateTimeout), s.key(state), login); err != nil {
+		return "", "", err
+	}
+
+	return config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(login.Verifier)), binding, nil
+}
+
+// Exchange completes the sign-in with the code of the callback and the binding returned by AuthURL,
+// it returns the admin with whether the provider verified the second factor.
+func (s *OIDCService) Exchange(ctx context.Context, state, binding, code string) (model.Admin, bool, error) {
+	login, err := s.login(ctx, state)
+	if err != nil {
+		return model.Admin{}, false, err
+	}
+	if subtle.ConstantTimeCompare([]byte(login.Binding), []byte(hashToken(binding))) != 1 {
+		return model.Admin{}, false, ErrOIDCState
+	}
+
+	config, verifier, err := s.provider(ctx)
+	if err != nil {
+		return model.Admin{}, false, err
+	}
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(login.Verifier))
+	if err != nil {
+		return model.Admin{}, false, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return model.Admin{}, false, errors.New("oidc: id_token is missing")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return model.Admin{}, false, err
+	}
+	if idToken.Nonce != login.Nonce {
+		return model.Admin{}, false, ErrOIDCNonce
+	}
+
+	var claims struct {
+		Email         string   `json:"email"`
+		EmailVerified bool     `json:"email_verified"`
+		AMR           []string `json:"amr"`
+	}
+	if err = idToken.Claims(&claims); err != nil {
+		return model.Admin{}, false, err
+	}
+
+	admin, err := s.admin(ctx, claims.Email, claims.EmailVerified)
+	if err != nil {
+		return model.Admin{}, false, err
+	}
+
+	twoFA := slices.ContainsFunc(claims.AMR, func(method string) bool {
+		return slices.Contains(s.cfg.TwoFAMethods, method)
+	})
+	return admin, twoFA, nil
+}
+
+func (s *OIDCService) admin(ctx context.Context, email string, verified bool) (model.Admin, error) {
+	if !verified || email == "" {
+		return model.Admin{}, ErrOIDCEmailNotVerified
+	}
+
+	email = strings.ToLower(email)
+	if len(s.cfg.Domains) > 0 {
+		_, domain, _ := strings.Cut(email, "@")
+		if !slices.Contains(s.cfg.Domains, domain) {
+			return model.Admin{}, ErrOIDCEmailNotAllowed
+		}
+	}
+
+	admin, err := s.repo.FindByEmail(ctx, email)
+	if err == nil && admin.IsDisabled() {
+		return model.Admin{}, model.ErrAdminDisabled
+	}
+	if err == nil || !errors.Is(err, repository.ErrNotFound) || !s.cfg.AutoProvision {
+		return admin, err
+	}
+	return s.admins.Provision(ctx, email, s.cfg.DefaultRole)
+}
+
+// provider discovers the provider once, a failed discovery is tried again on the next call.
+func (s *OIDCService) provider(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.oauth2 != nil {
+		return s.oauth2, s.verifier, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.DiscoveryTimeout)
+	defer cancel()
+
+	provider, err := oidc.NewProvider(ctx, s.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc: failed to discover the provider: %w", err)
+	}
+
+	scopes := []string{oidc.ScopeOpenID, "email"}
+	for _, scope := range s.cfg.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	s.oauth2 = &oauth2.Config{
+		ClientID:     s.cfg.ClientID,
+		ClientSecret: s.cfg.ClientSecret,
+		RedirectURL:  s.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	s.verifier = provider.Verifier(&oidc.Config{ClientID: s.cfg.ClientID})
+	return s.oauth2, s.verifier, nil
+}
+
+// login takes the state out of the cache, so it cannot be replayed. The parallel
+// callbacks with the state read it both, only the first one to claim it signs in.
+func (s *OIDCService) login(ctx context.Context, state string) (login oidcLogin, err error) {
+	key := s.key(state)
+	if err = s.cache.Get(ctx, key, &login); err != nil {
+		return login, errors.Join(ErrOIDCState, err)
+	}
+	if time.Now().After(login.Expires) {
+		return login, ErrOIDCState
+	}
+
+	claimed, err := s.counter.Incr(WithCacheTTL(ctx, time.Until(login.Expires)), s.claimKey(state), 1)
+	if err != nil {
+		return login, err
+	}
+	if claimed > 1 {
+		return login, ErrOIDCState
+	}
+
+	_ = s.cache.DelByKey(ctx, key)
+	return login, nil
+}
+
+func (s *OIDCService) key(state string) string {
+	return "cms::oidc:state:" + state
+}
+
+func (s *OIDCService) claimKey(state string) string {
+	return "cms::oidc:claim:" + state
+}
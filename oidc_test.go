@@ -0,0 +1,274 @@
+package cms_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+const oidcClientID = "cms"
+
+// oidcProvider is a local OpenID Connect provider issuing the codes of the authorization
+// requests it is given, it checks the PKCE verifier and signs the id tokens with RS256.
+type oidcProvider struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	requests atomic.Int32
+	down     atomic.Bool
+
+	mu    sync.Mutex
+	codes map[string]url.Values
+	email string
+	amr   []string
+}
+
+func newOIDCProvider(t *testing.T) *oidcProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &oidcProvider{key: key, codes: make(map[string]url.Values), email: "admin@example.com"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		p.mu.Lock()
+		auth, ok := p.codes[r.PostForm.Get("code")]
+		delete(p.codes, r.PostForm.Get("code"))
+		email, amr := p.email, p.amr
+		p.mu.Unlock()
+
+		challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || auth.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		now := time.Now()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token": p.sign(t, map[string]any{
+				"iss":            p.URL,
+				"aud":            oidcClientID,
+				"sub":            "1",
+				"iat":            now.Unix(),
+				"exp":            now.Add(time.Hour).Unix(),
+				"nonce":          auth.Get("nonce"),
+				"email":          email,
+				"email_verified": true,
+				"amr":            amr,
+			}),
+		})
+	})
+
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.requests.Add(1)
+		if p.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+// authorize signs the admin in at the provider and returns the code and the state of the callback.
+func (p *oidcProvider) authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := "code-" + u.Query().Get("state")
+
+	p.mu.Lock()
+	p.codes[code] = u.Query()
+	p.mu.Unlock()
+
+	return code, u.Query().Get("state")
+}
+
+func (p *oidcProvider) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newOIDCService(provider *oidcProvider) (*cms.OIDCService, *memory.AdminRepository) {
+	repo := memory.NewAdminRepository()
+	service := cms.NewOIDCService(cms.OIDCConfig{
+		Issuer:        provider.URL,
+		ClientID:      oidcClientID,
+		RedirectURL:   "http://localhost/callback",
+		AutoProvision: true,
+		DefaultRole:   model.NewRole("reader"),
+		TwoFAMethods:  []string{"mfa"},
+	}, repo, cache.NewLRU(100, 0, nil))
+	return service, repo
+}
+
+func TestOIDCService_SignIn(t *testing.T) {
+	ctx := context.Background()
+	provider := newOIDCProvider(t)
+	provider.amr = []string{"pwd", "mfa"}
+
+	service, _ := newOIDCService(provider)
+	if n := provider.requests.Load(); n != 0 {
+		t.Fatalf("the provider is contacted %d times before the first sign-in", n)
+	}
+
+	authURL, binding, err := service.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := provider.authorize(t, authURL)
+
+	admin, twoFA, err := service.Exchange(ctx, state, binding, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admin.Email != provider.email || !twoFA {
+		t.Fatalf("got %q with 2fa %t, want %q with 2fa", admin.Email, twoFA, provider.email)
+	}
+
+	// the state is taken by the callback
+	if _, _, err = service.Exchange(ctx, state, binding, code); !errors.Is(err, cms.ErrOIDCState) {
+		t.Fatalf("got %v for a replayed state, want ErrOIDCState", err)
+	}
+}
+
+func TestOIDCService_RejectsForeignBinding(t *testing.T) {
+	ctx := context.Background()
+	provider := newOIDCProvider(t)
+	service, repo := newOIDCService(provider)
+
+	// the attacker starts the sign-in and hands the code and the state to the browser of the victim
+	authURL, _, err := service.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := provider.authorize(t, authURL)
+
+	// the browser of the victim has a binding of its own, or none at all
+	_, victimBinding, err := service.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, binding := range []string{victimBinding, ""} {
+		if _, _, err = service.Exchange(ctx, state, binding, code); !errors.Is(err, cms.ErrOIDCState) {
+			t.Fatalf("got %v for a foreign binding, want ErrOIDCState", err)
+		}
+	}
+
+	if _, err = repo.FindByEmail(ctx, provider.email); err == nil {
+		t.Fatal("the admin is provisioned by a sign-in of a foreign browser")
+	}
+}
+
+func TestOIDCService_RetriesDiscovery(t *testing.T) {
+	ctx := context.Background()
+	provider := newOIDCProvider(t)
+	provider.down.Store(true)
+
+	service, _ := newOIDCService(provider)
+	if _, _, err := service.AuthURL(ctx); err == nil {
+		t.Fatal("the sign-in started with an unavailable provider")
+	}
+
+	provider.down.Store(false)
+	if _, _, err := service.AuthURL(ctx); err != nil {
+		t.Fatalf("the discovery is not tried again: %v", err)
+	}
+}
+
+func TestOIDCService_ParallelCallbacks(t *testing.T) {
+	ctx := context.Background()
+	provider := newOIDCProvider(t)
+	service, _ := newOIDCService(provider)
+
+	authURL, binding, err := service.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := provider.authorize(t, authURL)
+
+	var (
+		wg      sync.WaitGroup
+		signIns atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch _, _, err := service.Exchange(ctx, state, binding, code); {
+			case err == nil:
+				signIns.Add(1)
+			case !errors.Is(err, cms.ErrOIDCState):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := signIns.Load(); n != 1 {
+		t.Fatalf("the state is redeemed %d times", n)
+	}
+}
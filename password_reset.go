@@ -0,0 +1,155 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var ErrPasswordResetInvalid = errors.New("password reset token is not valid")
+
+type passwordReset struct {
+	AdminID int64     `json:"admin_id"`
+	Expires time.Time `json:"expires"`
+}
+
+// PasswordResetService mails the admins a single-use token to replace a forgotten password with,
+// only the hash of the token is kept in the cache.
+type PasswordResetService struct {
+	repo    repository.Admin
+	mailer  Mailer
+	cache   Cache
+	counter CacheCounter
+	url     string
+
+	Timeout time.Duration
+	// Sessions signs the admin out everywhere after the reset when set.
+	Sessions *AdminSessionService
+}
+
+// NewPasswordResetService returns the service mailing the links to the page of the admin panel
+// at rawURL, the token is passed to the page in the token query parameter.
+func NewPasswordResetService(repo repository.Admin, mailer Mailer, cache Cache, rawURL string) *PasswordResetService {
+	if repo == nil {
+		panic("admin repository is not specified")
+	}
+	if mailer == nil {
+		panic("mailer is not specified")
+	}
+	if cache == nil {
+		panic("cache is not specified")
+	}
+	return &PasswordResetService{
+		repo:    repo,
+		mailer:  mailer,
+		cache:   cache,
+		counter: counterOf(cache),
+		url:     rawURL,
+		Timeout: time.Hour,
+	}
+}
+
+// Request mails the reset link to the admin, an unknown email is not reported to the caller.
+func (s *PasswordResetService) Request(ctx context.Context, email string) error {
+	admin, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if admin.IsDisabled() {
+		return nil
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+
+	reset := passwordReset{AdminID: admin.ID, Expires: time.Now().Add(s.Timeout)}
+	if err = s.cache.Set(WithCacheTTL(ctx, s.Timeout), s.key(token), reset, s.tag(admin.ID)); err != nil {
+		return err
+	}
+
+	link, err := tokenLink(s.url, token)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, Mail{
+		To:      admin.Email,
+		Subject: "Password reset",
+		Text: fmt.Sprintf(
+			"A password reset was requested for your account.\n\nFollow the link to choose a new password, it expires in %s:\n\n%s\n\nIgnore this mail if you did not request the reset.\n",
+			s.Timeout, link,
+		),
+	})
+}
+
+// Reset replaces the password of the admin the token was mailed to, the other tokens
+// of the admin and the signed tokens issued before are invalidated.
+func (s *PasswordResetService) Reset(ctx context.Context, token, password string) error {
+	key := s.key(token)
+
+	var reset passwordReset
+	if err := s.cache.Get(ctx, key, &reset); err != nil {
+		return errors.Join(ErrPasswordResetInvalid, err)
+	}
+	if time.Now().After(reset.Expires) {
+		return ErrPasswordResetInvalid
+	}
+
+	pswd, err := model.NewPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// the token is claimed atomically, so the parallel resets with it fail
+	claimed, err := s.counter.Incr(WithCacheTTL(ctx, time.Until(reset.Expires)), s.claimKey(token), 1)
+	if err != nil {
+		return err
+	}
+	if claimed > 1 {
+		return ErrPasswordResetInvalid
+	}
+
+	admin, err := s.repo.FindByID(ctx, reset.AdminID)
+	if err != nil {
+		return err
+	}
+
+	admin.Password = pswd
+	admin = admin.WithRandomSalt()
+	if err = s.repo.Update(ctx, &admin); err != nil {
+		return err
+	}
+
+	if err = s.cache.DelByKey(ctx, key); err != nil {
+		return err
+	}
+	if err = s.cache.DelByTag(ctx, s.tag(admin.ID)); err != nil {
+		return err
+	}
+
+	if s.Sessions != nil {
+		return s.Sessions.RevokeAdmin(ctx, admin.ID)
+	}
+	return nil
+}
+
+func (s *PasswordResetService) key(token string) string {
+	return "cms::password-reset:" + hashToken(token)
+}
+
+func (s *PasswordResetService) claimKey(token string) string {
+	return "cms::password-reset:claim:" + hashToken(token)
+}
+
+func (s *PasswordResetService) tag(adminID int64) string {
+	return fmt.Sprintf("cms::password-reset:tag:%d", adminID)
+}
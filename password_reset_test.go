@@ -0,0 +1,127 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+type mailbox struct {
+	mu    sync.Mutex
+	mails []cms.Mail
+}
+
+func (m *mailbox) Send(_ context.Context, mail cms.Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+var linkRe = regexp.MustCompile(`https?://\S+`)
+
+func (m *mailbox) token(t *testing.T) string {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.mails) == 0 {
+		t.Fatal("no mail sent")
+	}
+	u, err := url.Parse(linkRe.FindString(m.mails[len(m.mails)-1].Text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get("token")
+}
+
+func newAdmin(t *testing.T, repo *memory.AdminRepository, email, password string) model.Admin {
+	t.Helper()
+
+	pswd, err := model.NewPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := model.Admin{Email: email, Password: pswd, Role: model.RoleAdmin}.WithRandomSalt()
+	if err = repo.Create(context.Background(), &admin); err != nil {
+		t.Fatal(err)
+	}
+	return admin
+}
+
+func TestPasswordResetService_ResetOnce(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+	admin := newAdmin(t, admins, "admin@example.com", "old-password")
+
+	mails := new(mailbox)
+	service := cms.NewPasswordResetService(admins, mails, cache.NewLRU(100, 0, nil), "https://cms.test/reset")
+
+	if err := service.Request(ctx, admin.Email); err != nil {
+		t.Fatal(err)
+	}
+	token := mails.token(t)
+
+	var (
+		wg    sync.WaitGroup
+		reset atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch err := service.Reset(ctx, token, "new-password"); {
+			case err == nil:
+				reset.Add(1)
+			case !errors.Is(err, cms.ErrPasswordResetInvalid):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := reset.Load(); n != 1 {
+		t.Fatalf("the token is used %d times", n)
+	}
+
+	updated, err := admins.FindByID(ctx, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = updated.ValidatePassword("new-password"); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Salt == admin.Salt {
+		t.Fatal("the salt is not rotated")
+	}
+}
+
+func TestAdminService_ChangeOwnPasswordRotatesSalt(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+	admin := newAdmin(t, admins, "admin@example.com", "old-password")
+
+	service := cms.NewAdminService(admins)
+	if err := service.ChangeOwnPassword(ctx, admin.Email, "old-password", "new-password"); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := admins.FindByID(ctx, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Salt == admin.Salt {
+		t.Fatal("the salt is not rotated, the tokens issued before stay valid")
+	}
+}
@@ -0,0 +1,256 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+// pageDepthLimit stops walking the ancestors of a page with a broken parent chain.
+const pageDepthLimit = 64
+
+type Permissions interface {
+	// Allowed reports whether the admin is granted the access to the record within the scope.
+	Allowed(ctx context.Context, admin *model.Admin, scope model.Scope, access Access) (bool, error)
+	// Visibility returns the records of the resource the admin is granted the access to.
+	Visibility(ctx context.Context, admin *model.Admin, resource string, access Access) (Visibility, error)
+}
+
+// Visibility restricts a resource to the records of the sites and the pages,
+// the pages of a granted subtree are listed one by one.
+type Visibility struct {
+	All     bool
+	SiteIDs []int64
+	PageIDs []int64
+}
+
+func (v Visibility) IsEmpty() bool {
+	return !v.All && len(v.SiteIDs) == 0 && len(v.PageIDs) == 0
+}
+
+// Filter returns the conditions selecting the visible records of the resource,
+// a resource with neither site nor page columns is visible only as a whole.
+func (v Visibility) Filter(resource string) cr.Filter {
+	if v.All {
+		return cr.Filter{}
+	}
+
+	siteColumn, pageColumn := "site_id", ""
+	switch resource {
+	case model.ResourceSite:
+		siteColumn = "id"
+	case model.ResourcePage:
+		pageColumn = "id"
+	case model.ResourceBlock, model.ResourceRedirect:
+		pageColumn = "page_id"
+	case model.ResourceMedia:
+		// media belongs to sites only
+	default:
+		siteColumn = ""
+	}
+
+	f := cr.Filter{Operator: cr.OpOR}
+	if siteColumn != "" && len(v.SiteIDs) > 0 {
+		f.Conditions = append(f.Conditions, cr.Condition{Column: siteColumn, Operator: cr.OpIN, Value: v.SiteIDs})
+	}
+	if pageColumn != "" && len(v.PageIDs) > 0 {
+		f.Conditions = append(f.Conditions, cr.Condition{Column: pageColumn, Operator: cr.OpIN, Value: v.PageIDs})
+	}
+	if f.IsEmpty() {
+		f.Conditions = append(f.Conditions, cr.Condition{Column: "id", Operator: cr.OpIN, Value: []int64{}})
+	}
+	return f
+}
+
+var _ Permissions = (*DefaultPermissions)(nil)
+
+// DefaultPermissions grants the role of the admin everywhere and the roles of its
+// permissions within their scopes.
+type DefaultPermissions struct {
+	repo  repository.Permission
+	pages repository.Page
+}
+
+func NewDefaultPermissions(repo repository.Permission, pages repository.Page) *DefaultPermissions {
+	if repo == nil {
+		panic("permission repository is not specified")
+	}
+	if pages == nil {
+		panic("page repository is not specified")
+	}
+	return &DefaultPermissions{repo: repo, pages: pages}
+}
+
+func (p *DefaultPermissions) Allowed(ctx context.Context, admin *model.Admin, scope model.Scope, access Access) (bool, error) {
+	required := getRequiredRole(access)
+	if admin == nil {
+		return model.RoleGuest >= required, nil
+	}
+	if admin.Role >= required {
+		return true, nil
+	}
+
+	permissions, err := p.granted(ctx, admin, scope.Resource, required)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permission := range permissions {
+		if permission.SiteID != nil && (scope.SiteID == nil || *scope.SiteID != *permission.SiteID) {
+			continue
+		}
+		if permission.PageID != nil {
+			ok, err := p.inSubtree(ctx, *permission.PageID, scope)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *DefaultPermissions) Visibility(ctx context.Context, admin *model.Admin, resource string, access Access) (v Visibility, err error) {
+	required := getRequiredRole(access)
+	if admin == nil {
+		v.All = model.RoleGuest >= required
+		return
+	}
+	if admin.Role >= required {
+		v.All = true
+		return
+	}
+
+	permissions, err := p.granted(ctx, admin, resource, required)
+	if err != nil {
+		return
+	}
+
+	for _, permission := range permissions {
+		switch {
+		case permission.PageID != nil:
+			if v.PageIDs, err = p.subtree(ctx, *permission.PageID, v.PageIDs); err != nil {
+				return
+			}
+		case permission.SiteID != nil:
+			if !slices.Contains(v.SiteIDs, *permission.SiteID) {
+				v.SiteIDs = append(v.SiteIDs, *permission.SiteID)
+			}
+		default:
+			return Visibility{All: true}, nil
+		}
+	}
+	return
+}
+
+func (p *DefaultPermissions) granted(ctx context.Context, admin *model.Admin, resource string, required model.Role) ([]model.Permission, error) {
+	permissions, err := p.repo.FindByAdminID(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(permissions, func(permission model.Permission) bool {
+		return permission.Role < required || (permission.Resource != "" && permission.Resource != resource)
+	}), nil
+}
+
+// inSubtree reports whether the record of the scope belongs to the page or to one of its descendants.
+func (p *DefaultPermissions) inSubtree(ctx context.Context, rootID int64, scope model.Scope) (bool, error) {
+	if scope.PageID != nil && *scope.PageID == rootID {
+		return true, nil
+	}
+
+	parentID := scope.ParentID
+	for i := 0; parentID != nil && i < pageDepthLimit; i++ {
+		if *parentID == rootID {
+			return true, nil
+		}
+
+		parent, err := p.pages.FindByID(ctx, *parentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		parentID = parent.ParentID
+	}
+	return false, nil
+}
+
+// subtree appends the page and its descendants to ids.
+func (p *DefaultPermissions) subtree(ctx context.Context, rootID int64, ids []int64) ([]int64, error) {
+	queue := []int64{rootID}
+	for depth := 0; len(queue) > 0 && depth < pageDepthLimit; depth++ {
+		var next []int64
+		for _, id := range queue {
+			if slices.Contains(ids, id) {
+				continue
+			}
+			ids = append(ids, id)
+
+			children, err := p.pages.FindByParentID(ctx, id, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				next = append(next, child.ID)
+			}
+		}
+		queue = next
+	}
+	return ids, nil
+}
+
+// ResourceAccess allows a call when the admin is granted the access to the resource
+// within any scope, the records of the call are checked against their scopes by the handlers.
+type ResourceAccess struct {
+	TargetAccess
+	Resource    string
+	Permissions Permissions
+}
+
+func NewResourceAccess(resource string, access Access, twoFA bool, permissions Permissions) *ResourceAccess {
+	return &ResourceAccess{
+		TargetAccess: TargetAccess{Access: access, TwoFA: twoFA},
+		Resource:     resource,
+		Permissions:  permissions,
+	}
+}
+
+func (a *ResourceAccess) Decide(ctx context.Context, claims *Claims) (Decision, error) {
+	decision, err := a.TargetAccess.Decide(ctx, claims)
+	if err != nil || decision == DecisionAllow {
+		return decision, err
+	}
+	if claims == nil || claims.Subject == nil || a.Access == AccessUnknown || (a.TwoFA && !claims.TwoFA) {
+		return DecisionDeny, nil
+	}
+
+	v, err := a.Permissions.Visibility(ctx, claims.Subject, a.Resource, a.Access)
+	if err != nil {
+		return DecisionDeny, err
+	}
+	if v.IsEmpty() {
+		return DecisionDeny, nil
+	}
+	return DecisionAllow, nil
+}
+
+func NewResourceCallTarget(resource string, access Access, permissions Permissions) *CallTarget {
+	return &CallTarget{
+		Access: map[AuthScheme]Decider{
+			BasicScheme: NewResourceAccess(resource, access, false, permissions),
+			JWTScheme:   NewResourceAccess(resource, access, true, permissions),
+			TokenScheme: NewTokenAccess(resource, access, NewResourceAccess(resource, access, false, permissions)),
+		},
+	}
+}
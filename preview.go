@@ -0,0 +1,36 @@
+package cms
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const previewTokenType = "preview"
+
+var ErrInvalidPreviewToken = errors.New("invalid preview token")
+
+// NewPreviewToken returns a signed token which allows to see the draft
+// of the page until it expires.
+func NewPreviewToken(pageID int64, signingKey string, exp time.Duration) (string, error) {
+	return NewJWT(jwt.MapClaims{"type": previewTokenType, "page_id": pageID}, signingKey, exp)
+}
+
+// ParsePreviewToken verifies the token and returns the ID of the page it was issued for.
+func ParsePreviewToken(token string, verificationKey string) (int64, error) {
+	claims, err := ParseJWT(token, verificationKey)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidPreviewToken, err)
+	}
+
+	if claims["type"] != previewTokenType {
+		return 0, ErrInvalidPreviewToken
+	}
+
+	pageID, ok := claims["page_id"].(float64)
+	if !ok || pageID <= 0 {
+		return 0, ErrInvalidPreviewToken
+	}
+	return int64(pageID), nil
+}
@@ -0,0 +1,278 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Purger purges the responses tagged with the surrogate keys from the caches in front of the cms, e.g. a CDN.
+type Purger interface {
+	Purge(ctx context.Context, keys ...string) error
+}
+
+var (
+	_ Purger       = (*WebhookPurger)(nil)
+	_ Cache        = (*PurgeCache)(nil)
+	_ CacheCounter = (*PurgeCache)(nil)
+)
+
+// surrogatePrefixes are the prefixes of the tags of the entities a rendered page depends on.
+var surrogatePrefixes = []string{
+	"cms::site:",
+	"cms::page:",
+	"cms::template:",
+	"cms::menu:",
+	"cms::node:",
+	"cms::block:",
+}
+
+// SurrogateKey returns the surrogate key of the cache tag, e.g. page-1 of the tag of the page 1,
+// the tags out of the rendered pages have no key.
+func SurrogateKey(tag string) (string, bool) {
+	if !slices.ContainsFunc(surrogatePrefixes, func(prefix string) bool { return strings.HasPrefix(tag, prefix) }) {
+		return "", false
+	}
+
+	key := strings.TrimPrefix(tag, "cms::")
+	key = strings.Replace(key, ":tag", "", 1)
+	key = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '-'
+	}, key)
+	return key, true
+}
+
+// SurrogateKeys returns the sorted surrogate keys of the cache tags.
+func SurrogateKeys(tags ...string) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if key, ok := SurrogateKey(tag); ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// SetSurrogateKeys sets the Surrogate-Key and Cache-Tag headers of the CDNs to the surrogate keys of the tags.
+func SetSurrogateKeys(header http.Header, tags ...string) {
+	keys := SurrogateKeys(tags...)
+	if len(keys) == 0 {
+		return
+	}
+	header.Set("Surrogate-Key", strings.Join(keys, " "))
+	header.Set("Cache-Tag", strings.Join(keys, ","))
+}
+
+// PurgeCache purges the surrogate keys of the tags dropped from the cache, so the invalidation
+// of the cached repositories reaches the caches in front of the cms as well. The keys are
+// collected and purged in batches every Interval while started, so the purges do not slow
+// down the writes and run once they are committed, the failed purges are logged.
+type PurgeCache struct {
+	Cache
+	counter CacheCounter
+	purger  Purger
+	logger  *zap.Logger
+
+	// Interval is the time the keys are collected for before they are purged.
+	Interval time.Duration
+	// BatchSize limits the keys of a purge, reaching it purges the keys collected at once.
+	BatchSize int
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	flush   chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewPurgeCache(cache Cache, purger Purger, logger *zap.Logger) *PurgeCache {
+	if cache == nil {
+		panic("cache is not specified")
+	}
+	if purger == nil {
+		panic("purger is not specified")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &PurgeCache{
+		Cache:     cache,
+		counter:   counterOf(cache),
+		purger:    purger,
+		logger:    logger,
+		Interval:  time.Second,
+		BatchSize: 100,
+		pending:   make(map[string]struct{}),
+		flush:     make(chan struct{}, 1),
+	}
+}
+
+func (c *PurgeCache) Incr(ctx context.Context, key string, delta int64) (int64, error) {
+	return c.counter.Incr(ctx, key, delta)
+}
+
+// DelByTag drops the tag from the cache and queues its surrogate key, the key is purged
+// at once while the cache is not started.
+func (c *PurgeCache) DelByTag(ctx context.Context, tag string) error {
+	err := c.Cache.DelByTag(ctx, tag)
+
+	if key, ok := SurrogateKey(tag); ok {
+		c.mu.Lock()
+		c.pending[key] = struct{}{}
+		started, full := c.cancel != nil, len(c.pending) >= c.BatchSize
+		c.mu.Unlock()
+
+		switch {
+		case !started:
+			c.Flush(ctx)
+		case full:
+			select {
+			case c.flush <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return err
+}
+
+// Flush purges the keys collected.
+func (c *PurgeCache) Flush(ctx context.Context) {
+	c.mu.Lock()
+	keys := slices.Sorted(maps.Keys(c.pending))
+	clear(c.pending)
+	c.mu.Unlock()
+
+	size := max(c.BatchSize, 1)
+	for batch := range slices.Chunk(keys, size) {
+		if err := c.purger.Purge(ctx, batch...); err != nil {
+			c.logger.Error("failed to purge the surrogate keys", zap.Strings("keys", batch), zap.Error(err))
+		}
+	}
+}
+
+func (c *PurgeCache) Start(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.loop(ctx, c.done)
+
+	return nil
+}
+
+// Stop stops collecting the keys and purges the ones collected.
+func (c *PurgeCache) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel, c.done = nil, nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.Flush(ctx)
+	return nil
+}
+
+func (c *PurgeCache) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(max(c.Interval, time.Millisecond))
+	defer ticker.Stop()
+
+	// the purges outlive the loop, so the CDN still receives the keys taken from pending when Stop
+	// cancels during a request
+	purgeCtx := context.WithoutCancel(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.flush:
+		}
+		c.Flush(purgeCtx)
+	}
+}
+
+// WebhookPurger posts the surrogate keys to purge as {"keys": [...]} to the url,
+// e.g. to a function calling the API of the CDN.
+type WebhookPurger struct {
+	url    string
+	client *http.Client
+
+	// Header is sent with every request, e.g. the authorization of the webhook.
+	Header http.Header
+}
+
+func NewWebhookPurger(url string, client *http.Client) *WebhookPurger {
+	if url == "" {
+		panic("purge webhook url is not specified")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookPurger{url: url, client: client, Header: make(http.Header)}
+}
+
+func (p *WebhookPurger) Purge(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string][]string{"keys": keys})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range p.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("purge webhook: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("purge webhook: unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cms_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+)
+
+type purgeServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests [][]string
+	status   int
+}
+
+func newPurgeServer(t *testing.T, status int) *purgeServer {
+	t.Helper()
+
+	s := &purgeServer{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Keys []string `json:"keys"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, body.Keys)
+		s.mu.Unlock()
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *purgeServer) Requests() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func TestPurgeCache_BatchesKeys(t *testing.T) {
+	ctx := context.Background()
+	server := newPurgeServer(t, http.StatusOK)
+
+	c := cms.NewPurgeCache(cache.NewLRU(10, 0, nil), cms.NewWebhookPurger(server.URL, server.Client()), nil)
+	c.Interval = time.Hour
+	c.BatchSize = 2
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tag := range []string{cms.PageTag(1), cms.PageTag(2), cms.PageTag(1), cms.SiteTag(1), "cms::other"} {
+		if err := c.DelByTag(ctx, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, batch := range server.Requests() {
+		if len(batch) > 2 {
+			t.Fatalf("got a batch of %d keys, want at most 2", len(batch))
+		}
+		keys = append(keys, batch...)
+	}
+	slices.Sort(keys)
+
+	want := cms.SurrogateKeys(cms.PageTag(1), cms.PageTag(2), cms.SiteTag(1))
+	if !slices.Equal(keys, want) {
+		t.Fatalf("got keys %v, want %v", keys, want)
+	}
+}
+
+func TestPurgeCache_PurgesOnInterval(t *testing.T) {
+	ctx := context.Background()
+	server := newPurgeServer(t, http.StatusOK)
+
+	c := cms.NewPurgeCache(cache.NewLRU(10, 0, nil), cms.NewWebhookPurger(server.URL, server.Client()), nil)
+	c.Interval = 10 * time.Millisecond
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Stop(ctx) })
+
+	_ = c.DelByTag(ctx, cms.PageTag(1))
+	_ = c.DelByTag(ctx, cms.PageTag(2))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(server.Requests()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the keys are not purged")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if requests := server.Requests(); len(requests) != 1 || len(requests[0]) != 2 {
+		t.Fatalf("got requests %v, want the two keys in one", requests)
+	}
+}
+
+func TestPurgeCache_FailureIsNotReturned(t *testing.T) {
+	ctx := context.Background()
+	server := newPurgeServer(t, http.StatusInternalServerError)
+
+	c := cms.NewPurgeCache(cache.NewLRU(10, 0, nil), cms.NewWebhookPurger(server.URL, server.Client()), nil)
+
+	// not started, the key is purged at once
+	if err := c.DelByTag(ctx, cms.PageTag(1)); err != nil {
+		t.Fatalf("the failed purge is returned: %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+}
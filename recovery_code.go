@@ -0,0 +1,86 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var ErrRecoveryCodeInvalid = errors.New("recovery code is not valid")
+
+// RecoveryCodeService issues the single-use codes standing in for a lost second factor.
+type RecoveryCodeService struct {
+	repo  repository.RecoveryCode
+	Count int
+}
+
+func NewRecoveryCodeService(repo repository.RecoveryCode) *RecoveryCodeService {
+	if repo == nil {
+		panic("recovery code repository is not specified")
+	}
+	return &RecoveryCodeService{repo: repo, Count: 10}
+}
+
+// Generate replaces the codes of the admin and returns the new ones, they cannot be recovered later.
+func (s *RecoveryCodeService) Generate(ctx context.Context, adminID int64) ([]string, error) {
+	old, err := s.repo.FindByAdminID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if len(old) > 0 {
+		if err = s.repo.Delete(ctx, internal.Map(old, func(m model.RecoveryCode) int64 { return m.ID })...); err != nil {
+			return nil, err
+		}
+	}
+
+	codes := make([]string, 0, s.Count)
+	for range s.Count {
+		secret, err := randomToken(8)
+		if err != nil {
+			return nil, err
+		}
+		code := secret[:4] + "-" + secret[4:8] + "-" + secret[8:12] + "-" + secret[12:]
+
+		if err = s.repo.Create(ctx, &model.RecoveryCode{AdminID: adminID, Hash: hashToken(secret)}); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// Use spends the code of the admin.
+func (s *RecoveryCodeService) Use(ctx context.Context, adminID int64, code string) error {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+
+	// the code is spent by a single update, so the parallel requests cannot spend it twice
+	spent, err := s.repo.Spend(ctx, adminID, hashToken(code), time.Now())
+	if err != nil {
+		return err
+	}
+	if !spent {
+		return ErrRecoveryCodeInvalid
+	}
+	return nil
+}
+
+// Remaining returns the number of the unused codes of the admin.
+func (s *RecoveryCodeService) Remaining(ctx context.Context, adminID int64) (int, error) {
+	codes, err := s.repo.FindByAdminID(ctx, adminID)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, m := range codes {
+		if m.Used == nil {
+			n++
+		}
+	}
+	return n, nil
+}
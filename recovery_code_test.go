@@ -0,0 +1,68 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestRecoveryCodeService_UseOnce(t *testing.T) {
+	ctx := context.Background()
+	service := cms.NewRecoveryCodeService(memory.NewRecoveryCodeRepository())
+
+	codes, err := service.Generate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		used atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch err := service.Use(ctx, 1, codes[0]); {
+			case err == nil:
+				used.Add(1)
+			case !errors.Is(err, cms.ErrRecoveryCodeInvalid):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := used.Load(); n != 1 {
+		t.Fatalf("the code is spent %d times", n)
+	}
+
+	remaining, err := service.Remaining(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != len(codes)-1 {
+		t.Fatalf("got %d remaining codes, want %d", remaining, len(codes)-1)
+	}
+}
+
+func TestRecoveryCodeService_OtherAdmin(t *testing.T) {
+	ctx := context.Background()
+	service := cms.NewRecoveryCodeService(memory.NewRecoveryCodeRepository())
+
+	codes, err := service.Generate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = service.Use(ctx, 2, codes[0]); !errors.Is(err, cms.ErrRecoveryCodeInvalid) {
+		t.Fatalf("got %v, want %v", err, cms.ErrRecoveryCodeInvalid)
+	}
+	if err = service.Use(ctx, 1, codes[0]); err != nil {
+		t.Fatal(err)
+	}
+}
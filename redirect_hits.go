@@ -0,0 +1,133 @@
+package cms
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/repository"
+)
+
+type redirectHit struct {
+	count int64
+	last  time.Time
+}
+
+// RedirectHits counts the hits of the redirects in the process and adds them to the repository
+// every Interval while started, so a redirected request does not wait for a write. The hits are
+// added at once while it is not started, the failed writes are logged.
+type RedirectHits struct {
+	repository repository.Redirect
+	logger     *zap.Logger
+
+	// Interval is the time the hits are counted for before they are written.
+	Interval time.Duration
+
+	mu      sync.Mutex
+	pending map[int64]redirectHit
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewRedirectHits(redirectRepository repository.Redirect, logger *zap.Logger) *RedirectHits {
+	if redirectRepository == nil {
+		panic("redirect repository is not specified")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &RedirectHits{
+		repository: redirectRepository,
+		logger:     logger,
+		Interval:   10 * time.Second,
+		pending:    make(map[int64]redirectHit),
+	}
+}
+
+// Hit counts a hit of the redirect.
+func (h *RedirectHits) Hit(ctx context.Context, id int64) {
+	h.mu.Lock()
+	hit := h.pending[id]
+	hit.count++
+	hit.last = time.Now()
+	h.pending[id] = hit
+	started := h.cancel != nil
+	h.mu.Unlock()
+
+	if !started {
+		h.Flush(ctx)
+	}
+}
+
+// Flush writes the hits counted.
+func (h *RedirectHits) Flush(ctx context.Context) {
+	h.mu.Lock()
+	pending := h.pending
+	h.pending = make(map[int64]redirectHit, len(pending))
+	h.mu.Unlock()
+
+	for id, hit := range pending {
+		if err := h.repository.Hit(ctx, id, hit.count, hit.last); err != nil {
+			h.logger.Error("failed to count the redirect hits", zap.Int64("redirect_id", id), zap.Int64("hits", hit.count), zap.Error(err))
+		}
+	}
+}
+
+func (h *RedirectHits) Start(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.done = make(chan struct{})
+
+	go h.loop(ctx, h.done)
+
+	return nil
+}
+
+// Stop stops counting the hits in the process and writes the ones counted.
+func (h *RedirectHits) Stop(ctx context.Context) error {
+	h.mu.Lock()
+	cancel, done := h.cancel, h.done
+	h.cancel, h.done = nil, nil
+	h.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	h.Flush(ctx)
+	return nil
+}
+
+func (h *RedirectHits) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(max(h.Interval, time.Millisecond))
+	defer ticker.Stop()
+
+	// the flushed hits are already out of pending, cancelling their write would lose them
+	flushCtx := context.WithoutCancel(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.Flush(flushCtx)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"maps"
+	"time"
 
 	"github.com/gowool/theme"
 	"github.com/labstack/echo/v4"
@@ -15,6 +16,9 @@ import (
 type Renderer struct {
 	theme   theme.Theme
 	cfgRepo repository.Configuration
+
+	// Translations adds the translations of the page to the rendered page when set.
+	Translations *TranslationService
 }
 
 func NewRenderer(theme theme.Theme, cfgRepo repository.Configuration) *Renderer {
@@ -72,6 +76,12 @@ func (renderer *Renderer) Render(w io.Writer, template string, data any, c echo.
 	}
 	page.Site = site
 
+	if renderer.Translations != nil && page.Translations == nil {
+		page.Translations, _ = renderer.Translations.Translations(ctx, page, site, Scheme(r), time.Now())
+	}
+
+	AddLastModified(ctx, site.Updated, page.Updated)
+
 	for key, value := range page.Headers {
 		c.Response().Header().Set(key, value)
 	}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+)
+
+type AdminInvitation interface {
+	repository[model.AdminInvitation, int64]
+	// Accept marks the invitation accepted unless it is accepted already, it reports whether it did.
+	Accept(ctx context.Context, id int64, now time.Time) (bool, error)
+}
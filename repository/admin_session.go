@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+)
+
+type AdminSession interface {
+	repository[model.AdminSession, int64]
+	FindByTokenHash(ctx context.Context, hash string) (model.AdminSession, error)
+	// FindCurrent returns the token of the family which is not rotated yet.
+	FindCurrent(ctx context.Context, familyID string) (model.AdminSession, error)
+	// FindByAdminID returns the current tokens of the families of the admin.
+	FindByAdminID(ctx context.Context, adminID int64) ([]model.AdminSession, error)
+	// Rotate marks the token rotated unless it is rotated or revoked already, it reports whether it did.
+	Rotate(ctx context.Context, id int64, now time.Time) (bool, error)
+	// RevokeFamilies revokes every token of the families.
+	RevokeFamilies(ctx context.Context, now time.Time, familyIDs ...string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
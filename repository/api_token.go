@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+)
+
+type APIToken interface {
+	repository[model.APIToken, int64]
+	FindByHash(ctx context.Context, hash string) (model.APIToken, error)
+	// Revoke revokes the tokens which are not revoked yet.
+	Revoke(ctx context.Context, now time.Time, ids ...int64) error
+	// Touch records the use of the token unless it is revoked.
+	Touch(ctx context.Context, id int64, now time.Time, ip string) error
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "github.com/gowool/cms/model"
+
+type AuditLog interface {
+	repository[model.AuditLog, int64]
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type AdminRepository struct {
+	repository.Admin
+	recorder[model.Admin, int64]
+}
+
+func NewAdminRepository(inner repository.Admin, log repository.AuditLog, logger *zap.Logger) AdminRepository {
+	r := AdminRepository{
+		Admin:    inner,
+		recorder: newRecorder[model.Admin, int64](log, "admin", logger),
+	}
+	r.sanitize = func(m model.Admin) model.Admin {
+		m.Salt = ""
+		return m
+	}
+	return r
+}
+
+func (r AdminRepository) Create(ctx context.Context, m *model.Admin) error {
+	return r.create(ctx, m, r.Admin.Create)
+}
+
+func (r AdminRepository) Update(ctx context.Context, m *model.Admin) error {
+	return r.update(ctx, m, r.Admin.FindByID, r.Admin.Update)
+}
+
+func (r AdminRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Admin.FindByID, r.Admin.Delete)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type AdminInvitationRepository struct {
+	repository.AdminInvitation
+	recorder[model.AdminInvitation, int64]
+}
+
+func NewAdminInvitationRepository(inner repository.AdminInvitation, log repository.AuditLog, logger *zap.Logger) AdminInvitationRepository {
+	return AdminInvitationRepository{
+		AdminInvitation: inner,
+		recorder:        newRecorder[model.AdminInvitation, int64](log, "admin_invitation", logger),
+	}
+}
+
+func (r AdminInvitationRepository) Create(ctx context.Context, m *model.AdminInvitation) error {
+	return r.create(ctx, m, r.AdminInvitation.Create)
+}
+
+func (r AdminInvitationRepository) Update(ctx context.Context, m *model.AdminInvitation) error {
+	return r.update(ctx, m, r.AdminInvitation.FindByID, r.AdminInvitation.Update)
+}
+
+func (r AdminInvitationRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.AdminInvitation.FindByID, r.AdminInvitation.Delete)
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type APITokenRepository struct {
+	repository.APIToken
+	recorder[model.APIToken, int64]
+}
+
+func NewAPITokenRepository(inner repository.APIToken, log repository.AuditLog, logger *zap.Logger) APITokenRepository {
+	return APITokenRepository{
+		APIToken: inner,
+		recorder: newRecorder[model.APIToken, int64](log, "api_token", logger),
+	}
+}
+
+func (r APITokenRepository) Create(ctx context.Context, m *model.APIToken) error {
+	return r.create(ctx, m, r.APIToken.Create)
+}
+
+func (r APITokenRepository) Update(ctx context.Context, m *model.APIToken) error {
+	return r.update(ctx, m, r.APIToken.FindByID, r.APIToken.Update)
+}
+
+func (r APITokenRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.APIToken.FindByID, r.APIToken.Delete)
+}
+
+func (r APITokenRepository) Revoke(ctx context.Context, now time.Time, ids ...int64) error {
+	if _, ok := cms.CtxAudit(ctx); !ok {
+		return r.APIToken.Revoke(ctx, now, ids...)
+	}
+
+	before := make([]model.APIToken, 0, len(ids))
+	for _, id := range ids {
+		m, err := r.APIToken.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if m.Revoked == nil {
+			before = append(before, m)
+		}
+	}
+
+	if err := r.APIToken.Revoke(ctx, now, ids...); err != nil {
+		return err
+	}
+
+	for _, old := range before {
+		m, err := r.APIToken.FindByID(ctx, old.ID)
+		if err != nil || m.Revoked == nil {
+			continue
+		}
+		r.record(ctx, model.AuditUpdate, r.id(m), r.marshal(old), r.marshal(m))
+	}
+	return nil
+}
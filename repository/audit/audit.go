@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type auditor struct {
+	log    repository.AuditLog
+	target string
+	logger *zap.Logger
+}
+
+func newAuditor(log repository.AuditLog, target string, logger *zap.Logger) auditor {
+	if log == nil {
+		panic("audit log repository is not specified")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return auditor{log: log, target: target, logger: logger}
+}
+
+// record stores the change when the context belongs to an audited call. The change is
+// applied already, so a failure is logged instead of failing the call.
+func (a auditor) record(ctx context.Context, action model.AuditAction, targetID string, before, after json.RawMessage) {
+	entry, ok := cms.CtxAudit(ctx)
+	if !ok {
+		return
+	}
+
+	entry.Action = action
+	entry.TargetType = a.target
+	entry.TargetID = targetID
+	entry.Before = before
+	entry.After = after
+
+	if err := a.log.Create(ctx, &entry); err != nil {
+		a.logger.Error("audit: failed to record the change",
+			zap.String("action", string(action)),
+			zap.String("target_type", a.target),
+			zap.String("target_id", targetID),
+			zap.Error(err),
+		)
+	}
+}
+
+type recorder[T interface{ GetID() ID }, ID comparable] struct {
+	auditor
+	// sanitize strips the values not meant to be kept in the log.
+	sanitize func(T) T
+}
+
+func newRecorder[T interface{ GetID() ID }, ID comparable](log repository.AuditLog, target string, logger *zap.Logger) recorder[T, ID] {
+	return recorder[T, ID]{auditor: newAuditor(log, target, logger)}
+}
+
+func (r recorder[T, ID]) create(ctx context.Context, m *T, create func(context.Context, *T) error) error {
+	if err := create(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, model.AuditCreate, r.id(*m), nil, r.marshal(*m))
+	return nil
+}
+
+func (r recorder[T, ID]) update(
+	ctx context.Context,
+	m *T,
+	find func(context.Context, ID) (T, error),
+	update func(context.Context, *T) error,
+) error {
+	var before json.RawMessage
+	if _, ok := cms.CtxAudit(ctx); ok {
+		old, err := find(ctx, (*m).GetID())
+		if err != nil {
+			return err
+		}
+		before = r.marshal(old)
+	}
+
+	if err := update(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, model.AuditUpdate, r.id(*m), before, r.marshal(*m))
+	return nil
+}
+
+func (r recorder[T, ID]) delete(
+	ctx context.Context,
+	ids []ID,
+	find func(context.Context, ID) (T, error),
+	del func(context.Context, ...ID) error,
+) error {
+	if _, ok := cms.CtxAudit(ctx); !ok {
+		return del(ctx, ids...)
+	}
+
+	items := make([]T, 0, len(ids))
+	for _, id := range ids {
+		m, err := find(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		items = append(items, m)
+	}
+
+	if err := del(ctx, ids...); err != nil {
+		return err
+	}
+
+	for _, m := range items {
+		r.record(ctx, model.AuditDelete, r.id(m), r.marshal(m), nil)
+	}
+	return nil
+}
+
+func (r recorder[T, ID]) id(m T) string {
+	return fmt.Sprintf("%v", m.GetID())
+}
+
+func (r recorder[T, ID]) marshal(m T) json.RawMessage {
+	if r.sanitize != nil {
+		m = r.sanitize(m)
+	}
+	raw, _ := json.Marshal(m)
+	return raw
+}
@@ -0,0 +1,77 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/audit"
+	"github.com/gowool/cms/repository/memory"
+)
+
+type failingLog struct {
+	repository.AuditLog
+}
+
+func (failingLog) Create(context.Context, *model.AuditLog) error {
+	return errors.New("audit log is down")
+}
+
+func TestPageRepository_AuditFailureKeepsTheChange(t *testing.T) {
+	ctx := cms.WithAudit(context.Background(), model.AuditLog{})
+	inner := memory.NewPageRepository()
+	pages := audit.NewPageRepository(inner, failingLog{memory.NewAuditLogRepository()}, nil)
+
+	page := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page"}
+	if err := pages.Create(ctx, &page); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	page.Name = "index"
+	if err := pages.Update(ctx, &page); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	saved, err := inner.FindByID(ctx, page.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Name != "index" {
+		t.Fatalf("got name %q, want %q", saved.Name, "index")
+	}
+
+	if err = pages.Delete(ctx, page.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+}
+
+func TestPageRepository_RecordsTheChanges(t *testing.T) {
+	ctx := cms.WithAudit(context.Background(), model.AuditLog{})
+	log := memory.NewAuditLogRepository()
+	pages := audit.NewPageRepository(memory.NewPageRepository(), log, nil)
+
+	page := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page"}
+	if err := pages.Create(ctx, &page); err != nil {
+		t.Fatal(err)
+	}
+	page.Name = "index"
+	if err := pages.Update(ctx, &page); err != nil {
+		t.Fatal(err)
+	}
+	if err := pages.Delete(ctx, page.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := log.Find(ctx, cr.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want the create, the update and the delete", len(entries))
+	}
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type BlockRepository struct {
+	repository.Block
+	recorder[model.Block, int64]
+}
+
+func NewBlockRepository(inner repository.Block, log repository.AuditLog, logger *zap.Logger) BlockRepository {
+	return BlockRepository{
+		Block:    inner,
+		recorder: newRecorder[model.Block, int64](log, "block", logger),
+	}
+}
+
+func (r BlockRepository) Create(ctx context.Context, m *model.Block) error {
+	return r.create(ctx, m, r.Block.Create)
+}
+
+func (r BlockRepository) Update(ctx context.Context, m *model.Block) error {
+	return r.update(ctx, m, r.Block.FindByID, r.Block.Update)
+}
+
+func (r BlockRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Block.FindByID, r.Block.Delete)
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type ConfigurationRepository struct {
+	repository.Configuration
+	auditor
+}
+
+func NewConfigurationRepository(inner repository.Configuration, log repository.AuditLog, logger *zap.Logger) ConfigurationRepository {
+	return ConfigurationRepository{
+		Configuration: inner,
+		auditor:       newAuditor(log, "configuration", logger),
+	}
+}
+
+func (r ConfigurationRepository) Save(ctx context.Context, m *model.Configuration) error {
+	var before json.RawMessage
+	if _, ok := cms.CtxAudit(ctx); ok {
+		old, err := r.Load(ctx)
+		if err != nil {
+			return err
+		}
+		before, _ = json.Marshal(old)
+	}
+
+	if err := r.Configuration.Save(ctx, m); err != nil {
+		return err
+	}
+
+	after, _ := json.Marshal(m)
+	r.record(ctx, model.AuditUpdate, "", before, after)
+	return nil
+}
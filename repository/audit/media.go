@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type MediaRepository struct {
+	repository.Media
+	recorder[model.Media, int64]
+}
+
+func NewMediaRepository(inner repository.Media, log repository.AuditLog, logger *zap.Logger) MediaRepository {
+	return MediaRepository{
+		Media:    inner,
+		recorder: newRecorder[model.Media, int64](log, "media", logger),
+	}
+}
+
+func (r MediaRepository) Create(ctx context.Context, m *model.Media) error {
+	return r.create(ctx, m, r.Media.Create)
+}
+
+func (r MediaRepository) Update(ctx context.Context, m *model.Media) error {
+	return r.update(ctx, m, r.Media.FindByID, r.Media.Update)
+}
+
+func (r MediaRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Media.FindByID, r.Media.Delete)
+}
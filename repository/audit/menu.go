@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type MenuRepository struct {
+	repository.Menu
+	recorder[model.Menu, int64]
+}
+
+func NewMenuRepository(inner repository.Menu, log repository.AuditLog, logger *zap.Logger) MenuRepository {
+	return MenuRepository{
+		Menu:     inner,
+		recorder: newRecorder[model.Menu, int64](log, "menu", logger),
+	}
+}
+
+func (r MenuRepository) Create(ctx context.Context, m *model.Menu) error {
+	return r.create(ctx, m, r.Menu.Create)
+}
+
+func (r MenuRepository) Update(ctx context.Context, m *model.Menu) error {
+	return r.update(ctx, m, r.Menu.FindByID, r.Menu.Update)
+}
+
+func (r MenuRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Menu.FindByID, r.Menu.Delete)
+}
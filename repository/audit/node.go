@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type NodeRepository struct {
+	repository.Node
+	recorder[model.Node, int64]
+}
+
+func NewNodeRepository(inner repository.Node, log repository.AuditLog, logger *zap.Logger) NodeRepository {
+	return NodeRepository{
+		Node:     inner,
+		recorder: newRecorder[model.Node, int64](log, "node", logger),
+	}
+}
+
+func (r NodeRepository) Create(ctx context.Context, m *model.Node) error {
+	return r.create(ctx, m, r.Node.Create)
+}
+
+func (r NodeRepository) Update(ctx context.Context, m *model.Node) error {
+	return r.update(ctx, m, r.Node.FindByID, r.Node.Update)
+}
+
+func (r NodeRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Node.FindByID, r.Node.Delete)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type PageRepository struct {
+	repository.Page
+	recorder[model.Page, int64]
+}
+
+func NewPageRepository(inner repository.Page, log repository.AuditLog, logger *zap.Logger) PageRepository {
+	return PageRepository{
+		Page:     inner,
+		recorder: newRecorder[model.Page, int64](log, "page", logger),
+	}
+}
+
+func (r PageRepository) Create(ctx context.Context, m *model.Page) error {
+	return r.create(ctx, m, r.Page.Create)
+}
+
+func (r PageRepository) Update(ctx context.Context, m *model.Page) error {
+	return r.update(ctx, m, r.Page.FindByID, r.Page.Update)
+}
+
+func (r PageRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Page.FindByID, r.Page.Delete)
+}
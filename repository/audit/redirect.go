@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type RedirectRepository struct {
+	repository.Redirect
+	recorder[model.Redirect, int64]
+}
+
+func NewRedirectRepository(inner repository.Redirect, log repository.AuditLog, logger *zap.Logger) RedirectRepository {
+	return RedirectRepository{
+		Redirect: inner,
+		recorder: newRecorder[model.Redirect, int64](log, "redirect", logger),
+	}
+}
+
+func (r RedirectRepository) Create(ctx context.Context, m *model.Redirect) error {
+	return r.create(ctx, m, r.Redirect.Create)
+}
+
+func (r RedirectRepository) Update(ctx context.Context, m *model.Redirect) error {
+	return r.update(ctx, m, r.Redirect.FindByID, r.Redirect.Update)
+}
+
+func (r RedirectRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Redirect.FindByID, r.Redirect.Delete)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type SiteRepository struct {
+	repository.Site
+	recorder[model.Site, int64]
+}
+
+func NewSiteRepository(inner repository.Site, log repository.AuditLog, logger *zap.Logger) SiteRepository {
+	return SiteRepository{
+		Site:     inner,
+		recorder: newRecorder[model.Site, int64](log, "site", logger),
+	}
+}
+
+func (r SiteRepository) Create(ctx context.Context, m *model.Site) error {
+	return r.create(ctx, m, r.Site.Create)
+}
+
+func (r SiteRepository) Update(ctx context.Context, m *model.Site) error {
+	return r.update(ctx, m, r.Site.FindByID, r.Site.Update)
+}
+
+func (r SiteRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Site.FindByID, r.Site.Delete)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type TemplateRepository struct {
+	repository.Template
+	recorder[model.Template, int64]
+}
+
+func NewTemplateRepository(inner repository.Template, log repository.AuditLog, logger *zap.Logger) TemplateRepository {
+	return TemplateRepository{
+		Template: inner,
+		recorder: newRecorder[model.Template, int64](log, "template", logger),
+	}
+}
+
+func (r TemplateRepository) Create(ctx context.Context, m *model.Template) error {
+	return r.create(ctx, m, r.Template.Create)
+}
+
+func (r TemplateRepository) Update(ctx context.Context, m *model.Template) error {
+	return r.update(ctx, m, r.Template.FindByID, r.Template.Update)
+}
+
+func (r TemplateRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.Template.FindByID, r.Template.Delete)
+}
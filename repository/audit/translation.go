@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type TranslationGroupRepository struct {
+	repository.TranslationGroup
+	recorder[model.TranslationGroup, int64]
+}
+
+func NewTranslationGroupRepository(inner repository.TranslationGroup, log repository.AuditLog, logger *zap.Logger) TranslationGroupRepository {
+	return TranslationGroupRepository{
+		TranslationGroup: inner,
+		recorder:         newRecorder[model.TranslationGroup, int64](log, "translation_group", logger),
+	}
+}
+
+func (r TranslationGroupRepository) Create(ctx context.Context, m *model.TranslationGroup) error {
+	return r.create(ctx, m, r.TranslationGroup.Create)
+}
+
+func (r TranslationGroupRepository) Update(ctx context.Context, m *model.TranslationGroup) error {
+	return r.update(ctx, m, r.TranslationGroup.FindByID, r.TranslationGroup.Update)
+}
+
+func (r TranslationGroupRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.TranslationGroup.FindByID, r.TranslationGroup.Delete)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type WebAuthnCredentialRepository struct {
+	repository.WebAuthnCredential
+	recorder[model.WebAuthnCredential, int64]
+}
+
+func NewWebAuthnCredentialRepository(inner repository.WebAuthnCredential, log repository.AuditLog, logger *zap.Logger) WebAuthnCredentialRepository {
+	return WebAuthnCredentialRepository{
+		WebAuthnCredential: inner,
+		recorder:           newRecorder[model.WebAuthnCredential, int64](log, "webauthn_credential", logger),
+	}
+}
+
+func (r WebAuthnCredentialRepository) Create(ctx context.Context, m *model.WebAuthnCredential) error {
+	return r.create(ctx, m, r.WebAuthnCredential.Create)
+}
+
+func (r WebAuthnCredentialRepository) Update(ctx context.Context, m *model.WebAuthnCredential) error {
+	return r.update(ctx, m, r.WebAuthnCredential.FindByID, r.WebAuthnCredential.Update)
+}
+
+func (r WebAuthnCredentialRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids, r.WebAuthnCredential.FindByID, r.WebAuthnCredential.Delete)
+}
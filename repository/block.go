@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type Block interface {
+	repository[model.Block, int64]
+	// FindByPage returns the enabled blocks of the page and the shared blocks
+	// of its site sorted by position.
+	FindByPage(ctx context.Context, siteID, pageID int64) ([]model.Block, error)
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type APITokenRepository struct {
+	repository.APIToken
+	repo[model.APIToken, int64]
+}
+
+func NewAPITokenRepository(inner repository.APIToken, c cms.Cache) APITokenRepository {
+	return APITokenRepository{
+		APIToken: inner,
+		repo:     repo[model.APIToken, int64]{inner: inner, cache: c, prefix: "cms::api_token"},
+	}
+}
+
+func (r APITokenRepository) FindByID(ctx context.Context, id int64) (model.APIToken, error) {
+	return r.findByID(ctx, id)
+}
+
+func (r APITokenRepository) FindByHash(ctx context.Context, hash string) (m model.APIToken, err error) {
+	key := fmt.Sprintf("%s:hash:%s", r.prefix, hash)
+
+	if err = r.cache.Get(ctx, key, &m); err == nil {
+		return
+	}
+
+	if m, err = r.APIToken.FindByHash(ctx, hash); err != nil {
+		return
+	}
+
+	r.set(ctx, key, m, m.ID)
+	return
+}
+
+func (r APITokenRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids...)
+}
+
+func (r APITokenRepository) Update(ctx context.Context, m *model.APIToken) error {
+	defer r.del(ctx, m.ID)
+
+	return r.APIToken.Update(ctx, m)
+}
+
+func (r APITokenRepository) Revoke(ctx context.Context, now time.Time, ids ...int64) error {
+	defer func() {
+		for _, id := range ids {
+			r.del(ctx, id)
+		}
+	}()
+
+	return r.APIToken.Revoke(ctx, now, ids...)
+}
+
+func (r APITokenRepository) Touch(ctx context.Context, id int64, now time.Time, ip string) error {
+	defer r.del(ctx, id)
+
+	return r.APIToken.Touch(ctx, id, now, ip)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type BlockRepository struct {
+	repository.Block
+	repo[model.Block, int64]
+}
+
+func NewBlockRepository(inner repository.Block, c cms.Cache) BlockRepository {
+	return BlockRepository{
+		Block: inner,
+		repo:  repo[model.Block, int64]{inner: inner, cache: c, prefix: "cms::block"},
+	}
+}
+
+func (r BlockRepository) FindByID(ctx context.Context, id int64) (model.Block, error) {
+	return r.findByID(ctx, id)
+}
+
+func (r BlockRepository) Delete(ctx context.Context, ids ...int64) error {
+	defer r.delList(ctx)
+
+	return r.delete(ctx, ids...)
+}
+
+func (r BlockRepository) Create(ctx context.Context, m *model.Block) error {
+	defer r.delList(ctx)
+
+	return r.Block.Create(ctx, m)
+}
+
+func (r BlockRepository) Update(ctx context.Context, m *model.Block) error {
+	defer r.delList(ctx)
+	defer r.del(ctx, m.ID)
+
+	return r.Block.Update(ctx, m)
+}
+
+func (r BlockRepository) FindByPage(ctx context.Context, siteID, pageID int64) (blocks []model.Block, err error) {
+	key := fmt.Sprintf("%s:page:%d:%d", r.prefix, siteID, pageID)
+
+	// shared blocks appear on many pages, so any change drops every list
+	tags := []string{r.tag("list"), cms.PageTag(pageID)}
+
+	if err = r.cache.Get(ctx, key, &blocks); err != nil {
+		if blocks, err = r.Block.FindByPage(ctx, siteID, pageID); err != nil {
+			return
+		}
+
+		_ = r.cache.Set(ctx, key, blocks, tags...)
+	}
+
+	cms.AddCacheTags(ctx, tags...)
+	return
+}
+
+func (r BlockRepository) delList(ctx context.Context) {
+	_ = r.cache.DelByTag(ctx, r.tag("list"))
+}
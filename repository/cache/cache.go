@@ -24,7 +24,16 @@ func (r repo[T, ID]) del(ctx context.Context, id ID) {
 }
 
 func (r repo[T, ID]) set(ctx context.Context, key string, m any, ids ...ID) {
-	_ = r.cache.Set(ctx, key, m, internal.Map(ids, func(id ID) string { return r.tag(fmt.Sprintf("%v", id)) })...)
+	_ = r.cache.Set(ctx, key, m, r.tags(ids...)...)
+}
+
+// touch records the tags of the entities read with the context, see cms.WithCacheTags.
+func (r repo[T, ID]) touch(ctx context.Context, ids ...ID) {
+	cms.AddCacheTags(ctx, r.tags(ids...)...)
+}
+
+func (r repo[T, ID]) tags(ids ...ID) []string {
+	return internal.Map(ids, func(id ID) string { return r.tag(fmt.Sprintf("%v", id)) })
 }
 
 func (r repo[T, ID]) tag(suffix string) string {
@@ -34,15 +43,15 @@ func (r repo[T, ID]) tag(suffix string) string {
 func (r repo[T, ID]) findByID(ctx context.Context, id ID) (m T, err error) {
 	key := fmt.Sprintf("%s:id:%v", r.prefix, id)
 
-	if err = r.cache.Get(ctx, key, &m); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, key, &m); err != nil {
+		if m, err = r.inner.FindByID(ctx, id); err != nil {
+			return
+		}
 
-	if m, err = r.inner.FindByID(ctx, id); err != nil {
-		return
+		r.set(ctx, key, m, id)
 	}
 
-	r.set(ctx, key, m, id)
+	r.touch(ctx, id)
 	return
 }
 
@@ -23,22 +23,27 @@ func NewConfigurationRepository(inner repository.Configuration, c cms.Cache) Con
 }
 
 func (r ConfigurationRepository) Load(ctx context.Context) (m model.Configuration, err error) {
-	if err = r.cache.Get(ctx, r.key, &m); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, r.key, &m); err != nil {
+		if m, err = r.Configuration.Load(ctx); err != nil {
+			return
+		}
 
-	if m, err = r.Configuration.Load(ctx); err != nil {
-		return
+		_ = r.cache.Set(ctx, r.key, m, r.tag())
 	}
 
-	_ = r.cache.Set(ctx, r.key, m)
+	cms.AddCacheTags(ctx, r.tag())
 	return
 }
 
 func (r ConfigurationRepository) Save(ctx context.Context, m *model.Configuration) error {
 	defer func() {
 		_ = r.cache.DelByKey(ctx, r.key)
+		_ = r.cache.DelByTag(ctx, r.tag())
 	}()
 
 	return r.Configuration.Save(ctx, m)
 }
+
+func (r ConfigurationRepository) tag() string {
+	return r.key + ":tag"
+}
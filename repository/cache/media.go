@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type MediaRepository struct {
+	repository.Media
+	repo[model.Media, int64]
+}
+
+func NewMediaRepository(inner repository.Media, c cms.Cache) MediaRepository {
+	return MediaRepository{
+		Media: inner,
+		repo:  repo[model.Media, int64]{inner: inner, cache: c, prefix: "cms::media"},
+	}
+}
+
+func (r MediaRepository) FindByID(ctx context.Context, id int64) (model.Media, error) {
+	return r.findByID(ctx, id)
+}
+
+func (r MediaRepository) FindByKey(ctx context.Context, key string) (m model.Media, err error) {
+	k := fmt.Sprintf("%s:key:%s", r.prefix, key)
+
+	if err = r.cache.Get(ctx, k, &m); err == nil {
+		return
+	}
+
+	if m, err = r.Media.FindByKey(ctx, key); err != nil {
+		return
+	}
+
+	r.set(ctx, k, m, m.ID)
+	return
+}
+
+func (r MediaRepository) Delete(ctx context.Context, ids ...int64) error {
+	return r.delete(ctx, ids...)
+}
+
+func (r MediaRepository) Update(ctx context.Context, m *model.Media) error {
+	defer r.del(ctx, m.ID)
+
+	return r.Media.Update(ctx, m)
+}
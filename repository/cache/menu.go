@@ -29,7 +29,14 @@ func (r MenuRepository) Delete(ctx context.Context, ids ...int64) error {
 	return r.delete(ctx, ids...)
 }
 
+func (r MenuRepository) Create(ctx context.Context, m *model.Menu) error {
+	defer func() { _ = r.cache.DelByTag(ctx, r.handleTag(m.Handle)) }()
+
+	return r.Menu.Create(ctx, m)
+}
+
 func (r MenuRepository) Update(ctx context.Context, m *model.Menu) error {
+	defer func() { _ = r.cache.DelByTag(ctx, r.handleTag(m.Handle)) }()
 	defer r.del(ctx, m.ID)
 
 	return r.Menu.Update(ctx, m)
@@ -38,14 +45,21 @@ func (r MenuRepository) Update(ctx context.Context, m *model.Menu) error {
 func (r MenuRepository) FindByHandle(ctx context.Context, handle string) (m model.Menu, err error) {
 	key := fmt.Sprintf("%s:handle:%s", r.prefix, handle)
 
-	if err = r.cache.Get(ctx, key, &m); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, key, &m); err != nil {
+		if m, err = r.Menu.FindByHandle(ctx, handle); err != nil {
+			// the menu may be created or enabled later
+			cms.AddCacheTags(ctx, r.handleTag(handle))
+			return
+		}
 
-	if m, err = r.Menu.FindByHandle(ctx, handle); err != nil {
-		return
+		_ = r.cache.Set(ctx, key, m, append(r.tags(m.ID), cms.MenusTag)...)
 	}
 
-	r.set(ctx, key, m, m.ID)
+	r.touch(ctx, m.ID)
+	cms.AddCacheTags(ctx, cms.MenusTag)
 	return
 }
+
+func (r MenuRepository) handleTag(handle string) string {
+	return r.tag("handle:" + handle)
+}
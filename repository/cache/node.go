@@ -38,21 +38,24 @@ func (r NodeRepository) Update(ctx context.Context, m *model.Node) error {
 func (r NodeRepository) FindWithChildren(ctx context.Context, id int64) (nodes []model.Node, err error) {
 	key := fmt.Sprintf("%s:with:children:%d", r.prefix, id)
 
-	if err = r.cache.Get(ctx, key, &nodes); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, key, &nodes); err != nil {
+		if nodes, err = r.Node.FindWithChildren(ctx, id); err != nil {
+			return
+		}
 
-	if nodes, err = r.Node.FindWithChildren(ctx, id); err != nil {
-		return
+		_ = r.cache.Set(ctx, key, nodes, r.treeTags(id, nodes)...)
 	}
 
-	tags := make([]string, 0, len(nodes)+1)
-	tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, id))
+	cms.AddCacheTags(ctx, r.treeTags(id, nodes)...)
+	return
+}
+
+func (r NodeRepository) treeTags(id int64, nodes []model.Node) []string {
+	tags := make([]string, 0, len(nodes)+2)
+	tags = append(tags, cms.MenusTag, fmt.Sprintf("%s:tag:%d", r.prefix, id))
 
 	for _, n := range nodes {
 		tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, n.ID))
 	}
-
-	_ = r.cache.Set(ctx, key, nodes, tags...)
-	return
+	return tags
 }
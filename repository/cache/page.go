@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gowool/cms"
@@ -25,15 +26,15 @@ func NewPageRepository(inner repository.Page, c cms.Cache) PageRepository {
 func (r PageRepository) FindByID(ctx context.Context, id int64) (m model.Page, err error) {
 	key := fmt.Sprintf("%s:id:%v", r.prefix, id)
 
-	if err = r.cache.Get(ctx, key, &m); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, key, &m); err != nil {
+		if m, err = r.findByID(ctx, id); err != nil {
+			return
+		}
 
-	if m, err = r.findByID(ctx, id); err != nil {
-		return
+		r.set(ctx, key, m)
 	}
 
-	r.set(ctx, key, m)
+	cms.AddCacheTags(ctx, r.pageTags(m)...)
 	return
 }
 
@@ -47,6 +48,8 @@ func (r PageRepository) FindByParentID(ctx context.Context, parentID int64, now
 				goto INNER
 			}
 		}
+
+		cms.AddCacheTags(ctx, r.childrenTags(parentID, pages)...)
 		return
 	}
 
@@ -55,14 +58,29 @@ INNER:
 		return
 	}
 
-	tags := make([]string, 0, len(pages)+1)
-	tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, parentID))
+	_ = r.cache.Set(ctx, key, pages, r.childrenTags(parentID, pages)...)
+	cms.AddCacheTags(ctx, r.childrenTags(parentID, pages)...)
+	return
+}
 
-	for _, p := range pages {
-		tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, p.ID))
+// FindByTranslationGroupID caches the whole group and filters it by lifespan on every call,
+// so a scheduled page joins its translations without invalidation.
+func (r PageRepository) FindByTranslationGroupID(ctx context.Context, groupID int64, now time.Time) (pages []model.Page, err error) {
+	key := fmt.Sprintf("%s:translation:%d", r.prefix, groupID)
+
+	if err = r.cache.Get(ctx, key, &pages); err != nil {
+		if pages, err = r.Page.FindByTranslationGroupID(ctx, groupID, time.Time{}); err != nil {
+			return
+		}
+
+		_ = r.cache.Set(ctx, key, pages, r.translationTags(groupID, pages)...)
 	}
 
-	_ = r.cache.Set(ctx, key, pages, tags...)
+	cms.AddCacheTags(ctx, r.translationTags(groupID, pages)...)
+
+	if !now.IsZero() {
+		pages = slices.DeleteFunc(pages, func(p model.Page) bool { return !p.IsEnabled(now) })
+	}
 	return
 }
 
@@ -70,6 +88,7 @@ func (r PageRepository) FindByPattern(ctx context.Context, siteID int64, pattern
 	key := fmt.Sprintf("%s:pattern:%d:%s", r.prefix, siteID, pattern)
 
 	if r.get(ctx, key, now, &m) {
+		cms.AddCacheTags(ctx, r.pageTags(m)...)
 		return
 	}
 
@@ -78,6 +97,7 @@ func (r PageRepository) FindByPattern(ctx context.Context, siteID int64, pattern
 	}
 
 	r.set(ctx, key, m)
+	cms.AddCacheTags(ctx, r.pageTags(m)...)
 	return
 }
 
@@ -85,6 +105,7 @@ func (r PageRepository) FindByAlias(ctx context.Context, siteID int64, alias str
 	key := fmt.Sprintf("%s:alias:%d:%s", r.prefix, siteID, alias)
 
 	if r.get(ctx, key, now, &m) {
+		cms.AddCacheTags(ctx, r.pageTags(m)...)
 		return
 	}
 
@@ -93,6 +114,7 @@ func (r PageRepository) FindByAlias(ctx context.Context, siteID int64, alias str
 	}
 
 	r.set(ctx, key, m)
+	cms.AddCacheTags(ctx, r.pageTags(m)...)
 	return
 }
 
@@ -100,6 +122,7 @@ func (r PageRepository) FindByURL(ctx context.Context, siteID int64, url string,
 	key := fmt.Sprintf("%s:url:%d:%s", r.prefix, siteID, url)
 
 	if r.get(ctx, key, now, &m) {
+		cms.AddCacheTags(ctx, r.pageTags(m)...)
 		return
 	}
 
@@ -108,29 +131,66 @@ func (r PageRepository) FindByURL(ctx context.Context, siteID int64, url string,
 	}
 
 	r.set(ctx, key, m)
+	cms.AddCacheTags(ctx, r.pageTags(m)...)
 	return
 }
 
+// Create drops the entries of the site, the new page may show up on any of its pages.
+func (r PageRepository) Create(ctx context.Context, m *model.Page) error {
+	defer func() { _ = r.cache.DelByTag(ctx, cms.SiteTag(m.SiteID)) }()
+
+	return r.Page.Create(ctx, m)
+}
+
 func (r PageRepository) Delete(ctx context.Context, ids ...int64) error {
 	return r.delete(ctx, ids...)
 }
 
 func (r PageRepository) Update(ctx context.Context, m *model.Page) error {
 	defer r.del(ctx, m.ID)
+	if m.TranslationGroupID != nil {
+		defer func() { _ = r.cache.DelByTag(ctx, r.translationTag(*m.TranslationGroupID)) }()
+	}
 
 	return r.Page.Update(ctx, m)
 }
 
+func (r PageRepository) translationTag(groupID int64) string {
+	return r.tag(fmt.Sprintf("translation:%d", groupID))
+}
+
+func (r PageRepository) childrenTags(parentID int64, pages []model.Page) []string {
+	tags := make([]string, 0, len(pages)+1)
+	tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, parentID))
+
+	for _, p := range pages {
+		tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, p.ID))
+	}
+	return tags
+}
+
+func (r PageRepository) translationTags(groupID int64, pages []model.Page) []string {
+	tags := make([]string, 0, len(pages)+1)
+	tags = append(tags, r.translationTag(groupID))
+	for _, p := range pages {
+		tags = append(tags, cms.PageTag(p.ID))
+	}
+	return tags
+}
+
 func (r PageRepository) set(ctx context.Context, key string, m model.Page) {
+	_ = r.cache.Set(ctx, key, m, r.pageTags(m)...)
+}
+
+func (r PageRepository) pageTags(m model.Page) []string {
 	tags := []string{
-		fmt.Sprintf("%s:tag:%d", r.prefix, m.ID),
-		fmt.Sprintf("cms:site:tag:%d", m.SiteID),
+		cms.PageTag(m.ID),
+		cms.SiteTag(m.SiteID),
 	}
 	if m.ParentID != nil {
-		tags = append(tags, fmt.Sprintf("%s:tag:%d", r.prefix, m.ParentID))
+		tags = append(tags, cms.PageTag(*m.ParentID))
 	}
-
-	_ = r.cache.Set(ctx, key, m, tags...)
+	return tags
 }
 
 func (r PageRepository) get(ctx context.Context, key string, now time.Time, m *model.Page) bool {
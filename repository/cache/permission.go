@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type PermissionRepository struct {
+	repository.Permission
+	repo[model.Permission, int64]
+}
+
+func NewPermissionRepository(inner repository.Permission, c cms.Cache) PermissionRepository {
+	return PermissionRepository{
+		Permission: inner,
+		repo:       repo[model.Permission, int64]{inner: inner, cache: c, prefix: "cms::permission"},
+	}
+}
+
+func (r PermissionRepository) FindByID(ctx context.Context, id int64) (model.Permission, error) {
+	return r.findByID(ctx, id)
+}
+
+func (r PermissionRepository) FindByAdminID(ctx context.Context, adminID int64) (permissions []model.Permission, err error) {
+	key := fmt.Sprintf("%s:admin:%d", r.prefix, adminID)
+
+	if err = r.cache.Get(ctx, key, &permissions); err == nil {
+		return
+	}
+
+	if permissions, err = r.Permission.FindByAdminID(ctx, adminID); err != nil {
+		return
+	}
+
+	// a permission may move to another admin, so any change drops every list
+	_ = r.cache.Set(ctx, key, permissions, r.tag("list"))
+	return
+}
+
+func (r PermissionRepository) Delete(ctx context.Context, ids ...int64) error {
+	defer r.delList(ctx)
+
+	return r.delete(ctx, ids...)
+}
+
+func (r PermissionRepository) Create(ctx context.Context, m *model.Permission) error {
+	defer r.delList(ctx)
+
+	return r.Permission.Create(ctx, m)
+}
+
+func (r PermissionRepository) Update(ctx context.Context, m *model.Permission) error {
+	defer r.delList(ctx)
+	defer r.del(ctx, m.ID)
+
+	return r.Permission.Update(ctx, m)
+}
+
+func (r PermissionRepository) delList(ctx context.Context) {
+	_ = r.cache.DelByTag(ctx, r.tag("list"))
+}
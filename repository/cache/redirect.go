@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type RedirectRepository struct {
+	repository.Redirect
+	repo[model.Redirect, int64]
+}
+
+func NewRedirectRepository(inner repository.Redirect, c cms.Cache) RedirectRepository {
+	return RedirectRepository{
+		Redirect: inner,
+		repo:     repo[model.Redirect, int64]{inner: inner, cache: c, prefix: "cms::redirect"},
+	}
+}
+
+func (r RedirectRepository) FindByID(ctx context.Context, id int64) (model.Redirect, error) {
+	return r.findByID(ctx, id)
+}
+
+func (r RedirectRepository) Delete(ctx context.Context, ids ...int64) error {
+	defer r.delList(ctx)
+
+	return r.delete(ctx, ids...)
+}
+
+func (r RedirectRepository) Create(ctx context.Context, m *model.Redirect) error {
+	defer r.delList(ctx)
+
+	return r.Redirect.Create(ctx, m)
+}
+
+func (r RedirectRepository) Update(ctx context.Context, m *model.Redirect) error {
+	defer r.delList(ctx)
+	defer r.del(ctx, m.ID)
+
+	return r.Redirect.Update(ctx, m)
+}
+
+func (r RedirectRepository) FindBySiteID(ctx context.Context, siteID int64) (redirects []model.Redirect, err error) {
+	key := fmt.Sprintf("%s:site:%d", r.prefix, siteID)
+
+	if err = r.cache.Get(ctx, key, &redirects); err == nil {
+		return
+	}
+
+	if redirects, err = r.Redirect.FindBySiteID(ctx, siteID); err != nil {
+		return
+	}
+
+	// the rules of a site include the global ones, so any change drops every list
+	_ = r.cache.Set(ctx, key, redirects, r.tag("list"))
+	return
+}
+
+func (r RedirectRepository) delList(ctx context.Context) {
+	_ = r.cache.DelByTag(ctx, r.tag("list"))
+}
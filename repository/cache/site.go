@@ -43,9 +43,11 @@ INNER:
 		return
 	}
 
-	r.set(ctx, key, internal.Map(sites, func(item model.Site) int64 {
-		return item.ID
-	}))
+	tags := append(internal.Map(sites, func(item model.Site) string {
+		return cms.SiteTag(item.ID)
+	}), cms.SitesTag)
+
+	_ = r.cache.Set(ctx, key, sites, tags...)
 	return
 }
 
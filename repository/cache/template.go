@@ -24,15 +24,17 @@ func NewTemplateRepository(inner repository.Template, c cms.Cache) TemplateRepos
 func (r TemplateRepository) FindByName(ctx context.Context, name string) (m model.Template, err error) {
 	key := fmt.Sprintf("%s:name:%s", r.prefix, name)
 
-	if err = r.cache.Get(ctx, key, &m); err == nil {
-		return
-	}
+	if err = r.cache.Get(ctx, key, &m); err != nil {
+		if m, err = r.Template.FindByName(ctx, name); err != nil {
+			// a template created later overrides the one of the theme files
+			cms.AddCacheTags(ctx, r.nameTag(name))
+			return
+		}
 
-	if m, err = r.Template.FindByName(ctx, name); err != nil {
-		return
+		r.set(ctx, key, m, m.ID)
 	}
 
-	r.set(ctx, key, m, m.ID)
+	r.touch(ctx, m.ID)
 	return
 }
 
@@ -44,8 +46,19 @@ func (r TemplateRepository) Delete(ctx context.Context, ids ...int64) error {
 	return r.delete(ctx, ids...)
 }
 
+func (r TemplateRepository) Create(ctx context.Context, m *model.Template) error {
+	defer func() { _ = r.cache.DelByTag(ctx, r.nameTag(m.Name)) }()
+
+	return r.Template.Create(ctx, m)
+}
+
 func (r TemplateRepository) Update(ctx context.Context, m *model.Template) error {
+	defer func() { _ = r.cache.DelByTag(ctx, r.nameTag(m.Name)) }()
 	defer r.del(ctx, m.ID)
 
 	return r.Template.Update(ctx, m)
 }
+
+func (r TemplateRepository) nameTag(name string) string {
+	return r.tag("name:" + name)
+}
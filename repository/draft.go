@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type PageDraft interface {
+	repository[model.PageDraft, int64]
+	FindByPageID(ctx context.Context, pageID int64) (model.PageDraft, error)
+	// Publish promotes the draft of the page over the live version by
+	// calling save and removing the draft, both within one transaction.
+	Publish(ctx context.Context, pageID int64, save func(context.Context, *model.Page) error) (model.Page, error)
+}
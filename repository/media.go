@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type Media interface {
+	repository[model.Media, int64]
+	FindByKey(ctx context.Context, key string) (model.Media, error)
+	// Folders returns the distinct folders in use, sorted by name.
+	Folders(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Admin = (*AdminRepository)(nil)
+
+type AdminRepository struct {
+	Repository[model.Admin, int64]
+}
+
+func NewAdminRepository() *AdminRepository {
+	nextID := sequence()
+
+	return &AdminRepository{
+		Repository: Repository[model.Admin, int64]{
+			Values: func(m *model.Admin) map[string]any {
+				return map[string]any{
+					"id":       m.ID,
+					"avatar":   m.Avatar,
+					"email":    m.Email,
+					"role":     m.Role.String(),
+					"disabled": m.Disabled,
+					"created":  m.Created,
+					"updated":  m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.Admin) []string {
+				return []string{"email:" + strings.ToLower(m.Email)}
+			},
+			Clone: func(m model.Admin) model.Admin {
+				m.Disabled = cloneTime(m.Disabled)
+				return m
+			},
+			OnInsert: func(m *model.Admin) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Admin, old model.Admin) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *AdminRepository) FindByEmail(ctx context.Context, email string) (model.Admin, error) {
+	return r.FindBy(ctx, "email", email)
+}
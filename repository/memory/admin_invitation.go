@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.AdminInvitation = (*AdminInvitationRepository)(nil)
+
+type AdminInvitationRepository struct {
+	Repository[model.AdminInvitation, int64]
+}
+
+func NewAdminInvitationRepository() *AdminInvitationRepository {
+	nextID := sequence()
+
+	return &AdminInvitationRepository{
+		Repository: Repository[model.AdminInvitation, int64]{
+			Values: func(m *model.AdminInvitation) map[string]any {
+				return map[string]any{
+					"id":         m.ID,
+					"email":      m.Email,
+					"role":       m.Role.String(),
+					"invited_by": m.InvitedBy,
+					"admin_id":   m.AdminID,
+					"expires":    m.Expires,
+					"accepted":   m.Accepted,
+					"created":    m.Created,
+					"updated":    m.Updated,
+				}
+			},
+			Clone: func(m model.AdminInvitation) model.AdminInvitation {
+				m.SiteIDs = slices.Clone(m.SiteIDs)
+				m.InvitedBy = cloneID(m.InvitedBy)
+				m.AdminID = cloneID(m.AdminID)
+				m.Accepted = cloneTime(m.Accepted)
+				return m
+			},
+			OnInsert: func(m *model.AdminInvitation) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.AdminInvitation, old model.AdminInvitation) {
+				m.Email = old.Email
+				m.Role = old.Role
+				m.SiteIDs = slices.Clone(old.SiteIDs)
+				m.InvitedBy = cloneID(old.InvitedBy)
+				m.Expires = old.Expires
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *AdminInvitationRepository) Accept(_ context.Context, id int64, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || item.Accepted != nil {
+		return false, nil
+	}
+
+	item.Accepted = cloneTime(&now)
+	item.Updated = now
+	r.items[id] = item
+	return true, nil
+}
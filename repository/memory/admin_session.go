@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.AdminSession = (*AdminSessionRepository)(nil)
+
+type AdminSessionRepository struct {
+	Repository[model.AdminSession, int64]
+}
+
+func NewAdminSessionRepository() *AdminSessionRepository {
+	nextID := sequence()
+
+	return &AdminSessionRepository{
+		Repository: Repository[model.AdminSession, int64]{
+			Values: func(m *model.AdminSession) map[string]any {
+				return map[string]any{
+					"id":         m.ID,
+					"admin_id":   m.AdminID,
+					"family_id":  m.FamilyID,
+					"token_hash": m.TokenHash,
+					"two_fa":     m.TwoFA,
+					"ip":         m.IP,
+					"user_agent": m.UserAgent,
+					"started":    m.Started,
+					"expires":    m.Expires,
+					"rotated":    m.Rotated,
+					"revoked":    m.Revoked,
+					"created":    m.Created,
+					"updated":    m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.AdminSession) []string {
+				return []string{"token_hash:" + m.TokenHash}
+			},
+			Clone: func(m model.AdminSession) model.AdminSession {
+				m.Rotated = cloneTime(m.Rotated)
+				m.Revoked = cloneTime(m.Revoked)
+				return m
+			},
+			OnInsert: func(m *model.AdminSession) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.AdminSession, old model.AdminSession) {
+				rotated, revoked := m.Rotated, m.Revoked
+				*m = old
+				m.Rotated = rotated
+				m.Revoked = revoked
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *AdminSessionRepository) FindByTokenHash(ctx context.Context, hash string) (model.AdminSession, error) {
+	return r.FindBy(ctx, "token_hash", hash)
+}
+
+func (r *AdminSessionRepository) FindCurrent(ctx context.Context, familyID string) (model.AdminSession, error) {
+	sessions, err := r.Find(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{
+			cr.Condition{Column: "family_id", Value: familyID},
+			"rotated IS NULL",
+		},
+	}).SetSize(1))
+	if err != nil {
+		return model.AdminSession{}, err
+	}
+	if len(sessions) == 0 {
+		return model.AdminSession{}, r.error(repository.ErrNotFound)
+	}
+	return sessions[0], nil
+}
+
+func (r *AdminSessionRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.AdminSession, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "admin_id", Value: adminID},
+				"rotated IS NULL",
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "created", Order: "DESC"}},
+	})
+}
+
+func (r *AdminSessionRepository) Rotate(_ context.Context, id int64, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || item.Rotated != nil || item.Revoked != nil {
+		return false, nil
+	}
+
+	item.Rotated = cloneTime(&now)
+	item.Updated = now
+	r.items[id] = item
+	return true, nil
+}
+
+func (r *AdminSessionRepository) RevokeFamilies(_ context.Context, now time.Time, familyIDs ...string) error {
+	r.apply(func(m *model.AdminSession) bool {
+		if m.Revoked != nil || !slices.Contains(familyIDs, m.FamilyID) {
+			return false
+		}
+		m.Revoked = cloneTime(&now)
+		m.Updated = now
+		return true
+	})
+	return nil
+}
+
+func (r *AdminSessionRepository) DeleteExpired(_ context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if item.Expires.Before(now) {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
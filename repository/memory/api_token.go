@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.APIToken = (*APITokenRepository)(nil)
+
+type APITokenRepository struct {
+	Repository[model.APIToken, int64]
+}
+
+func NewAPITokenRepository() *APITokenRepository {
+	nextID := sequence()
+
+	return &APITokenRepository{
+		Repository: Repository[model.APIToken, int64]{
+			Values: func(m *model.APIToken) map[string]any {
+				return map[string]any{
+					"id":           m.ID,
+					"admin_id":     m.AdminID,
+					"name":         m.Name,
+					"prefix":       m.Prefix,
+					"hash":         m.Hash,
+					"role":         m.Role.String(),
+					"expires":      m.Expires,
+					"revoked":      m.Revoked,
+					"last_used":    m.LastUsed,
+					"last_used_ip": m.LastUsedIP,
+					"created":      m.Created,
+					"updated":      m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.APIToken) []string {
+				return []string{"hash:" + m.Hash}
+			},
+			Clone: func(m model.APIToken) model.APIToken {
+				m.Resources = slices.Clone(m.Resources)
+				m.Expires = cloneTime(m.Expires)
+				m.Revoked = cloneTime(m.Revoked)
+				m.LastUsed = cloneTime(m.LastUsed)
+				return m
+			},
+			OnInsert: func(m *model.APIToken) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.APIToken, old model.APIToken) {
+				m.AdminID = old.AdminID
+				m.Prefix = old.Prefix
+				m.Hash = old.Hash
+				m.Revoked = old.Revoked
+				m.LastUsed = old.LastUsed
+				m.LastUsedIP = old.LastUsedIP
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *APITokenRepository) FindByHash(ctx context.Context, hash string) (model.APIToken, error) {
+	return r.FindBy(ctx, "hash", hash)
+}
+
+func (r *APITokenRepository) Revoke(_ context.Context, now time.Time, ids ...int64) error {
+	r.apply(func(m *model.APIToken) bool {
+		if m.Revoked != nil || !slices.Contains(ids, m.ID) {
+			return false
+		}
+		m.Revoked = cloneTime(&now)
+		m.Updated = now
+		return true
+	})
+	return nil
+}
+
+func (r *APITokenRepository) Touch(_ context.Context, id int64, now time.Time, ip string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || item.Revoked != nil {
+		return nil
+	}
+
+	item.LastUsed = cloneTime(&now)
+	item.LastUsedIP = ip
+	r.items[id] = item
+	return nil
+}
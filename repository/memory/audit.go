@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.AuditLog = (*AuditLogRepository)(nil)
+
+type AuditLogRepository struct {
+	Repository[model.AuditLog, int64]
+}
+
+func NewAuditLogRepository() *AuditLogRepository {
+	nextID := sequence()
+
+	return &AuditLogRepository{
+		Repository: Repository[model.AuditLog, int64]{
+			Values: func(m *model.AuditLog) map[string]any {
+				return map[string]any{
+					"id":           m.ID,
+					"operation_id": m.OperationID,
+					"admin_id":     m.AdminID,
+					"admin_email":  m.AdminEmail,
+					"action":       m.Action,
+					"target_type":  m.TargetType,
+					"target_id":    m.TargetID,
+					"ip":           m.IP,
+					"created":      m.Created,
+				}
+			},
+			Clone: func(m model.AuditLog) model.AuditLog {
+				m.AdminID = cloneID(m.AdminID)
+				m.Before = slices.Clone(m.Before)
+				m.After = slices.Clone(m.After)
+				return m
+			},
+			OnInsert: func(m *model.AuditLog) {
+				m.ID = nextID()
+				m.Created = time.Now()
+			},
+			OnUpdate: func(m *model.AuditLog, old model.AuditLog) {
+				m.Created = old.Created
+			},
+		},
+	}
+}
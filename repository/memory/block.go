@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Block = (*BlockRepository)(nil)
+
+type BlockRepository struct {
+	Repository[model.Block, int64]
+}
+
+func NewBlockRepository() *BlockRepository {
+	nextID := sequence()
+
+	return &BlockRepository{
+		Repository: Repository[model.Block, int64]{
+			Values: func(m *model.Block) map[string]any {
+				return map[string]any{
+					"id":        m.ID,
+					"site_id":   m.SiteID,
+					"page_id":   m.PageID,
+					"parent_id": m.ParentID,
+					"region":    m.Region,
+					"type":      m.Type,
+					"name":      nullString(m.Name),
+					"position":  m.Position,
+					"shared":    m.Shared,
+					"enabled":   m.Enabled,
+					"created":   m.Created,
+					"updated":   m.Updated,
+				}
+			},
+			Clone: func(m model.Block) model.Block {
+				m.SiteID = cloneID(m.SiteID)
+				m.PageID = cloneID(m.PageID)
+				m.ParentID = cloneID(m.ParentID)
+				m.Settings = cloneJSON(m.Settings)
+				m.Children = nil
+				return m
+			},
+			OnInsert: func(m *model.Block) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Block, old model.Block) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *BlockRepository) FindByPage(ctx context.Context, siteID, pageID int64) ([]model.Block, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "enabled", Value: true},
+				cr.Filter{
+					Operator: cr.OpOR,
+					Conditions: []any{
+						cr.Condition{Column: "page_id", Value: pageID},
+						cr.Filter{
+							Conditions: []any{
+								cr.Condition{Column: "shared", Value: true},
+								cr.Filter{
+									Operator: cr.OpOR,
+									Conditions: []any{
+										cr.Condition{Column: "site_id", Value: siteID},
+										"site_id IS NULL",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "position", Order: "ASC"}, cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Configuration = (*ConfigurationRepository)(nil)
+
+type ConfigurationRepository struct {
+	mu  sync.RWMutex
+	cfg *model.Configuration
+}
+
+func NewConfigurationRepository() *ConfigurationRepository {
+	return &ConfigurationRepository{}
+}
+
+func (r *ConfigurationRepository) Load(context.Context) (model.Configuration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.cfg == nil {
+		return model.NewConfiguration(), nil
+	}
+	return cloneConfiguration(*r.cfg), nil
+}
+
+// Save keeps the additional keys of the previous configuration,
+// the same way the SQL repositories upsert one row per key.
+func (r *ConfigurationRepository) Save(_ context.Context, m *model.Configuration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg := cloneConfiguration(*m)
+	if r.cfg != nil {
+		additional := maps.Clone(r.cfg.Additional)
+		if additional == nil {
+			additional = make(map[string]string)
+		}
+		maps.Copy(additional, cfg.Additional)
+		cfg.Additional = additional
+	}
+	r.cfg = &cfg
+	return nil
+}
+
+func cloneConfiguration(cfg model.Configuration) model.Configuration {
+	cfg.IgnoreRequestPatterns = slices.Clone(cfg.IgnoreRequestPatterns)
+	cfg.IgnoreRequestURIs = slices.Clone(cfg.IgnoreRequestURIs)
+	cfg.Additional = maps.Clone(cfg.Additional)
+	if cfg.Additional == nil {
+		cfg.Additional = make(map[string]string)
+	}
+
+	catchErrors := make(map[string][]int, len(cfg.CatchErrors))
+	for key, codes := range cfg.CatchErrors {
+		catchErrors[key] = slices.Clone(codes)
+	}
+	cfg.CatchErrors = catchErrors
+	return cfg
+}
@@ -0,0 +1,310 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gowool/cr"
+)
+
+type row map[string]any
+
+func (r row) value(column string) (any, error) {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	column = strings.Trim(column, `"`)
+
+	v, ok := r[column]
+	if !ok {
+		return nil, fmt.Errorf("memory: unknown column %q", column)
+	}
+	return normalize(v), nil
+}
+
+func match(r row, filter cr.Filter) (bool, error) {
+	if filter.IsEmpty() {
+		return true, nil
+	}
+
+	or := strings.EqualFold(filter.Operator.String(), cr.OpOR.String())
+
+	for _, condition := range filter.Conditions {
+		var (
+			ok  bool
+			err error
+		)
+
+		switch c := condition.(type) {
+		case string:
+			ok, err = match(r, cr.ParseFilter(c, true))
+		case cr.Condition:
+			ok, err = matchCondition(r, c)
+		case cr.Filter:
+			ok, err = match(r, c)
+		default:
+			err = fmt.Errorf("memory: unsupported condition %T", condition)
+		}
+
+		if err != nil {
+			return false, err
+		}
+		if or && ok {
+			return true, nil
+		}
+		if !or && !ok {
+			return false, nil
+		}
+	}
+	return !or, nil
+}
+
+func matchCondition(r row, c cr.Condition) (bool, error) {
+	value, err := r.value(c.Column)
+	if err != nil {
+		return false, err
+	}
+
+	operator := strings.ToUpper(strings.Join(strings.Fields(c.Operator.String()), " "))
+
+	switch operator {
+	case "", "=":
+		return equal(value, c.Value), nil
+	case "<>", "!=":
+		return value != nil && normalize(c.Value) != nil && !equal(value, c.Value), nil
+	case ">", ">=", "<", "<=":
+		result, ok := compare(value, c.Value)
+		if !ok {
+			return false, nil
+		}
+		switch operator {
+		case ">":
+			return result > 0, nil
+		case ">=":
+			return result >= 0, nil
+		case "<":
+			return result < 0, nil
+		default:
+			return result <= 0, nil
+		}
+	case "IS":
+		return value == nil, nil
+	case "IS NOT":
+		return value != nil, nil
+	case "IN", "NOT IN":
+		if value == nil {
+			return false, nil
+		}
+		found := false
+		for _, item := range values(c.Value) {
+			if equal(value, item) {
+				found = true
+				break
+			}
+		}
+		return found == (operator == "IN"), nil
+	case "LIKE", "NOT LIKE", "ILIKE", "NOT ILIKE":
+		if value == nil || normalize(c.Value) == nil {
+			return false, nil
+		}
+		re, err := like(fmt.Sprint(normalize(c.Value)), strings.Contains(operator, "ILIKE"))
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(fmt.Sprint(value)) == !strings.HasPrefix(operator, "NOT"), nil
+	default:
+		return false, fmt.Errorf("memory: unsupported operator %q", c.Operator)
+	}
+}
+
+func compareRows(a, b row, sortBy cr.SortBy) (int, error) {
+	for _, s := range sortBy {
+		x, err := a.value(s.Column)
+		if err != nil {
+			return 0, err
+		}
+		y, err := b.value(s.Column)
+		if err != nil {
+			return 0, err
+		}
+
+		order := strings.ToUpper(s.Order)
+		desc := strings.HasPrefix(order, "DESC")
+
+		// PostgreSQL puts NULLs last in ascending and first in descending order.
+		nullsFirst := desc
+		if strings.Contains(order, "NULLS FIRST") {
+			nullsFirst = true
+		} else if strings.Contains(order, "NULLS LAST") {
+			nullsFirst = false
+		}
+
+		var result int
+		switch {
+		case x == nil && y == nil:
+			continue
+		case x == nil:
+			result = 1
+			if nullsFirst {
+				result = -1
+			}
+			return result, nil
+		case y == nil:
+			result = -1
+			if nullsFirst {
+				result = 1
+			}
+			return result, nil
+		}
+
+		result, _ = compare(x, y)
+		if result == 0 {
+			continue
+		}
+		if desc {
+			result = -result
+		}
+		return result, nil
+	}
+	return 0, nil
+}
+
+func equal(a, b any) bool {
+	result, ok := compare(a, b)
+	return ok && result == 0
+}
+
+// compare orders two column values the way SQL does, ok is false when
+// any of them is NULL.
+func compare(a, b any) (int, bool) {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return cmp.Compare(x, y), true
+		case float64:
+			return cmp.Compare(float64(x), y), true
+		}
+	case float64:
+		switch y := b.(type) {
+		case int64:
+			return cmp.Compare(x, float64(y)), true
+		case float64:
+			return cmp.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case !x:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)), true
+}
+
+// normalize dereferences pointers and converts the value to one of
+// int64, float64, bool, string or time.Time, a nil pointer becomes nil.
+func normalize(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	default:
+		if t, ok := rv.Interface().(time.Time); ok {
+			return t
+		}
+		return rv.Interface()
+	}
+}
+
+func values(value any) []any {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return []any{value}
+	}
+
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+func like(pattern string, insensitive bool) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	if insensitive {
+		expr.WriteString("(?is)")
+	} else {
+		expr.WriteString("(?s)")
+	}
+	expr.WriteByte('^')
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteByte('.')
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteByte('$')
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gowool/cr"
+)
+
+func TestMatch(t *testing.T) {
+	published := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	parentID := int64(7)
+
+	r := row{
+		"id":        int64(3),
+		"name":      "Home Page",
+		"position":  2,
+		"score":     1.5,
+		"enabled":   true,
+		"parent_id": &parentID,
+		"site_id":   (*int64)(nil),
+		"published": &published,
+	}
+
+	tests := []struct {
+		name   string
+		filter cr.Filter
+		want   bool
+	}{
+		{name: "empty", filter: cr.Filter{}, want: true},
+		{name: "equal", filter: filter(cr.Condition{Column: "id", Value: 3}), want: true},
+		{name: "equal pointer", filter: filter(cr.Condition{Column: "parent_id", Value: int64(7)}), want: true},
+		{name: "equal null", filter: filter(cr.Condition{Column: "site_id", Value: nil}), want: false},
+		{name: "not equal", filter: filter(cr.Condition{Column: "id", Operator: cr.OpNotEqual, Value: 4}), want: true},
+		{name: "not equal null", filter: filter(cr.Condition{Column: "site_id", Operator: cr.OpNotEqual, Value: 4}), want: false},
+		{name: "greater int float", filter: filter(cr.Condition{Column: "position", Operator: cr.OpGt, Value: 1.5}), want: true},
+		{name: "less float", filter: filter(cr.Condition{Column: "score", Operator: cr.OpLt, Value: 2}), want: true},
+		{name: "time range", filter: filter(
+			cr.Condition{Column: "published", Operator: cr.OpGt, Value: published.Add(-time.Minute)},
+			cr.Condition{Column: "published", Operator: cr.OpLte, Value: published},
+		), want: true},
+		{name: "time out of range", filter: filter(cr.Condition{Column: "published", Operator: cr.OpGt, Value: published}), want: false},
+		{name: "is null", filter: filter("site_id IS NULL"), want: true},
+		{name: "is not null", filter: filter("parent_id IS NOT NULL"), want: true},
+		{name: "in", filter: filter(cr.Condition{Column: "id", Operator: cr.OpIN, Value: []int64{1, 3}}), want: true},
+		{name: "empty in", filter: filter(cr.Condition{Column: "id", Operator: cr.OpIN, Value: []int64{}}), want: false},
+		{name: "not in", filter: filter(cr.Condition{Column: "id", Operator: "NOT IN", Value: []int64{1, 2}}), want: true},
+		{name: "empty not in", filter: filter(cr.Condition{Column: "id", Operator: "NOT IN", Value: []int64{}}), want: true},
+		{name: "in null", filter: filter(cr.Condition{Column: "site_id", Operator: cr.OpIN, Value: []int64{1}}), want: false},
+		{name: "like", filter: filter(cr.Condition{Column: "name", Operator: cr.OpLIKE, Value: "Home%"}), want: true},
+		{name: "like case", filter: filter(cr.Condition{Column: "name", Operator: cr.OpLIKE, Value: "home%"}), want: false},
+		{name: "ilike", filter: filter(cr.Condition{Column: "name", Operator: cr.OpILIKE, Value: "home_page"}), want: true},
+		{name: "not like", filter: filter(cr.Condition{Column: "name", Operator: "NOT LIKE", Value: "%Page"}), want: false},
+		{name: "like escaped", filter: filter(cr.Condition{Column: "name", Operator: cr.OpLIKE, Value: `Home\%`}), want: false},
+		{name: "bool", filter: filter(cr.Condition{Column: "enabled", Value: true}), want: true},
+		{name: "qualified column", filter: filter(cr.Condition{Column: `p."id"`, Value: 3}), want: true},
+		{name: "and", filter: filter(
+			cr.Condition{Column: "id", Value: 3},
+			cr.Condition{Column: "enabled", Value: false},
+		), want: false},
+		{name: "or", filter: cr.Filter{Operator: cr.OpOR, Conditions: []any{
+			cr.Condition{Column: "id", Value: 4},
+			cr.Condition{Column: "enabled", Value: true},
+		}}, want: true},
+		{name: "nested", filter: filter(
+			cr.Condition{Column: "enabled", Value: true},
+			cr.Filter{Operator: cr.OpOR, Conditions: []any{
+				cr.Condition{Column: "site_id", Value: 1},
+				"site_id IS NULL",
+			}},
+		), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := match(r, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("match = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch_Errors(t *testing.T) {
+	r := row{"id": int64(1)}
+
+	for name, f := range map[string]cr.Filter{
+		"unknown column":   filter(cr.Condition{Column: "missing", Value: 1}),
+		"unknown operator": filter(cr.Condition{Column: "id", Operator: cr.OpBETWEEN, Value: 1}),
+		"unknown type":     filter(42),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := match(r, f); err == nil {
+				t.Fatal("no error")
+			}
+		})
+	}
+}
+
+func TestCompareRows(t *testing.T) {
+	a := row{"position": int64(1), "published": nil}
+	b := row{"position": int64(2), "published": time.Now()}
+
+	tests := []struct {
+		name   string
+		sortBy cr.SortBy
+		want   int
+	}{
+		{name: "asc", sortBy: cr.SortBy{{Column: "position", Order: "ASC"}}, want: -1},
+		{name: "desc", sortBy: cr.SortBy{{Column: "position", Order: "DESC"}}, want: 1},
+		{name: "nulls last in asc", sortBy: cr.SortBy{{Column: "published", Order: "ASC"}}, want: 1},
+		{name: "nulls first in desc", sortBy: cr.SortBy{{Column: "published", Order: "DESC"}}, want: -1},
+		{name: "nulls first", sortBy: cr.SortBy{{Column: "published", Order: "ASC NULLS FIRST"}}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareRows(a, b, tt.sortBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("compare = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func filter(conditions ...any) cr.Filter {
+	return cr.Filter{Conditions: conditions}
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.PageDraft = (*PageDraftRepository)(nil)
+
+type PageDraftRepository struct {
+	Repository[model.PageDraft, int64]
+}
+
+func NewPageDraftRepository() *PageDraftRepository {
+	nextID := sequence()
+
+	return &PageDraftRepository{
+		Repository: Repository[model.PageDraft, int64]{
+			Values: func(m *model.PageDraft) map[string]any {
+				return map[string]any{
+					"id":       m.ID,
+					"page_id":  m.PageID,
+					"admin_id": m.AdminID,
+					"created":  m.Created,
+					"updated":  m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.PageDraft) []string {
+				return []string{fmt.Sprintf("page_id:%d", m.PageID)}
+			},
+			Clone: func(m model.PageDraft) model.PageDraft {
+				m.AdminID = cloneID(m.AdminID)
+				m.Page = cloneJSON(m.Page)
+				return m
+			},
+			OnInsert: func(m *model.PageDraft) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.PageDraft, old model.PageDraft) {
+				m.PageID = old.PageID
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *PageDraftRepository) FindByPageID(ctx context.Context, pageID int64) (model.PageDraft, error) {
+	return r.FindBy(ctx, "page_id", pageID)
+}
+
+func (r *PageDraftRepository) Publish(ctx context.Context, pageID int64, save func(context.Context, *model.Page) error) (model.Page, error) {
+	draft, err := r.FindByPageID(ctx, pageID)
+	if err != nil {
+		return model.Page{}, err
+	}
+
+	page := draft.Live()
+	if err = save(ctx, &page); err != nil {
+		return model.Page{}, err
+	}
+
+	if err = r.Delete(ctx, draft.ID); err != nil {
+		return model.Page{}, err
+	}
+	return page, nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Media = (*MediaRepository)(nil)
+
+type MediaRepository struct {
+	Repository[model.Media, int64]
+}
+
+func NewMediaRepository() *MediaRepository {
+	nextID := sequence()
+
+	return &MediaRepository{
+		Repository: Repository[model.Media, int64]{
+			Values: func(m *model.Media) map[string]any {
+				return map[string]any{
+					"id":      m.ID,
+					"site_id": m.SiteID,
+					"folder":  m.Folder,
+					"name":    m.Name,
+					"key":     m.Key,
+					"alt":     m.Alt,
+					"mime":    m.MIME,
+					"size":    m.Size,
+					"width":   m.Width,
+					"height":  m.Height,
+					"created": m.Created,
+					"updated": m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.Media) []string {
+				return []string{"key:" + m.Key}
+			},
+			Clone: func(m model.Media) model.Media {
+				m.SiteID = cloneID(m.SiteID)
+				return m
+			},
+			OnInsert: func(m *model.Media) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Media, old model.Media) {
+				m.Key = old.Key
+				m.MIME = old.MIME
+				m.Size = old.Size
+				m.Width = old.Width
+				m.Height = old.Height
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *MediaRepository) FindByKey(ctx context.Context, key string) (model.Media, error) {
+	return r.FindBy(ctx, "key", key)
+}
+
+func (r *MediaRepository) Folders(ctx context.Context) ([]string, error) {
+	items, err := r.Find(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []string
+	for _, item := range items {
+		if item.Folder != "" && !slices.Contains(folders, item.Folder) {
+			folders = append(folders, item.Folder)
+		}
+	}
+	slices.Sort(folders)
+	return folders, nil
+}
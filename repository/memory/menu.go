@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Menu = (*MenuRepository)(nil)
+
+type MenuRepository struct {
+	Repository[model.Menu, int64]
+}
+
+func NewMenuRepository() *MenuRepository {
+	nextID := sequence()
+
+	return &MenuRepository{
+		Repository: Repository[model.Menu, int64]{
+			Values: func(m *model.Menu) map[string]any {
+				return map[string]any{
+					"id":      m.ID,
+					"node_id": m.NodeID,
+					"name":    m.Name,
+					"handle":  m.Handle,
+					"enabled": m.Enabled,
+					"created": m.Created,
+					"updated": m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.Menu) []string {
+				return []string{"handle:" + strings.ToLower(m.Handle)}
+			},
+			Clone: func(m model.Menu) model.Menu {
+				m.NodeID = cloneID(m.NodeID)
+				m.Node = nil
+				return m
+			},
+			OnInsert: func(m *model.Menu) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Menu, old model.Menu) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *MenuRepository) FindByHandle(ctx context.Context, handle string) (model.Menu, error) {
+	menu, err := r.FindBy(ctx, "handle", handle)
+	if err != nil {
+		return model.Menu{}, err
+	}
+	if !menu.Enabled {
+		return model.Menu{}, r.error(repository.ErrNotFound)
+	}
+	return menu, nil
+}
+
+func (r *MenuRepository) Create(ctx context.Context, m *model.Menu) error {
+	r.fixHandle(m)
+	return r.Repository.Create(ctx, m)
+}
+
+func (r *MenuRepository) Update(ctx context.Context, m *model.Menu) error {
+	r.fixHandle(m)
+	return r.Repository.Update(ctx, m)
+}
+
+func (r *MenuRepository) fixHandle(m *model.Menu) {
+	if m == nil {
+		panic("memory: Update called with nil pointer")
+	}
+	*m = m.WithFixedHandle()
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Node = (*NodeRepository)(nil)
+
+type NodeRepository struct {
+	Repository[model.Node, int64]
+	nextID func() int64
+}
+
+func NewNodeRepository() *NodeRepository {
+	return &NodeRepository{
+		nextID: sequence(),
+		Repository: Repository[model.Node, int64]{
+			Values: func(m *model.Node) map[string]any {
+				return map[string]any{
+					"id":               m.ID,
+					"parent_id":        m.ParentID,
+					"name":             m.Name,
+					"label":            nullString(m.Label),
+					"uri":              nullString(m.URI),
+					"path":             m.Path,
+					"level":            m.Level,
+					"position":         m.Position,
+					"display_children": m.DisplayChildren,
+					"display":          m.Display,
+					"created":          m.Created,
+					"updated":          m.Updated,
+				}
+			},
+			Clone: func(m model.Node) model.Node {
+				m.Attributes = maps.Clone(m.Attributes)
+				m.LinkAttributes = maps.Clone(m.LinkAttributes)
+				m.ChildrenAttributes = maps.Clone(m.ChildrenAttributes)
+				m.LabelAttributes = maps.Clone(m.LabelAttributes)
+				m.Metadata = maps.Clone(m.Metadata)
+				m.Current = false
+				m.Ancestor = false
+				m.Parent = nil
+				m.Menu = nil
+				m.Children = nil
+				return m
+			},
+			OnInsert: func(m *model.Node) {
+				now := time.Now()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Node, old model.Node) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *NodeRepository) FindWithChildren(ctx context.Context, id int64) ([]model.Node, error) {
+	criteria := cr.New().
+		SetSortBy(cr.ParseSort("path")...).
+		SetFilter(cr.Filter{
+			Operator: cr.OpOR,
+			Conditions: []any{
+				cr.Condition{Column: "path", Operator: cr.OpLIKE, Value: fmt.Sprintf("%%/%d", id)},
+				cr.Condition{Column: "path", Operator: cr.OpLIKE, Value: fmt.Sprintf("%%/%d/%%", id)},
+			},
+		})
+
+	return r.Find(ctx, criteria)
+}
+
+func (r *NodeRepository) Create(ctx context.Context, m *model.Node) error {
+	if m == nil {
+		panic("memory: Create called with nil pointer")
+	}
+
+	m.ID = r.nextID()
+
+	if err := r.fixPath(ctx, m); err != nil {
+		return err
+	}
+	return r.Repository.Create(ctx, m)
+}
+
+func (r *NodeRepository) Update(ctx context.Context, m *model.Node) error {
+	if err := r.fixPath(ctx, m); err != nil {
+		return err
+	}
+	return r.Repository.Update(ctx, m)
+}
+
+func (r *NodeRepository) fixPath(ctx context.Context, m *model.Node) (err error) {
+	if m == nil {
+		panic("memory: Update called with nil pointer")
+	}
+
+	if m.ParentID != 0 && m.Parent == nil {
+		var parent model.Node
+		if parent, err = r.FindByID(ctx, m.ParentID); err != nil {
+			return err
+		}
+		m.Parent = &parent
+	}
+
+	*m = m.WithFixedPathAndLevel()
+	return
+}
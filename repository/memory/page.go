@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Page = (*PageRepository)(nil)
+
+type PageRepository struct {
+	Repository[model.Page, int64]
+}
+
+func NewPageRepository() *PageRepository {
+	nextID := sequence()
+
+	return &PageRepository{
+		Repository: Repository[model.Page, int64]{
+			Values: func(m *model.Page) map[string]any {
+				return map[string]any{
+					"id":                   m.ID,
+					"site_id":              m.SiteID,
+					"parent_id":            m.ParentID,
+					"translation_group_id": m.TranslationGroupID,
+					"name":                 m.Name,
+					"title":                nullString(m.Title),
+					"pattern":              m.Pattern,
+					"alias":                nullString(m.Alias),
+					"slug":                 nullString(m.Slug),
+					"url":                  nullString(m.URL),
+					"custom_url":           nullString(m.CustomURL),
+					"javascript":           nullString(m.Javascript),
+					"stylesheet":           nullString(m.Stylesheet),
+					"template":             m.Template,
+					"decorate":             m.Decorate,
+					"position":             m.Position,
+					"created":              m.Created,
+					"updated":              m.Updated,
+					"published":            m.Published,
+					"expired":              m.Expired,
+				}
+			},
+			Clone: func(m model.Page) model.Page {
+				m.ParentID = cloneID(m.ParentID)
+				m.TranslationGroupID = cloneID(m.TranslationGroupID)
+				m.Headers = maps.Clone(m.Headers)
+				m.Metas = slices.Clone(m.Metas)
+				m.Metadata = maps.Clone(m.Metadata)
+				m.Published = cloneTime(m.Published)
+				m.Expired = cloneTime(m.Expired)
+				m.Site = nil
+				m.Parent = nil
+				m.Children = nil
+				m.Translations = nil
+				return m
+			},
+			OnInsert: func(m *model.Page) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Page, old model.Page) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+			OnError: func(err error) error {
+				if errors.Is(err, repository.ErrNotFound) {
+					return errors.Join(repository.ErrPageNotFound, err)
+				}
+				return err
+			},
+		},
+	}
+}
+
+func (r *PageRepository) FindByParentID(ctx context.Context, parentID int64, now time.Time) ([]model.Page, error) {
+	conditions := []any{cr.Condition{Column: "parent_id", Value: parentID}}
+
+	if !now.IsZero() {
+		conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+	}
+
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: conditions,
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "position", Order: "ASC"}},
+	})
+}
+
+func (r *PageRepository) FindByTranslationGroupID(ctx context.Context, groupID int64, now time.Time) ([]model.Page, error) {
+	conditions := []any{cr.Condition{Column: "translation_group_id", Value: groupID}}
+
+	if !now.IsZero() {
+		conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+	}
+
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: conditions,
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "site_id", Order: "ASC"}, cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
+
+func (r *PageRepository) FindByPattern(ctx context.Context, siteID int64, pattern string, now time.Time) (model.Page, error) {
+	return r.findBy(ctx, siteID, "pattern", pattern, now)
+}
+
+func (r *PageRepository) FindByAlias(ctx context.Context, siteID int64, alias string, now time.Time) (model.Page, error) {
+	return r.findBy(ctx, siteID, "alias", alias, now)
+}
+
+func (r *PageRepository) FindByURL(ctx context.Context, siteID int64, url string, now time.Time) (model.Page, error) {
+	return r.findBy(ctx, siteID, "url", url, now)
+}
+
+func (r *PageRepository) findBy(ctx context.Context, siteID int64, column, value string, now time.Time) (model.Page, error) {
+	conditions := []any{cr.Condition{Column: column, Value: value}}
+
+	if siteID > 0 {
+		conditions = append(conditions, cr.Condition{Column: "site_id", Value: siteID})
+	}
+
+	if !now.IsZero() {
+		conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+	}
+
+	data, err := r.Find(ctx, cr.New().SetFilter(cr.Filter{Conditions: conditions}).SetSize(1))
+	if err != nil {
+		return model.Page{}, err
+	}
+	if len(data) == 0 {
+		return model.Page{}, r.error(repository.ErrNotFound)
+	}
+	return data[0], nil
+}
+
+func (r *PageRepository) Create(ctx context.Context, m *model.Page) error {
+	if err := r.fixURL(ctx, m); err != nil {
+		return err
+	}
+	return r.Repository.Create(ctx, m)
+}
+
+func (r *PageRepository) Update(ctx context.Context, m *model.Page) error {
+	if err := r.fixURL(ctx, m); err != nil {
+		return err
+	}
+	return r.Repository.Update(ctx, m)
+}
+
+// Delete also detaches the children of the deleted pages,
+// like the ON DELETE SET NULL action of the parent_id column.
+func (r *PageRepository) Delete(ctx context.Context, ids ...int64) error {
+	if err := r.Repository.Delete(ctx, ids...); err != nil {
+		return err
+	}
+
+	r.apply(func(m *model.Page) bool {
+		if m.ParentID != nil && slices.Contains(ids, *m.ParentID) {
+			m.ParentID = nil
+			return true
+		}
+		return false
+	})
+	return nil
+}
+
+func (r *PageRepository) fixURL(ctx context.Context, m *model.Page) error {
+	if m == nil {
+		panic("memory: Create called with nil pointer")
+	}
+
+	if m.ParentID == nil || *m.ParentID == 0 {
+		return nil
+	}
+
+	p, err := r.FindByID(ctx, *m.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find parent page: %w", err)
+	}
+
+	m.Parent = &p
+	*m = m.WithFixedURL()
+	return nil
+}
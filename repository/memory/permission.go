@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Permission = (*PermissionRepository)(nil)
+
+type PermissionRepository struct {
+	Repository[model.Permission, int64]
+}
+
+func NewPermissionRepository() *PermissionRepository {
+	nextID := sequence()
+
+	return &PermissionRepository{
+		Repository: Repository[model.Permission, int64]{
+			Values: func(m *model.Permission) map[string]any {
+				return map[string]any{
+					"id":       m.ID,
+					"admin_id": m.AdminID,
+					"role":     m.Role.String(),
+					"resource": m.Resource,
+					"site_id":  m.SiteID,
+					"page_id":  m.PageID,
+					"created":  m.Created,
+					"updated":  m.Updated,
+				}
+			},
+			Clone: func(m model.Permission) model.Permission {
+				m.SiteID = cloneID(m.SiteID)
+				m.PageID = cloneID(m.PageID)
+				return m
+			},
+			OnInsert: func(m *model.Permission) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Permission, old model.Permission) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *PermissionRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.Permission, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}}},
+		SortBy: cr.SortBy{cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
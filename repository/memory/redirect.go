@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Redirect = (*RedirectRepository)(nil)
+
+type RedirectRepository struct {
+	Repository[model.Redirect, int64]
+}
+
+func NewRedirectRepository() *RedirectRepository {
+	nextID := sequence()
+
+	return &RedirectRepository{
+		Repository: Repository[model.Redirect, int64]{
+			Values: func(m *model.Redirect) map[string]any {
+				return map[string]any{
+					"id":       m.ID,
+					"site_id":  m.SiteID,
+					"page_id":  m.PageID,
+					"type":     m.Type,
+					"source":   m.Source,
+					"target":   nullString(m.Target),
+					"status":   m.Status,
+					"enabled":  m.Enabled,
+					"hits":     m.Hits,
+					"last_hit": m.LastHit,
+					"created":  m.Created,
+					"updated":  m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.Redirect) []string {
+				var siteID int64
+				if m.SiteID != nil {
+					siteID = *m.SiteID
+				}
+				return []string{fmt.Sprintf("%d|%s|%s", siteID, m.Type, m.Source)}
+			},
+			Clone: func(m model.Redirect) model.Redirect {
+				m.SiteID = cloneID(m.SiteID)
+				m.PageID = cloneID(m.PageID)
+				m.LastHit = cloneTime(m.LastHit)
+				return m
+			},
+			OnInsert: func(m *model.Redirect) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Hits = 0
+				m.LastHit = nil
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Redirect, old model.Redirect) {
+				m.Hits = old.Hits
+				m.LastHit = old.LastHit
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *RedirectRepository) FindBySiteID(ctx context.Context, siteID int64) ([]model.Redirect, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "enabled", Value: true},
+				cr.Filter{
+					Operator: cr.OpOR,
+					Conditions: []any{
+						cr.Condition{Column: "site_id", Value: siteID},
+						"site_id IS NULL",
+					},
+				},
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
+
+func (r *RedirectRepository) Hit(_ context.Context, id int64, hits int64, last time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.items[id]
+	if !ok {
+		return r.error(repository.ErrNotFound)
+	}
+
+	m.Hits += hits
+	m.LastHit = &last
+	r.items[id] = m
+	return nil
+}
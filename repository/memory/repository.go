@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/repository"
+)
+
+type Repository[T interface{ GetID() ID }, ID cmp.Ordered] struct {
+	Values     func(*T) map[string]any
+	UniqueKeys func(*T) []string
+	Clone      func(T) T
+	OnInsert   func(*T)
+	OnUpdate   func(m *T, old T)
+	OnError    func(error) error
+
+	mu    sync.RWMutex
+	items map[ID]T
+}
+
+func (r *Repository[T, ID]) FindAndCount(ctx context.Context, criteria *cr.Criteria) ([]T, int, error) {
+	if criteria == nil {
+		criteria = cr.New()
+	}
+
+	data, err := r.filter(criteria)
+	if err != nil {
+		return nil, 0, r.error(err)
+	}
+
+	total := len(data)
+	if total == 0 {
+		return nil, 0, nil
+	}
+	return r.page(data, criteria), total, nil
+}
+
+func (r *Repository[T, ID]) Find(ctx context.Context, criteria *cr.Criteria) ([]T, error) {
+	if criteria == nil {
+		criteria = cr.New()
+	}
+
+	data, err := r.filter(criteria)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	return r.page(data, criteria), nil
+}
+
+func (r *Repository[T, ID]) FindByID(ctx context.Context, id ID) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.items[id]
+	if !ok {
+		var zero T
+		return zero, r.error(repository.ErrNotFound)
+	}
+	return r.clone(m), nil
+}
+
+func (r *Repository[T, ID]) FindBy(ctx context.Context, column string, value any) (T, error) {
+	data, err := r.Find(ctx, cr.New().
+		SetFilter(cr.Filter{Conditions: []any{cr.Condition{Column: column, Value: value}}}).
+		SetSize(1))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if len(data) == 0 {
+		var zero T
+		return zero, r.error(repository.ErrNotFound)
+	}
+	return data[0], nil
+}
+
+func (r *Repository[T, ID]) Delete(_ context.Context, ids ...ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		delete(r.items, id)
+	}
+	return nil
+}
+
+func (r *Repository[T, ID]) Create(_ context.Context, m *T) error {
+	if m == nil {
+		panic("memory: Create called with nil pointer")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item := *m
+	if r.OnInsert != nil {
+		r.OnInsert(&item)
+	}
+
+	if _, ok := r.items[item.GetID()]; ok {
+		return r.error(fmt.Errorf("memory: duplicate id %v: %w", item.GetID(), repository.ErrUniqueViolation))
+	}
+	if err := r.unique(&item); err != nil {
+		return r.error(err)
+	}
+
+	if r.items == nil {
+		r.items = make(map[ID]T)
+	}
+	r.items[item.GetID()] = r.clone(item)
+
+	*m = r.clone(item)
+	return nil
+}
+
+func (r *Repository[T, ID]) Update(_ context.Context, m *T) error {
+	if m == nil {
+		panic("memory: Update called with nil pointer")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.items[(*m).GetID()]
+	if !ok {
+		return r.error(repository.ErrNotFound)
+	}
+
+	item := *m
+	if r.OnUpdate != nil {
+		r.OnUpdate(&item, old)
+	}
+
+	if err := r.unique(&item); err != nil {
+		return r.error(err)
+	}
+
+	r.items[item.GetID()] = r.clone(item)
+
+	*m = r.clone(item)
+	return nil
+}
+
+// apply calls fn for every stored model and keeps the changes of those
+// for which it returns true, it is used to emulate foreign key actions.
+func (r *Repository[T, ID]) apply(fn func(*T) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if fn(&item) {
+			r.items[id] = item
+		}
+	}
+}
+
+func (r *Repository[T, ID]) filter(criteria *cr.Criteria) ([]T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		data = make([]T, 0, len(r.items))
+		rows = make(map[ID]row, len(r.items))
+	)
+
+	for _, id := range slices.Sorted(maps.Keys(r.items)) {
+		item := r.items[id]
+		values := row(r.Values(&item))
+
+		ok, err := match(values, criteria.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			data = append(data, r.clone(item))
+			rows[id] = values
+		}
+	}
+
+	if len(criteria.SortBy) == 0 {
+		return data, nil
+	}
+
+	var err error
+	slices.SortStableFunc(data, func(a, b T) int {
+		result, e := compareRows(rows[a.GetID()], rows[b.GetID()], criteria.SortBy)
+		if e != nil && err == nil {
+			err = e
+		}
+		return result
+	})
+	return data, err
+}
+
+func (r *Repository[T, ID]) page(data []T, criteria *cr.Criteria) []T {
+	if criteria.Size == nil || *criteria.Size <= 0 {
+		return data
+	}
+
+	offset := min(criteria.GetOffset(), len(data))
+	end := min(offset+*criteria.Size, len(data))
+	return slices.Clip(data[offset:end])
+}
+
+func (r *Repository[T, ID]) unique(m *T) error {
+	if r.UniqueKeys == nil {
+		return nil
+	}
+
+	keys := r.UniqueKeys(m)
+	for id, item := range r.items {
+		if id == (*m).GetID() {
+			continue
+		}
+		for _, key := range r.UniqueKeys(&item) {
+			if slices.Contains(keys, key) {
+				return fmt.Errorf("memory: duplicate key %q: %w", key, repository.ErrUniqueViolation)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Repository[T, ID]) clone(m T) T {
+	if r.Clone == nil {
+		return m
+	}
+	return r.Clone(m)
+}
+
+func (r *Repository[T, ID]) error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if r.OnError != nil {
+		return r.OnError(err)
+	}
+	return err
+}
+
+func sequence() func() int64 {
+	var seq atomic.Int64
+	return func() int64 {
+		return seq.Add(1)
+	}
+}
+
+// nullString mirrors the nullable varchar columns, which store an
+// empty string as NULL.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func cloneID(id *int64) *int64 {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	return &c
+}
+
+// cloneJSON deep copies a snapshot the same way the SQL repositories
+// store it, so later changes of the model do not leak into the history.
+func cloneJSON[T any](data T) T {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+
+	var c T
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return data
+	}
+	return c
+}
+
+func timeKey(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,84 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestRepository_FindAndCount(t *testing.T) {
+	ctx := context.Background()
+	pages := memory.NewPageRepository()
+
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		m := model.Page{SiteID: int64(i%2 + 1), Name: name, Pattern: "/" + name, Template: "page", Position: 5 - i}
+		if err := pages.Create(ctx, &m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	criteria := cr.New().
+		SetFilter(cr.Filter{Conditions: []any{cr.Condition{Column: "site_id", Value: 1}}}).
+		SetSortBy(cr.Sort{Column: "position", Order: "ASC"}).
+		SetOffset(1).
+		SetSize(1)
+
+	items, total, err := pages.FindAndCount(ctx, criteria)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 {
+		t.Fatalf("total = %d, want 3", total)
+	}
+	if len(items) != 1 || items[0].Name != "c" {
+		t.Fatalf("items = %v, want the second page of site 1 by position", items)
+	}
+}
+
+func TestRepository_ClonesTheItems(t *testing.T) {
+	ctx := context.Background()
+	pages := memory.NewPageRepository()
+
+	m := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page", Metadata: map[string]string{"k": "v"}}
+	if err := pages.Create(ctx, &m); err != nil {
+		t.Fatal(err)
+	}
+	m.Metadata["k"] = "changed"
+
+	saved, err := pages.FindByID(ctx, m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Metadata["k"] != "v" {
+		t.Fatalf("the stored page shares the metadata of the caller: %v", saved.Metadata)
+	}
+}
+
+func TestRepository_Errors(t *testing.T) {
+	ctx := context.Background()
+	admins := memory.NewAdminRepository()
+
+	m := model.Admin{Email: "admin@example.com"}
+	if err := admins.Create(ctx, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	dup := model.Admin{Email: "admin@example.com"}
+	if err := admins.Create(ctx, &dup); !errors.Is(err, repository.ErrUniqueViolation) {
+		t.Fatalf("err = %v, want a unique violation", err)
+	}
+
+	if _, err := admins.FindByID(ctx, m.ID+100); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("err = %v, want not found", err)
+	}
+
+	if _, err := admins.Find(ctx, cr.New().SetFilter(cr.Filter{Conditions: []any{cr.Condition{Column: "missing", Value: 1}}})); err == nil {
+		t.Fatal("an unknown column is not reported")
+	}
+}
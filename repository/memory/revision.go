@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	_ repository.PageRevision     = (*RevisionRepository[model.Page])(nil)
+	_ repository.TemplateRevision = (*RevisionRepository[model.Template])(nil)
+)
+
+type RevisionRepository[T any] struct {
+	Repository[model.Revision[T], int64]
+
+	// appending serializes Append, so the version read is still the latest one on insert
+	appending sync.Mutex
+}
+
+func NewPageRevisionRepository() *RevisionRepository[model.Page] {
+	return NewRevisionRepository[model.Page]()
+}
+
+func NewTemplateRevisionRepository() *RevisionRepository[model.Template] {
+	return NewRevisionRepository[model.Template]()
+}
+
+func NewRevisionRepository[T any]() *RevisionRepository[T] {
+	nextID := sequence()
+
+	return &RevisionRepository[T]{
+		Repository: Repository[model.Revision[T], int64]{
+			Values: func(m *model.Revision[T]) map[string]any {
+				return map[string]any{
+					"id":        m.ID,
+					"record_id": m.RecordID,
+					"version":   m.Version,
+					"admin_id":  m.AdminID,
+					"created":   m.Created,
+				}
+			},
+			UniqueKeys: func(m *model.Revision[T]) []string {
+				return []string{fmt.Sprintf("version:%d:%d", m.RecordID, m.Version)}
+			},
+			Clone: func(m model.Revision[T]) model.Revision[T] {
+				m.AdminID = cloneID(m.AdminID)
+				m.Data = cloneJSON(m.Data)
+				return m
+			},
+			OnInsert: func(m *model.Revision[T]) {
+				m.ID = nextID()
+				m.Created = time.Now()
+			},
+			OnUpdate: func(m *model.Revision[T], old model.Revision[T]) {
+				m.RecordID = old.RecordID
+				m.Version = old.Version
+				m.Created = old.Created
+			},
+		},
+	}
+}
+
+func (r *RevisionRepository[T]) FindByVersion(ctx context.Context, recordID int64, version int) (model.Revision[T], error) {
+	return r.findOne(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{
+			cr.Condition{Column: "record_id", Value: recordID},
+			cr.Condition{Column: "version", Value: version},
+		},
+	}))
+}
+
+func (r *RevisionRepository[T]) FindLatest(ctx context.Context, recordID int64) (model.Revision[T], error) {
+	return r.findOne(ctx, cr.New().
+		SetFilter(cr.Filter{Conditions: []any{cr.Condition{Column: "record_id", Value: recordID}}}).
+		SetSortBy(cr.Sort{Column: "version", Order: "DESC"}))
+}
+
+func (r *RevisionRepository[T]) findOne(ctx context.Context, criteria *cr.Criteria) (model.Revision[T], error) {
+	data, err := r.Find(ctx, criteria.SetSize(1))
+	if err != nil {
+		return model.Revision[T]{}, err
+	}
+	if len(data) == 0 {
+		return model.Revision[T]{}, r.error(repository.ErrNotFound)
+	}
+	return data[0], nil
+}
+
+func (r *RevisionRepository[T]) Append(ctx context.Context, m *model.Revision[T]) error {
+	r.appending.Lock()
+	defer r.appending.Unlock()
+
+	m.Version = 1
+	latest, err := r.FindLatest(ctx, m.RecordID)
+	switch {
+	case err == nil:
+		m.Version = latest.Version + 1
+	case !errors.Is(err, repository.ErrNotFound):
+		return err
+	}
+	return r.Create(ctx, m)
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"html"
+	"html/template"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Search = (*SearchRepository)(nil)
+
+const (
+	snippetWords  = 30
+	snippetBefore = 10
+)
+
+type searchEntry struct {
+	doc   model.SearchDocument
+	terms map[string]float64
+}
+
+// SearchRepository is an in-process inverted index, every term of the query
+// must match a word of the document or its prefix, terms starting with
+// a minus exclude the documents containing them.
+type SearchRepository struct {
+	mu      sync.RWMutex
+	entries map[int64]searchEntry
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{entries: make(map[int64]searchEntry)}
+}
+
+func (r *SearchRepository) Index(_ context.Context, docs ...model.SearchDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, doc := range docs {
+		if old, ok := r.entries[doc.PageID]; ok && doc.Content == "" {
+			doc.Content = old.doc.Content
+		}
+		r.entries[doc.PageID] = newSearchEntry(doc)
+	}
+	return nil
+}
+
+func (r *SearchRepository) IndexContent(_ context.Context, pageID int64, content string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[pageID]; ok {
+		entry.doc.Content = content
+		r.entries[pageID] = newSearchEntry(entry.doc)
+	}
+	return nil
+}
+
+func (r *SearchRepository) Remove(_ context.Context, pageIDs ...int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range pageIDs {
+		delete(r.entries, id)
+	}
+	return nil
+}
+
+func (r *SearchRepository) Search(_ context.Context, query model.SearchQuery) ([]model.SearchHit, int, error) {
+	var include, exclude []string
+	for _, field := range strings.Fields(query.Text) {
+		if strings.HasPrefix(field, "-") {
+			exclude = append(exclude, searchTerms(field)...)
+		} else {
+			include = append(include, searchTerms(field)...)
+		}
+	}
+	if len(include) == 0 {
+		return nil, 0, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var hits []model.SearchHit
+	for _, entry := range r.entries {
+		if entry.doc.SiteID != query.SiteID ||
+			(query.Locale != "" && entry.doc.Locale != query.Locale) ||
+			(!query.Now.IsZero() && !entry.doc.IsEnabled(query.Now)) ||
+			slices.ContainsFunc(exclude, func(term string) bool { return entry.terms[term] > 0 }) {
+			continue
+		}
+
+		rank := entry.rank(include)
+		if rank == 0 {
+			continue
+		}
+
+		hits = append(hits, model.SearchHit{
+			PageID:  entry.doc.PageID,
+			SiteID:  entry.doc.SiteID,
+			Locale:  entry.doc.Locale,
+			URL:     entry.doc.URL,
+			Title:   cmp.Or(entry.doc.Title, entry.doc.Name),
+			Snippet: snippet(cmp.Or(entry.doc.Content, entry.doc.Description, entry.doc.Title, entry.doc.Name), include),
+			Rank:    rank,
+		})
+	}
+
+	slices.SortFunc(hits, func(a, b model.SearchHit) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PageID, b.PageID)
+	})
+
+	total := len(hits)
+	hits = hits[min(max(query.Offset, 0), total):]
+	if query.Limit > 0 && query.Limit < len(hits) {
+		hits = hits[:query.Limit]
+	}
+	return hits, total, nil
+}
+
+func newSearchEntry(doc model.SearchDocument) searchEntry {
+	entry := searchEntry{doc: doc, terms: make(map[string]float64)}
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{doc.Title, 1},
+		{doc.Name, 1},
+		{doc.Description, .4},
+		{doc.Keywords, .4},
+		{doc.Metadata, .2},
+		{doc.Content, .1},
+	} {
+		for _, term := range searchTerms(field.text) {
+			entry.terms[term] += field.weight
+		}
+	}
+	return entry
+}
+
+// rank sums the weights of the matched words, prefix matches count half,
+// it is zero when any of the terms does not match.
+func (e searchEntry) rank(terms []string) (rank float64) {
+	for _, term := range terms {
+		var score float64
+		for word, weight := range e.terms {
+			if word == term {
+				score += weight
+			} else if strings.HasPrefix(word, term) {
+				score += weight / 2
+			}
+		}
+		if score == 0 {
+			return 0
+		}
+		rank += score
+	}
+	return
+}
+
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isNotWordRune)
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// snippet returns an HTML fragment of the text around the first matched
+// word, the matched words are wrapped in mark elements.
+func snippet(text string, terms []string) template.HTML {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		if isNotWordRune(r) {
+			if start >= 0 {
+				spans = append(spans, [2]int{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	if len(spans) == 0 {
+		return ""
+	}
+
+	match := func(span [2]int) bool {
+		word := strings.ToLower(text[span[0]:span[1]])
+		return slices.ContainsFunc(terms, func(term string) bool { return strings.HasPrefix(word, term) })
+	}
+
+	first := max(slices.IndexFunc(spans, match)-snippetBefore, 0)
+	last := min(first+snippetWords, len(spans))
+
+	var b strings.Builder
+	if first > 0 {
+		b.WriteString("… ")
+	}
+	pos := spans[first][0]
+	for _, span := range spans[first:last] {
+		b.WriteString(html.EscapeString(text[pos:span[0]]))
+		if match(span) {
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(text[span[0]:span[1]]))
+			b.WriteString("</mark>")
+		} else {
+			b.WriteString(html.EscapeString(text[span[0]:span[1]]))
+		}
+		pos = span[1]
+	}
+	if last < len(spans) {
+		b.WriteString(" …")
+	} else {
+		b.WriteString(html.EscapeString(text[pos:]))
+	}
+	return template.HTML(b.String())
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Site = (*SiteRepository)(nil)
+
+type SiteRepository struct {
+	Repository[model.Site, int64]
+}
+
+func NewSiteRepository() *SiteRepository {
+	nextID := sequence()
+
+	return &SiteRepository{
+		Repository: Repository[model.Site, int64]{
+			Values: func(m *model.Site) map[string]any {
+				return map[string]any{
+					"id":            m.ID,
+					"name":          m.Name,
+					"title":         nullString(m.Title),
+					"separator":     m.Separator,
+					"host":          m.Host,
+					"locale":        nullString(m.Locale),
+					"relative_path": nullString(m.RelativePath),
+					"is_default":    m.IsDefault,
+					"javascript":    nullString(m.Javascript),
+					"stylesheet":    nullString(m.Stylesheet),
+					"robots":        nullString(m.Robots),
+					"created":       m.Created,
+					"updated":       m.Updated,
+					"published":     m.Published,
+					"expired":       m.Expired,
+				}
+			},
+			UniqueKeys: func(m *model.Site) []string {
+				return []string{strings.Join([]string{
+					"host",
+					strings.ToLower(m.Host),
+					strings.ToLower(m.Locale),
+					strings.ToLower(m.RelativePath),
+					timeKey(m.Published),
+					timeKey(m.Expired),
+				}, ":")}
+			},
+			Clone: func(m model.Site) model.Site {
+				m.Metas = slices.Clone(m.Metas)
+				m.Metadata = maps.Clone(m.Metadata)
+				m.Published = cloneTime(m.Published)
+				m.Expired = cloneTime(m.Expired)
+				return m
+			},
+			OnInsert: func(m *model.Site) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Site, old model.Site) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+			OnError: func(err error) error {
+				if errors.Is(err, repository.ErrNotFound) {
+					return errors.Join(repository.ErrSiteNotFound, err)
+				}
+				return err
+			},
+		},
+	}
+}
+
+func (r *SiteRepository) FindByHosts(ctx context.Context, hosts []string, now time.Time) ([]model.Site, error) {
+	conditions := []any{cr.Condition{Column: "host", Operator: cr.OpIN, Value: hosts}}
+
+	if !now.IsZero() {
+		conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+	}
+
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: conditions,
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "is_default", Order: "DESC"}},
+	})
+}
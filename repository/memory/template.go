@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Template = (*TemplateRepository)(nil)
+
+type TemplateRepository struct {
+	Repository[model.Template, int64]
+}
+
+func NewTemplateRepository() *TemplateRepository {
+	nextID := sequence()
+
+	return &TemplateRepository{
+		Repository: Repository[model.Template, int64]{
+			Values: func(m *model.Template) map[string]any {
+				return map[string]any{
+					"id":      m.ID,
+					"name":    m.Name,
+					"content": m.Content,
+					"enabled": m.Enabled,
+					"created": m.Created,
+					"updated": m.Updated,
+				}
+			},
+			Clone: func(m model.Template) model.Template {
+				m.Type = model.TemplateDB
+				return m
+			},
+			OnInsert: func(m *model.Template) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.Template, old model.Template) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *TemplateRepository) FindByName(ctx context.Context, name string) (model.Template, error) {
+	return r.FindBy(ctx, "name", name)
+}
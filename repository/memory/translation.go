@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.TranslationGroup = (*TranslationGroupRepository)(nil)
+
+type TranslationGroupRepository struct {
+	Repository[model.TranslationGroup, int64]
+}
+
+func NewTranslationGroupRepository() *TranslationGroupRepository {
+	nextID := sequence()
+
+	return &TranslationGroupRepository{
+		Repository: Repository[model.TranslationGroup, int64]{
+			Values: func(m *model.TranslationGroup) map[string]any {
+				return map[string]any{
+					"id":      m.ID,
+					"name":    m.Name,
+					"created": m.Created,
+					"updated": m.Updated,
+				}
+			},
+			OnInsert: func(m *model.TranslationGroup) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.TranslationGroup, old model.TranslationGroup) {
+				m.Created = old.Created
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	_ repository.WebAuthnCredential = (*WebAuthnCredentialRepository)(nil)
+	_ repository.RecoveryCode       = (*RecoveryCodeRepository)(nil)
+)
+
+type WebAuthnCredentialRepository struct {
+	Repository[model.WebAuthnCredential, int64]
+}
+
+func NewWebAuthnCredentialRepository() *WebAuthnCredentialRepository {
+	nextID := sequence()
+
+	return &WebAuthnCredentialRepository{
+		Repository: Repository[model.WebAuthnCredential, int64]{
+			Values: func(m *model.WebAuthnCredential) map[string]any {
+				return map[string]any{
+					"id":               m.ID,
+					"admin_id":         m.AdminID,
+					"name":             m.Name,
+					"credential_id":    m.CredentialID,
+					"attestation_type": m.AttestationType,
+					"sign_count":       m.SignCount,
+					"backup_eligible":  m.BackupEligible,
+					"backup_state":     m.BackupState,
+					"last_used":        m.LastUsed,
+					"created":          m.Created,
+					"updated":          m.Updated,
+				}
+			},
+			UniqueKeys: func(m *model.WebAuthnCredential) []string {
+				return []string{"credential_id:" + m.CredentialID}
+			},
+			Clone: func(m model.WebAuthnCredential) model.WebAuthnCredential {
+				m.PublicKey = slices.Clone(m.PublicKey)
+				m.AAGUID = slices.Clone(m.AAGUID)
+				m.Transports = slices.Clone(m.Transports)
+				m.LastUsed = cloneTime(m.LastUsed)
+				return m
+			},
+			OnInsert: func(m *model.WebAuthnCredential) {
+				now := time.Now()
+				m.ID = nextID()
+				m.Created = now
+				m.Updated = now
+			},
+			OnUpdate: func(m *model.WebAuthnCredential, old model.WebAuthnCredential) {
+				name := m.Name
+				*m = old
+				m.Name = name
+				m.Updated = time.Now()
+			},
+		},
+	}
+}
+
+func (r *WebAuthnCredentialRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.WebAuthnCredential, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "created", Order: "ASC"}},
+	})
+}
+
+func (r *WebAuthnCredentialRepository) FindByCredentialID(ctx context.Context, credentialID string) (model.WebAuthnCredential, error) {
+	return r.FindBy(ctx, "credential_id", credentialID)
+}
+
+func (r *WebAuthnCredentialRepository) Use(_ context.Context, id int64, signCount uint32, backupState bool, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || (item.SignCount >= signCount && (item.SignCount != 0 || signCount != 0)) {
+		return false, nil
+	}
+
+	item.SignCount = signCount
+	item.BackupState = backupState
+	item.LastUsed = cloneTime(&now)
+	item.Updated = now
+	r.items[id] = item
+	return true, nil
+}
+
+type RecoveryCodeRepository struct {
+	Repository[model.RecoveryCode, int64]
+}
+
+func NewRecoveryCodeRepository() *RecoveryCodeRepository {
+	nextID := sequence()
+
+	return &RecoveryCodeRepository{
+		Repository: Repository[model.RecoveryCode, int64]{
+			Values: func(m *model.RecoveryCode) map[string]any {
+				return map[string]any{
+					"id":       m.ID,
+					"admin_id": m.AdminID,
+					"hash":     m.Hash,
+					"used":     m.Used,
+					"created":  m.Created,
+				}
+			},
+			UniqueKeys: func(m *model.RecoveryCode) []string {
+				return []string{"hash:" + m.Hash}
+			},
+			Clone: func(m model.RecoveryCode) model.RecoveryCode {
+				m.Used = cloneTime(m.Used)
+				return m
+			},
+			OnInsert: func(m *model.RecoveryCode) {
+				m.ID = nextID()
+				m.Created = time.Now()
+			},
+			OnUpdate: func(m *model.RecoveryCode, old model.RecoveryCode) {
+				used := m.Used
+				*m = old
+				m.Used = used
+			},
+		},
+	}
+}
+
+func (r *RecoveryCodeRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.RecoveryCode, error) {
+	return r.Find(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}},
+	}))
+}
+
+func (r *RecoveryCodeRepository) FindByHash(ctx context.Context, hash string) (model.RecoveryCode, error) {
+	return r.FindBy(ctx, "hash", hash)
+}
+
+func (r *RecoveryCodeRepository) Spend(_ context.Context, adminID int64, hash string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if item.Hash != hash || item.AdminID != adminID || item.Used != nil {
+			continue
+		}
+		item.Used = cloneTime(&now)
+		r.items[id] = item
+		return true, nil
+	}
+	return false, nil
+}
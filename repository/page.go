@@ -16,4 +16,5 @@ type Page interface {
 	FindByPattern(ctx context.Context, siteID int64, pattern string, now time.Time) (model.Page, error)
 	FindByAlias(ctx context.Context, siteID int64, alias string, now time.Time) (model.Page, error)
 	FindByURL(ctx context.Context, siteID int64, url string, now time.Time) (model.Page, error)
+	FindByTranslationGroupID(ctx context.Context, groupID int64, now time.Time) ([]model.Page, error)
 }
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type Permission interface {
+	repository[model.Permission, int64]
+	FindByAdminID(ctx context.Context, adminID int64) ([]model.Permission, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+)
+
+type Redirect interface {
+	repository[model.Redirect, int64]
+	// FindBySiteID returns the enabled redirects of the site and the global ones.
+	FindBySiteID(ctx context.Context, siteID int64) ([]model.Redirect, error)
+	// Hit adds the hits to the hit counter of the redirect and sets the time of the last one.
+	Hit(ctx context.Context, id int64, hits int64, last time.Time) error
+}
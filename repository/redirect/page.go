@@ -0,0 +1,140 @@
+package redirect
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gowool/cr"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+// PageRepository keeps old page URLs working, when the URL of a page
+// changes a permanent redirect from the old URL to the new one is stored.
+//
+// The URL of a page is built from the URL of its parent, so Update also saves
+// every descendant of a page whose URL changed, one Update per page, to
+// recompute their URLs and redirect their old ones. The inner repository
+// recomputes only the URL of the page saved, the descendants would keep the
+// old prefix until they are saved otherwise.
+//
+// The page is saved before its redirect and descendants are, so their failures
+// are logged instead of failing the save.
+type PageRepository struct {
+	repository.Page
+	Redirects repository.Redirect
+	logger    *zap.Logger
+}
+
+func NewPageRepository(inner repository.Page, redirects repository.Redirect, logger *zap.Logger) PageRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return PageRepository{
+		Page:      inner,
+		Redirects: redirects,
+		logger:    logger,
+	}
+}
+
+func (r PageRepository) Update(ctx context.Context, m *model.Page) error {
+	old, err := r.Page.FindByID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = r.Page.Update(ctx, m); err != nil {
+		return err
+	}
+
+	r.move(ctx, old, *m)
+	return nil
+}
+
+func (r PageRepository) move(ctx context.Context, old, m model.Page) {
+	if old.URL == m.URL || old.URL == "" || m.URL == "" || old.IsDynamic() || m.IsDynamic() {
+		return
+	}
+
+	if err := r.redirect(ctx, m.SiteID, m.ID, old.URL, m.URL); err != nil {
+		r.logger.Error("redirect: failed to redirect the old url of page",
+			zap.Int64("page_id", m.ID),
+			zap.String("from", old.URL),
+			zap.String("to", m.URL),
+			zap.Error(err),
+		)
+	}
+
+	children, err := r.Page.FindByParentID(ctx, m.ID, time.Time{})
+	if err != nil {
+		r.logger.Error("redirect: failed to find children of page", zap.Int64("page_id", m.ID), zap.Error(err))
+		return
+	}
+	for _, child := range children {
+		if err = r.Update(ctx, &child); err != nil {
+			r.logger.Error("redirect: failed to move child page",
+				zap.Int64("page_id", child.ID),
+				zap.Int64("parent_id", m.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (r PageRepository) redirect(ctx context.Context, siteID, pageID int64, from, to string) error {
+	redirects, err := r.Redirects.Find(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{
+			cr.Condition{Column: "site_id", Value: siteID},
+			cr.Condition{Column: "type", Value: model.RedirectExact},
+		},
+	}))
+	if err != nil {
+		return err
+	}
+
+	var (
+		current *model.Redirect
+		stale   []int64
+	)
+	for _, item := range redirects {
+		switch {
+		case item.Source == to:
+			// the new URL is served by the page again
+			stale = append(stale, item.ID)
+		case item.Source == from:
+			current = &item
+		case item.Target == from:
+			// avoid redirect chains to the old URL
+			item.Target = to
+			if err = r.Redirects.Update(ctx, &item); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		if err = r.Redirects.Delete(ctx, stale...); err != nil {
+			return err
+		}
+	}
+
+	if current == nil {
+		current = &model.Redirect{
+			SiteID: &siteID,
+			Type:   model.RedirectExact,
+			Source: from,
+		}
+	}
+	current.PageID = &pageID
+	current.Target = to
+	current.Status = http.StatusMovedPermanently
+	current.Enabled = true
+
+	if current.ID == 0 {
+		return r.Redirects.Create(ctx, current)
+	}
+	return r.Redirects.Update(ctx, current)
+}
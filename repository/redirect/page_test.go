@@ -0,0 +1,53 @@
+package redirect_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/memory"
+	"github.com/gowool/cms/repository/redirect"
+)
+
+type failingRedirects struct {
+	repository.Redirect
+}
+
+func (failingRedirects) Find(context.Context, *cr.Criteria) ([]model.Redirect, error) {
+	return nil, errors.New("redirects are down")
+}
+
+func TestPageRepository_RedirectFailureKeepsTheSave(t *testing.T) {
+	ctx := context.Background()
+	inner := memory.NewPageRepository()
+	pages := redirect.NewPageRepository(inner, failingRedirects{memory.NewRedirectRepository()}, nil)
+
+	parent := model.Page{SiteID: 1, Name: "a", URL: "/a", Pattern: model.PageCMS, Template: "page"}
+	if err := inner.Create(ctx, &parent); err != nil {
+		t.Fatal(err)
+	}
+	child := model.Page{SiteID: 1, ParentID: &parent.ID, Name: "b", Pattern: model.PageCMS, Template: "page"}
+	if err := inner.Create(ctx, &child); err != nil {
+		t.Fatal(err)
+	}
+	if child.URL != "/a/b" {
+		t.Fatalf("child url = %q, want %q", child.URL, "/a/b")
+	}
+
+	parent.URL = "/c"
+	if err := pages.Update(ctx, &parent); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	moved, err := inner.FindByID(ctx, child.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.URL != "/c/b" {
+		t.Fatalf("child url = %q, want %q", moved.URL, "/c/b")
+	}
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type Revision[T any] interface {
+	repository[model.Revision[T], int64]
+	FindByVersion(ctx context.Context, recordID int64, version int) (model.Revision[T], error)
+	FindLatest(ctx context.Context, recordID int64) (model.Revision[T], error)
+	// Append creates m as the next version of its record, the version is allocated by the store.
+	Append(ctx context.Context, m *model.Revision[T]) error
+}
+
+type (
+	PageRevision     = Revision[model.Page]
+	TemplateRevision = Revision[model.Template]
+)
@@ -0,0 +1,38 @@
+package revision
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type PageRepository struct {
+	repository.Page
+	recorder[model.Page]
+}
+
+func NewPageRepository(inner repository.Page, revisions repository.PageRevision, logger *zap.Logger) PageRepository {
+	return PageRepository{
+		Page:     inner,
+		recorder: newRecorder(revisions, logger),
+	}
+}
+
+func (r PageRepository) Create(ctx context.Context, m *model.Page) error {
+	if err := r.Page.Create(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, *m)
+	return nil
+}
+
+func (r PageRepository) Update(ctx context.Context, m *model.Page) error {
+	if err := r.Page.Update(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, *m)
+	return nil
+}
@@ -0,0 +1,42 @@
+package revision
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type recorder[T interface{ GetID() int64 }] struct {
+	revisions repository.Revision[T]
+	logger    *zap.Logger
+}
+
+func newRecorder[T interface{ GetID() int64 }](revisions repository.Revision[T], logger *zap.Logger) recorder[T] {
+	if revisions == nil {
+		panic("revision repository is not specified")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return recorder[T]{revisions: revisions, logger: logger}
+}
+
+// record stores a snapshot of m as the next version of the record. The record is saved
+// already, so a failure is logged instead of failing the save.
+func (r recorder[T]) record(ctx context.Context, m T) {
+	rev := model.Revision[T]{
+		RecordID: m.GetID(),
+		Data:     m,
+	}
+	if admin := cms.CtxAdmin(ctx); admin != nil {
+		rev.AdminID = &admin.ID
+	}
+
+	if err := r.revisions.Append(ctx, &rev); err != nil {
+		r.logger.Error("revision: failed to create version", zap.Int64("record_id", rev.RecordID), zap.Error(err))
+	}
+}
@@ -0,0 +1,85 @@
+package revision_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/memory"
+	"github.com/gowool/cms/repository/revision"
+)
+
+func TestPageRepository_ParallelUpdatesGetDistinctVersions(t *testing.T) {
+	ctx := context.Background()
+	revisions := memory.NewPageRevisionRepository()
+	pages := revision.NewPageRepository(memory.NewPageRepository(), revisions, nil)
+
+	page := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page"}
+	if err := pages.Create(ctx, &page); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 16
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := page
+			if err := pages.Update(ctx, &m); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	items, err := revisions.Find(ctx, cr.New().SetSortBy(cr.Sort{Column: "version", Order: "ASC"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != n+1 {
+		t.Fatalf("got %d revisions, want %d", len(items), n+1)
+	}
+	for i, item := range items {
+		if item.Version != i+1 {
+			t.Fatalf("revision %d has version %d", i, item.Version)
+		}
+	}
+}
+
+type failingRevisions struct {
+	repository.PageRevision
+}
+
+func (failingRevisions) Append(context.Context, *model.Revision[model.Page]) error {
+	return errors.New("revision store is down")
+}
+
+func TestPageRepository_RevisionFailureKeepsTheSave(t *testing.T) {
+	ctx := context.Background()
+	inner := memory.NewPageRepository()
+	pages := revision.NewPageRepository(inner, failingRevisions{memory.NewPageRevisionRepository()}, nil)
+
+	page := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page"}
+	if err := pages.Create(ctx, &page); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	page.Name = "index"
+	if err := pages.Update(ctx, &page); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	saved, err := inner.FindByID(ctx, page.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Name != "index" {
+		t.Fatalf("got name %q, want %q", saved.Name, "index")
+	}
+}
@@ -0,0 +1,38 @@
+package revision
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type TemplateRepository struct {
+	repository.Template
+	recorder[model.Template]
+}
+
+func NewTemplateRepository(inner repository.Template, revisions repository.TemplateRevision, logger *zap.Logger) TemplateRepository {
+	return TemplateRepository{
+		Template: inner,
+		recorder: newRecorder(revisions, logger),
+	}
+}
+
+func (r TemplateRepository) Create(ctx context.Context, m *model.Template) error {
+	if err := r.Template.Create(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, *m)
+	return nil
+}
+
+func (r TemplateRepository) Update(ctx context.Context, m *model.Template) error {
+	if err := r.Template.Update(ctx, m); err != nil {
+		return err
+	}
+	r.record(ctx, *m)
+	return nil
+}
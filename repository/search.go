@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gowool/cms/model"
+)
+
+type Search interface {
+	// Index adds or replaces the documents, the already indexed content
+	// of a page is kept when the document has no content.
+	Index(ctx context.Context, docs ...model.SearchDocument) error
+	// IndexContent replaces the rendered content of an indexed page.
+	IndexContent(ctx context.Context, pageID int64, content string) error
+	Remove(ctx context.Context, pageIDs ...int64) error
+	Search(ctx context.Context, query model.SearchQuery) ([]model.SearchHit, int, error)
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gowool/cr"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+// PageRepository keeps the search index in sync with the stored pages. The page is
+// saved before the index is written, so a failure of the index is logged instead of
+// failing the save.
+type PageRepository struct {
+	repository.Page
+	Sites  repository.Site
+	Index  repository.Search
+	logger *zap.Logger
+}
+
+func NewPageRepository(inner repository.Page, sites repository.Site, index repository.Search, logger *zap.Logger) PageRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return PageRepository{
+		Page:   inner,
+		Sites:  sites,
+		Index:  index,
+		logger: logger,
+	}
+}
+
+func (r PageRepository) Create(ctx context.Context, m *model.Page) error {
+	if err := r.Page.Create(ctx, m); err != nil {
+		return err
+	}
+	r.index(ctx, *m)
+	return nil
+}
+
+func (r PageRepository) Update(ctx context.Context, m *model.Page) error {
+	if err := r.Page.Update(ctx, m); err != nil {
+		return err
+	}
+	r.index(ctx, *m)
+	return nil
+}
+
+func (r PageRepository) Delete(ctx context.Context, ids ...int64) error {
+	if err := r.Page.Delete(ctx, ids...); err != nil {
+		return err
+	}
+	if err := r.Index.Remove(ctx, ids...); err != nil {
+		r.logger.Error("search: failed to remove pages", zap.Int64s("page_ids", ids), zap.Error(err))
+	}
+	return nil
+}
+
+func (r PageRepository) index(ctx context.Context, page model.Page) {
+	if !page.InSearch() {
+		if err := r.Index.Remove(ctx, page.ID); err != nil {
+			r.logger.Error("search: failed to remove page", zap.Int64("page_id", page.ID), zap.Error(err))
+		}
+		return
+	}
+
+	site, err := r.Sites.FindByID(ctx, page.SiteID)
+	if err != nil {
+		r.logger.Error("search: failed to find site", zap.Int64("page_id", page.ID), zap.Int64("site_id", page.SiteID), zap.Error(err))
+		return
+	}
+
+	if err = r.Index.Index(ctx, model.NewSearchDocument(page, site)); err != nil {
+		r.logger.Error("search: failed to index page", zap.Int64("page_id", page.ID), zap.Error(err))
+	}
+}
+
+// Reindex rebuilds the index of all stored pages, the rendered content is kept
+// for the pages which are already indexed.
+func Reindex(ctx context.Context, pages repository.Page, sites repository.Site, index repository.Search) error {
+	items, err := sites.Find(ctx, cr.New())
+	if err != nil {
+		return fmt.Errorf("search: failed to find sites: %w", err)
+	}
+
+	for _, site := range items {
+		data, err := pages.Find(ctx, cr.New().SetFilter(cr.Filter{
+			Conditions: []any{cr.Condition{Column: "site_id", Value: site.ID}},
+		}))
+		if err != nil {
+			return fmt.Errorf("search: failed to find pages of site %d: %w", site.ID, err)
+		}
+
+		var (
+			docs    []model.SearchDocument
+			removed []int64
+		)
+		for _, page := range data {
+			if page.InSearch() {
+				docs = append(docs, model.NewSearchDocument(page, site))
+			} else {
+				removed = append(removed, page.ID)
+			}
+		}
+
+		if err = index.Index(ctx, docs...); err != nil {
+			return err
+		}
+		if err = index.Remove(ctx, removed...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
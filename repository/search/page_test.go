@@ -0,0 +1,60 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+	"github.com/gowool/cms/repository/memory"
+	"github.com/gowool/cms/repository/search"
+)
+
+type failingIndex struct {
+	inner repository.Search
+}
+
+func (i failingIndex) IndexContent(ctx context.Context, pageID int64, content string) error {
+	return i.inner.IndexContent(ctx, pageID, content)
+}
+
+func (i failingIndex) Search(ctx context.Context, query model.SearchQuery) ([]model.SearchHit, int, error) {
+	return i.inner.Search(ctx, query)
+}
+
+func (failingIndex) Index(context.Context, ...model.SearchDocument) error {
+	return errors.New("index is down")
+}
+
+func (failingIndex) Remove(context.Context, ...int64) error {
+	return errors.New("index is down")
+}
+
+func TestPageRepository_IndexFailureKeepsTheSave(t *testing.T) {
+	ctx := context.Background()
+	inner := memory.NewPageRepository()
+	pages := search.NewPageRepository(inner, memory.NewSiteRepository(), failingIndex{inner: memory.NewSearchRepository()}, nil)
+
+	page := model.Page{SiteID: 1, Name: "home", Pattern: "/", Template: "page"}
+	if err := pages.Create(ctx, &page); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	page.Name = "index"
+	if err := pages.Update(ctx, &page); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	saved, err := inner.FindByID(ctx, page.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Name != "index" {
+		t.Fatalf("got name %q, want %q", saved.Name, "index")
+	}
+
+	if err = pages.Delete(ctx, page.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+}
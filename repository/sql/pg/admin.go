@@ -21,7 +21,7 @@ func NewAdminRepository(db *sql.DB) *AdminRepository {
 			DB:    db,
 			Table: "admins",
 			SelectColumns: []string{
-				"id", "avatar", "email", "role", "salt", "password", "otp", "created", "updated",
+				"id", "avatar", "email", "role", "salt", "password", "otp", "disabled", "created", "updated",
 			},
 			RowScan: func(row interface{ Scan(...any) error }, m *model.Admin) error {
 				var (
@@ -29,7 +29,7 @@ func NewAdminRepository(db *sql.DB) *AdminRepository {
 					otp      OTP
 					password Password
 				)
-				if err := row.Scan(&m.ID, &m.Avatar, &m.Email, &role, &m.Salt, &password, &otp, &m.Created, &m.Updated); err != nil {
+				if err := row.Scan(&m.ID, &m.Avatar, &m.Email, &role, &m.Salt, &password, &otp, &m.Disabled, &m.Created, &m.Updated); err != nil {
 					return err
 				}
 
@@ -49,18 +49,22 @@ func NewAdminRepository(db *sql.DB) *AdminRepository {
 					"salt":     m.Salt,
 					"password": Password(m.Password),
 					"otp":      &otp,
+					"disabled": m.Disabled,
 					"created":  now,
 					"updated":  now,
 				}
 			},
 			UpdateValues: func(m *model.Admin) map[string]any {
+				role := Role(m.Role)
+				otp := OTP(m.OTP)
 				return map[string]any{
 					"avatar":   m.Avatar,
 					"email":    m.Email,
-					"role":     Role(m.Role),
+					"role":     &role,
 					"salt":     m.Salt,
 					"password": Password(m.Password),
-					"otp":      OTP(m.OTP),
+					"otp":      &otp,
+					"disabled": m.Disabled,
 					"updated":  time.Now(),
 				}
 			},
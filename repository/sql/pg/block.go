@@ -0,0 +1,110 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Block = (*BlockRepository)(nil)
+
+type BlockRepository struct {
+	Repository[model.Block, int64]
+}
+
+func NewBlockRepository(db *sql.DB) *BlockRepository {
+	return &BlockRepository{
+		Repository[model.Block, int64]{
+			DB:    db,
+			Table: "blocks",
+			SelectColumns: []string{
+				"id", "site_id", "page_id", "parent_id", "region", "type", "name", "settings", "position",
+				"shared", "enabled", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.Block) error {
+				var name sql.NullString
+
+				if err := row.Scan(&m.ID, &m.SiteID, &m.PageID, &m.ParentID, &m.Region, &m.Type, &name,
+					&JSON[map[string]any]{V: &m.Settings}, &m.Position, &m.Shared, &m.Enabled, &m.Created, &m.Updated); err != nil {
+					return err
+				}
+
+				m.Name = name.String
+				return nil
+			},
+			InsertValues: func(m *model.Block) map[string]any {
+				now := time.Now()
+				return map[string]any{
+					"site_id":   m.SiteID,
+					"page_id":   m.PageID,
+					"parent_id": m.ParentID,
+					"region":    m.Region,
+					"type":      m.Type,
+					"name":      sql.NullString{String: m.Name, Valid: m.Name != ""},
+					"settings":  JSON[map[string]any]{V: blockSettings(m)},
+					"position":  m.Position,
+					"shared":    m.Shared,
+					"enabled":   m.Enabled,
+					"created":   now,
+					"updated":   now,
+				}
+			},
+			UpdateValues: func(m *model.Block) map[string]any {
+				return map[string]any{
+					"site_id":   m.SiteID,
+					"page_id":   m.PageID,
+					"parent_id": m.ParentID,
+					"region":    m.Region,
+					"type":      m.Type,
+					"name":      sql.NullString{String: m.Name, Valid: m.Name != ""},
+					"settings":  JSON[map[string]any]{V: blockSettings(m)},
+					"position":  m.Position,
+					"shared":    m.Shared,
+					"enabled":   m.Enabled,
+					"updated":   time.Now(),
+				}
+			},
+		},
+	}
+}
+
+func (r *BlockRepository) FindByPage(ctx context.Context, siteID, pageID int64) ([]model.Block, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "enabled", Value: true},
+				cr.Filter{
+					Operator: cr.OpOR,
+					Conditions: []any{
+						cr.Condition{Column: "page_id", Value: pageID},
+						cr.Filter{
+							Conditions: []any{
+								cr.Condition{Column: "shared", Value: true},
+								cr.Filter{
+									Operator: cr.OpOR,
+									Conditions: []any{
+										cr.Condition{Column: "site_id", Value: siteID},
+										"site_id IS NULL",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "position", Order: "ASC"}, cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
+
+func blockSettings(m *model.Block) *map[string]any {
+	if m.Settings == nil {
+		m.Settings = map[string]any{}
+	}
+	return &m.Settings
+}
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Media = (*MediaRepository)(nil)
+
+const mediaFoldersSQL = `SELECT DISTINCT folder FROM media WHERE folder <> '' ORDER BY folder`
+
+type MediaRepository struct {
+	Repository[model.Media, int64]
+}
+
+func NewMediaRepository(db *sql.DB) *MediaRepository {
+	return &MediaRepository{
+		Repository[model.Media, int64]{
+			DB:    db,
+			Table: "media",
+			SelectColumns: []string{
+				"id", "site_id", "folder", "name", "key", "alt", "mime", "size", "width", "height", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.Media) error {
+				return row.Scan(&m.ID, &m.SiteID, &m.Folder, &m.Name, &m.Key, &m.Alt, &m.MIME, &m.Size,
+					&m.Width, &m.Height, &m.Created, &m.Updated)
+			},
+			InsertValues: func(m *model.Media) map[string]any {
+				now := time.Now()
+				return map[string]any{
+					"site_id": m.SiteID,
+					"folder":  m.Folder,
+					"name":    m.Name,
+					"key":     m.Key,
+					"alt":     m.Alt,
+					"mime":    m.MIME,
+					"size":    m.Size,
+					"width":   m.Width,
+					"height":  m.Height,
+					"created": now,
+					"updated": now,
+				}
+			},
+			UpdateValues: func(m *model.Media) map[string]any {
+				return map[string]any{
+					"site_id": m.SiteID,
+					"folder":  m.Folder,
+					"name":    m.Name,
+					"alt":     m.Alt,
+					"updated": time.Now(),
+				}
+			},
+		},
+	}
+}
+
+func (r *MediaRepository) FindByKey(ctx context.Context, key string) (model.Media, error) {
+	return r.FindBy(ctx, "key", key)
+}
+
+func (r *MediaRepository) Folders(ctx context.Context) ([]string, error) {
+	rows, err := r.db(ctx).QueryContext(ctx, mediaFoldersSQL)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var folders []string
+	for rows.Next() {
+		var folder string
+		if err = rows.Scan(&folder); err != nil {
+			return nil, r.error(err)
+		}
+		folders = append(folders, folder)
+	}
+	return folders, r.error(rows.Err())
+}
@@ -25,7 +25,7 @@ func NewPageRepository(db *sql.DB) *PageRepository {
 			DB:    db,
 			Table: "pages",
 			SelectColumns: []string{
-				"id", "site_id", "parent_id", "name", "title", "pattern", "alias", "slug", "url", "custom_url",
+				"id", "site_id", "parent_id", "translation_group_id", "name", "title", "pattern", "alias", "slug", "url", "custom_url",
 				"javascript", "stylesheet", "template", "decorate", "position", "headers", "metas", "metadata",
 				"created", "updated", "published", "expired",
 			},
@@ -43,7 +43,7 @@ func NewPageRepository(db *sql.DB) *PageRepository {
 					headers    StrMap
 				)
 
-				if err := row.Scan(&m.ID, &m.SiteID, &m.ParentID, &m.Name, &title, &m.Pattern, &alias, &slug,
+				if err := row.Scan(&m.ID, &m.SiteID, &m.ParentID, &m.TranslationGroupID, &m.Name, &title, &m.Pattern, &alias, &slug,
 					&url, &customURL, &javascript, &stylesheet, &m.Template, &m.Decorate, &m.Position, &headers,
 					&metas, &metadata, &m.Created, &m.Updated, &m.Published, &m.Expired); err != nil {
 					return err
@@ -64,51 +64,53 @@ func NewPageRepository(db *sql.DB) *PageRepository {
 			InsertValues: func(m *model.Page) map[string]any {
 				now := time.Now()
 				return map[string]any{
-					"site_id":    m.SiteID,
-					"parent_id":  m.ParentID,
-					"name":       m.Name,
-					"title":      sql.NullString{String: m.Title, Valid: m.Title != ""},
-					"pattern":    m.Pattern,
-					"alias":      sql.NullString{String: m.Alias, Valid: m.Alias != ""},
-					"slug":       sql.NullString{String: m.Slug, Valid: m.Slug != ""},
-					"url":        sql.NullString{String: m.URL, Valid: m.URL != ""},
-					"custom_url": sql.NullString{String: m.CustomURL, Valid: m.CustomURL != ""},
-					"javascript": sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
-					"stylesheet": sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
-					"template":   m.Template,
-					"decorate":   m.Decorate,
-					"position":   m.Position,
-					"headers":    StrMap(m.Headers),
-					"metas":      Metas(m.Metas),
-					"metadata":   StrMap(m.Metadata),
-					"created":    now,
-					"updated":    now,
-					"published":  m.Published,
-					"expired":    m.Expired,
+					"site_id":              m.SiteID,
+					"parent_id":            m.ParentID,
+					"translation_group_id": m.TranslationGroupID,
+					"name":                 m.Name,
+					"title":                sql.NullString{String: m.Title, Valid: m.Title != ""},
+					"pattern":              m.Pattern,
+					"alias":                sql.NullString{String: m.Alias, Valid: m.Alias != ""},
+					"slug":                 sql.NullString{String: m.Slug, Valid: m.Slug != ""},
+					"url":                  sql.NullString{String: m.URL, Valid: m.URL != ""},
+					"custom_url":           sql.NullString{String: m.CustomURL, Valid: m.CustomURL != ""},
+					"javascript":           sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
+					"stylesheet":           sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
+					"template":             m.Template,
+					"decorate":             m.Decorate,
+					"position":             m.Position,
+					"headers":              StrMap(m.Headers),
+					"metas":                Metas(m.Metas),
+					"metadata":             StrMap(m.Metadata),
+					"created":              now,
+					"updated":              now,
+					"published":            m.Published,
+					"expired":              m.Expired,
 				}
 			},
 			UpdateValues: func(m *model.Page) map[string]any {
 				return map[string]any{
-					"site_id":    m.SiteID,
-					"parent_id":  m.ParentID,
-					"name":       m.Name,
-					"title":      sql.NullString{String: m.Title, Valid: m.Title != ""},
-					"pattern":    m.Pattern,
-					"alias":      sql.NullString{String: m.Alias, Valid: m.Alias != ""},
-					"slug":       sql.NullString{String: m.Slug, Valid: m.Slug != ""},
-					"url":        sql.NullString{String: m.URL, Valid: m.URL != ""},
-					"custom_url": sql.NullString{String: m.CustomURL, Valid: m.CustomURL != ""},
-					"javascript": sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
-					"stylesheet": sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
-					"template":   m.Template,
-					"decorate":   m.Decorate,
-					"position":   m.Position,
-					"headers":    StrMap(m.Headers),
-					"metas":      Metas(m.Metas),
-					"metadata":   StrMap(m.Metadata),
-					"updated":    time.Now(),
-					"published":  m.Published,
-					"expired":    m.Expired,
+					"site_id":              m.SiteID,
+					"parent_id":            m.ParentID,
+					"translation_group_id": m.TranslationGroupID,
+					"name":                 m.Name,
+					"title":                sql.NullString{String: m.Title, Valid: m.Title != ""},
+					"pattern":              m.Pattern,
+					"alias":                sql.NullString{String: m.Alias, Valid: m.Alias != ""},
+					"slug":                 sql.NullString{String: m.Slug, Valid: m.Slug != ""},
+					"url":                  sql.NullString{String: m.URL, Valid: m.URL != ""},
+					"custom_url":           sql.NullString{String: m.CustomURL, Valid: m.CustomURL != ""},
+					"javascript":           sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
+					"stylesheet":           sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
+					"template":             m.Template,
+					"decorate":             m.Decorate,
+					"position":             m.Position,
+					"headers":              StrMap(m.Headers),
+					"metas":                Metas(m.Metas),
+					"metadata":             StrMap(m.Metadata),
+					"updated":              time.Now(),
+					"published":            m.Published,
+					"expired":              m.Expired,
 				}
 			},
 			OnError: func(err error) error {
@@ -136,6 +138,21 @@ func (r *PageRepository) FindByParentID(ctx context.Context, parentID int64, now
 	})
 }
 
+func (r *PageRepository) FindByTranslationGroupID(ctx context.Context, groupID int64, now time.Time) ([]model.Page, error) {
+	conditions := []any{cr.Condition{Column: "translation_group_id", Value: groupID}}
+
+	if !now.IsZero() {
+		conditions = append(conditions, repository.LifeSpanConditions("", now)...)
+	}
+
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: conditions,
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "site_id", Order: "ASC"}, cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
+
 func (r *PageRepository) FindByPattern(ctx context.Context, siteID int64, pattern string, now time.Time) (model.Page, error) {
 	return r.findBy(ctx, siteID, "pattern", pattern, now)
 }
@@ -240,9 +240,13 @@ func (r Repository[T, ID]) error(err error) error {
 }
 
 func (r Repository[T, ID]) db(ctx context.Context) txDB {
-	var db txDB = r.DB
+	return ctxDB(ctx, r.DB)
+}
+
+// ctxDB returns the transaction of the context, see WithTx, or db without one.
+func ctxDB(ctx context.Context, db *sql.DB) txDB {
 	if tx, ok := ctx.Value(ctxTxKey{}).(*sql.Tx); ok {
-		db = tx
+		return tx
 	}
 	return db
 }
@@ -0,0 +1,166 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Search = (*SearchRepository)(nil)
+
+const (
+	searchIndexSQL = `INSERT INTO search_documents
+(page_id,site_id,locale,config,url,title,name,description,keywords,metadata,content,published,expired,updated)
+VALUES ($1,$2,$3,COALESCE((SELECT c.oid::regconfig FROM pg_catalog.pg_ts_config c WHERE c.cfgname = $4 LIMIT 1), 'simple'::regconfig),$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+ON CONFLICT (page_id) DO UPDATE SET
+site_id = EXCLUDED.site_id, locale = EXCLUDED.locale, config = EXCLUDED.config, url = EXCLUDED.url,
+title = EXCLUDED.title, name = EXCLUDED.name, description = EXCLUDED.description, keywords = EXCLUDED.keywords,
+metadata = EXCLUDED.metadata, content = COALESCE(NULLIF(EXCLUDED.content, ''), search_documents.content),
+published = EXCLUDED.published, expired = EXCLUDED.expired, updated = EXCLUDED.updated`
+	searchContentSQL = "UPDATE search_documents SET content = $1 WHERE page_id = $2 AND content <> $1"
+	searchRemoveSQL  = "DELETE FROM search_documents WHERE page_id = ANY($1)"
+	searchSQL        = `SELECT d.page_id, d.site_id, d.locale, d.url, COALESCE(NULLIF(d.title, ''), d.name),
+ts_headline(d.config, COALESCE(NULLIF(d.content, ''), NULLIF(d.description, ''), d.title), q, $6),
+ts_rank(d.document, q), COUNT(*) OVER()
+FROM search_documents d, LATERAL websearch_to_tsquery(d.config, $1) q
+WHERE d.site_id = $2 AND ($3 = '' OR d.locale = $3) AND d.document @@ q
+AND ($4::timestamptz IS NULL OR (d.published IS NOT NULL AND d.published <= $4 AND (d.expired IS NULL OR d.expired > $4)))
+ORDER BY 7 DESC, d.page_id ASC
+LIMIT $5 OFFSET $7`
+
+	// control characters never appear in the indexed text, so the headline
+	// can be escaped before the selection markers become mark elements
+	searchStartSel = "\x01"
+	searchStopSel  = "\x02"
+)
+
+var (
+	searchHeadlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=30, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \"", searchStartSel, searchStopSel)
+	searchMarker          = strings.NewReplacer(searchStartSel, "<mark>", searchStopSel, "</mark>")
+
+	// searchConfigs maps languages to the built-in text search configurations, a configuration
+	// the server does not have, older versions lack some of them, falls back to simple.
+	searchConfigs = map[string]string{
+		"ar": "arabic",
+		"ca": "catalan",
+		"da": "danish",
+		"de": "german",
+		"el": "greek",
+		"en": "english",
+		"es": "spanish",
+		"eu": "basque",
+		"fi": "finnish",
+		"fr": "french",
+		"ga": "irish",
+		"hi": "hindi",
+		"hu": "hungarian",
+		"hy": "armenian",
+		"id": "indonesian",
+		"it": "italian",
+		"lt": "lithuanian",
+		"ne": "nepali",
+		"nl": "dutch",
+		"no": "norwegian",
+		"nb": "norwegian",
+		"nn": "norwegian",
+		"pt": "portuguese",
+		"ro": "romanian",
+		"ru": "russian",
+		"sr": "serbian",
+		"sv": "swedish",
+		"ta": "tamil",
+		"tr": "turkish",
+		"yi": "yiddish",
+	}
+)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) Index(ctx context.Context, docs ...model.SearchDocument) error {
+	for _, doc := range docs {
+		if _, err := ctxDB(ctx, r.db).ExecContext(ctx, searchIndexSQL,
+			doc.PageID, doc.SiteID, doc.Locale, searchConfig(doc.Locale), doc.URL, doc.Title, doc.Name,
+			doc.Description, doc.Keywords, doc.Metadata, doc.Content, doc.Published, doc.Expired, doc.Updated,
+		); err != nil {
+			return fmt.Errorf("search: index page %d: %w", doc.PageID, err)
+		}
+	}
+	return nil
+}
+
+func (r *SearchRepository) IndexContent(ctx context.Context, pageID int64, content string) error {
+	_, err := ctxDB(ctx, r.db).ExecContext(ctx, searchContentSQL, content, pageID)
+	return err
+}
+
+func (r *SearchRepository) Remove(ctx context.Context, pageIDs ...int64) error {
+	if len(pageIDs) == 0 {
+		return nil
+	}
+	_, err := ctxDB(ctx, r.db).ExecContext(ctx, searchRemoveSQL, pageIDs)
+	return err
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query model.SearchQuery) ([]model.SearchHit, int, error) {
+	if strings.TrimSpace(query.Text) == "" {
+		return nil, 0, nil
+	}
+
+	var now *time.Time
+	if !query.Now.IsZero() {
+		now = internal.Ptr(query.Now.Truncate(time.Minute))
+	}
+
+	limit := sql.NullInt64{Int64: int64(query.Limit), Valid: query.Limit > 0}
+
+	rows, err := ctxDB(ctx, r.db).QueryContext(ctx, searchSQL,
+		query.Text, query.SiteID, query.Locale, now, limit, searchHeadlineOptions, max(query.Offset, 0))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		hits  []model.SearchHit
+		total int
+	)
+	for rows.Next() {
+		var (
+			hit      model.SearchHit
+			headline string
+		)
+		if err = rows.Scan(&hit.PageID, &hit.SiteID, &hit.Locale, &hit.URL, &hit.Title, &headline, &hit.Rank, &total); err != nil {
+			return nil, 0, err
+		}
+		hit.Snippet = template.HTML(searchMarker.Replace(html.EscapeString(headline)))
+		hits = append(hits, hit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
+
+func searchConfig(locale string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "-", "_")), "_")
+	if config, ok := searchConfigs[lang]; ok {
+		return config
+	}
+	return "simple"
+}
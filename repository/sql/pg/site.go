@@ -25,7 +25,7 @@ func NewSiteRepository(db *sql.DB) *SiteRepository {
 			Table: "sites",
 			SelectColumns: []string{
 				"id", "name", "title", "separator", "host", "locale", "relative_path", "is_default",
-				"javascript", "stylesheet", "metas", "metadata", "created", "updated", "published", "expired",
+				"javascript", "stylesheet", "robots", "metas", "metadata", "created", "updated", "published", "expired",
 			},
 			RowScan: func(row interface{ Scan(...any) error }, m *model.Site) error {
 				var (
@@ -34,12 +34,13 @@ func NewSiteRepository(db *sql.DB) *SiteRepository {
 					relativePath sql.NullString
 					javascript   sql.NullString
 					stylesheet   sql.NullString
+					robots       sql.NullString
 					metas        Metas
 					metadata     StrMap
 				)
 
 				if err := row.Scan(&m.ID, &m.Name, &title, &m.Separator, &m.Host, &locale, &relativePath,
-					&m.IsDefault, &javascript, &stylesheet, &metas, &metadata, &m.Created, &m.Updated,
+					&m.IsDefault, &javascript, &stylesheet, &robots, &metas, &metadata, &m.Created, &m.Updated,
 					&m.Published, &m.Expired); err != nil {
 					return err
 				}
@@ -49,6 +50,7 @@ func NewSiteRepository(db *sql.DB) *SiteRepository {
 				m.RelativePath = relativePath.String
 				m.Javascript = javascript.String
 				m.Stylesheet = stylesheet.String
+				m.Robots = robots.String
 				m.Metas = metas
 				m.Metadata = metadata
 				return nil
@@ -65,6 +67,7 @@ func NewSiteRepository(db *sql.DB) *SiteRepository {
 					"is_default":    m.IsDefault,
 					"javascript":    sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
 					"stylesheet":    sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
+					"robots":        sql.NullString{String: m.Robots, Valid: m.Robots != ""},
 					"metas":         Metas(m.Metas),
 					"metadata":      StrMap(m.Metadata),
 					"created":       now,
@@ -84,6 +87,7 @@ func NewSiteRepository(db *sql.DB) *SiteRepository {
 					"is_default":    m.IsDefault,
 					"javascript":    sql.NullString{String: m.Javascript, Valid: m.Javascript != ""},
 					"stylesheet":    sql.NullString{String: m.Stylesheet, Valid: m.Stylesheet != ""},
+					"robots":        sql.NullString{String: m.Robots, Valid: m.Robots != ""},
 					"metas":         Metas(m.Metas),
 					"metadata":      StrMap(m.Metadata),
 					"updated":       time.Now(),
@@ -33,6 +33,29 @@ func (m Metas) Value() (driver.Value, error) {
 	return internal.String(raw), nil
 }
 
+type JSON[T any] struct {
+	V *T
+}
+
+func (j *JSON[T]) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal(internal.Bytes(src), j.V)
+	case []byte:
+		return json.Unmarshal(src, j.V)
+	default:
+		return errors.New("invalid src type for JSON")
+	}
+}
+
+func (j JSON[T]) Value() (driver.Value, error) {
+	raw, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, err
+	}
+	return internal.String(raw), nil
+}
+
 type StrMap map[string]string
 
 func (m StrMap) Scan(src any) error {
@@ -0,0 +1,142 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const (
+	useWebAuthnCredentialSQL = "UPDATE %s SET sign_count = $1, backup_state = $2, last_used = $3, updated = $3 WHERE id = $4 AND (sign_count < $1 OR (sign_count = 0 AND $1 = 0))"
+	spendRecoveryCodeSQL     = "UPDATE %s SET used = $1 WHERE hash = $2 AND admin_id = $3 AND used IS NULL"
+)
+
+var (
+	_ repository.WebAuthnCredential = (*WebAuthnCredentialRepository)(nil)
+	_ repository.RecoveryCode       = (*RecoveryCodeRepository)(nil)
+)
+
+type WebAuthnCredentialRepository struct {
+	Repository[model.WebAuthnCredential, int64]
+}
+
+func NewWebAuthnCredentialRepository(db *sql.DB) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{
+		Repository[model.WebAuthnCredential, int64]{
+			DB:    db,
+			Table: "webauthn_credentials",
+			SelectColumns: []string{
+				"id", "admin_id", "name", "credential_id", "public_key", "attestation_type", "aaguid", "sign_count",
+				"transports", "backup_eligible", "backup_state", "last_used", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.WebAuthnCredential) error {
+				return row.Scan(&m.ID, &m.AdminID, &m.Name, &m.CredentialID, &m.PublicKey, &m.AttestationType, &m.AAGUID,
+					&m.SignCount, &JSON[[]string]{V: &m.Transports}, &m.BackupEligible, &m.BackupState, &m.LastUsed,
+					&m.Created, &m.Updated)
+			},
+			InsertValues: func(m *model.WebAuthnCredential) map[string]any {
+				now := time.Now()
+				return map[string]any{
+					"admin_id":         m.AdminID,
+					"name":             m.Name,
+					"credential_id":    m.CredentialID,
+					"public_key":       m.PublicKey,
+					"attestation_type": m.AttestationType,
+					"aaguid":           m.AAGUID,
+					"sign_count":       m.SignCount,
+					"transports":       JSON[[]string]{V: &m.Transports},
+					"backup_eligible":  m.BackupEligible,
+					"backup_state":     m.BackupState,
+					"last_used":        m.LastUsed,
+					"created":          now,
+					"updated":          now,
+				}
+			},
+			UpdateValues: func(m *model.WebAuthnCredential) map[string]any {
+				return map[string]any{
+					"name":    m.Name,
+					"updated": time.Now(),
+				}
+			},
+		},
+	}
+}
+
+func (r *WebAuthnCredentialRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.WebAuthnCredential, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "created", Order: "ASC"}},
+	})
+}
+
+func (r *WebAuthnCredentialRepository) FindByCredentialID(ctx context.Context, credentialID string) (model.WebAuthnCredential, error) {
+	return r.FindBy(ctx, "credential_id", credentialID)
+}
+
+func (r *WebAuthnCredentialRepository) Use(ctx context.Context, id int64, signCount uint32, backupState bool, now time.Time) (bool, error) {
+	result, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(useWebAuthnCredentialSQL, r.Table), signCount, backupState, now, id)
+	if err != nil {
+		return false, r.error(err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, r.error(err)
+}
+
+type RecoveryCodeRepository struct {
+	Repository[model.RecoveryCode, int64]
+}
+
+func NewRecoveryCodeRepository(db *sql.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		Repository[model.RecoveryCode, int64]{
+			DB:            db,
+			Table:         "recovery_codes",
+			SelectColumns: []string{"id", "admin_id", "hash", "used", "created"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.RecoveryCode) error {
+				return row.Scan(&m.ID, &m.AdminID, &m.Hash, &m.Used, &m.Created)
+			},
+			InsertValues: func(m *model.RecoveryCode) map[string]any {
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"hash":     m.Hash,
+					"used":     m.Used,
+					"created":  time.Now(),
+				}
+			},
+			UpdateValues: func(m *model.RecoveryCode) map[string]any {
+				return map[string]any{
+					"used": m.Used,
+				}
+			},
+		},
+	}
+}
+
+func (r *RecoveryCodeRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.RecoveryCode, error) {
+	return r.Find(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}},
+	}))
+}
+
+func (r *RecoveryCodeRepository) FindByHash(ctx context.Context, hash string) (model.RecoveryCode, error) {
+	return r.FindBy(ctx, "hash", hash)
+}
+
+func (r *RecoveryCodeRepository) Spend(ctx context.Context, adminID int64, hash string, now time.Time) (bool, error) {
+	result, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(spendRecoveryCodeSQL, r.Table), now, hash, adminID)
+	if err != nil {
+		return false, r.error(err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, r.error(err)
+}
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const acceptInvitationSQL = "UPDATE %s SET accepted = ?, updated = ? WHERE id = ? AND accepted IS NULL"
+
+var _ repository.AdminInvitation = (*AdminInvitationRepository)(nil)
+
+type AdminInvitationRepository struct {
+	Repository[model.AdminInvitation, int64]
+}
+
+func NewAdminInvitationRepository(db *sql.DB) *AdminInvitationRepository {
+	return &AdminInvitationRepository{
+		Repository[model.AdminInvitation, int64]{
+			DB:    db,
+			Table: "admin_invitations",
+			SelectColumns: []string{
+				"id", "email", "role", "site_ids", "invited_by", "admin_id", "expires", "accepted", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.AdminInvitation) error {
+				var role Role
+				if err := row.Scan(&m.ID, &m.Email, &role, &JSON[[]int64]{V: &m.SiteIDs}, &m.InvitedBy, &m.AdminID,
+					&m.Expires, &m.Accepted, &m.Created, &m.Updated); err != nil {
+					return err
+				}
+				m.Role = model.Role(role)
+				return nil
+			},
+			InsertValues: func(m *model.AdminInvitation) map[string]any {
+				now := time.Now().UTC()
+				role := Role(m.Role)
+				return map[string]any{
+					"email":      m.Email,
+					"role":       &role,
+					"site_ids":   JSON[[]int64]{V: &m.SiteIDs},
+					"invited_by": m.InvitedBy,
+					"admin_id":   m.AdminID,
+					"expires":    m.Expires,
+					"accepted":   m.Accepted,
+					"created":    now,
+					"updated":    now,
+				}
+			},
+			UpdateValues: func(m *model.AdminInvitation) map[string]any {
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"accepted": m.Accepted,
+					"updated":  time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *AdminInvitationRepository) Accept(ctx context.Context, id int64, now time.Time) (bool, error) {
+	result, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(acceptInvitationSQL, r.Table), now.UTC(), now.UTC(), id)
+	if err != nil {
+		return false, r.error(err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, r.error(err)
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.AdminSession = (*AdminSessionRepository)(nil)
+
+const (
+	rotateSQL         = "UPDATE %s SET rotated = ?, updated = ? WHERE id = ? AND rotated IS NULL AND revoked IS NULL"
+	revokeFamiliesSQL = "UPDATE %s SET revoked = ?, updated = ? WHERE family_id IN (%s) AND revoked IS NULL"
+	deleteExpiredSQL  = "DELETE FROM %s WHERE expires < ?"
+)
+
+type AdminSessionRepository struct {
+	Repository[model.AdminSession, int64]
+}
+
+func NewAdminSessionRepository(db *sql.DB) *AdminSessionRepository {
+	return &AdminSessionRepository{
+		Repository[model.AdminSession, int64]{
+			DB:    db,
+			Table: "admin_sessions",
+			SelectColumns: []string{
+				"id", "admin_id", "family_id", "token_hash", "two_fa", "ip", "user_agent", "started", "expires",
+				"rotated", "revoked", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.AdminSession) error {
+				return row.Scan(&m.ID, &m.AdminID, &m.FamilyID, &m.TokenHash, &m.TwoFA, &m.IP, &m.UserAgent,
+					&m.Started, &m.Expires, &m.Rotated, &m.Revoked, &m.Created, &m.Updated)
+			},
+			InsertValues: func(m *model.AdminSession) map[string]any {
+				now := time.Now().UTC()
+				return map[string]any{
+					"admin_id":   m.AdminID,
+					"family_id":  m.FamilyID,
+					"token_hash": m.TokenHash,
+					"two_fa":     m.TwoFA,
+					"ip":         m.IP,
+					"user_agent": m.UserAgent,
+					"started":    m.Started,
+					"expires":    m.Expires,
+					"rotated":    m.Rotated,
+					"revoked":    m.Revoked,
+					"created":    now,
+					"updated":    now,
+				}
+			},
+			UpdateValues: func(m *model.AdminSession) map[string]any {
+				return map[string]any{
+					"rotated": m.Rotated,
+					"revoked": m.Revoked,
+					"updated": time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *AdminSessionRepository) FindByTokenHash(ctx context.Context, hash string) (model.AdminSession, error) {
+	return r.FindBy(ctx, "token_hash", hash)
+}
+
+func (r *AdminSessionRepository) FindCurrent(ctx context.Context, familyID string) (model.AdminSession, error) {
+	sessions, err := r.Find(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{
+			cr.Condition{Column: "family_id", Value: familyID},
+			"rotated IS NULL",
+		},
+	}).SetSize(1))
+	if err != nil {
+		return model.AdminSession{}, err
+	}
+	if len(sessions) == 0 {
+		return model.AdminSession{}, r.error(sql.ErrNoRows)
+	}
+	return sessions[0], nil
+}
+
+func (r *AdminSessionRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.AdminSession, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "admin_id", Value: adminID},
+				"rotated IS NULL",
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "created", Order: "DESC"}},
+	})
+}
+
+func (r *AdminSessionRepository) Rotate(ctx context.Context, id int64, now time.Time) (bool, error) {
+	now = now.UTC()
+
+	result, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(rotateSQL, r.Table), now, now, id)
+	if err != nil {
+		return false, r.error(err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, r.error(err)
+}
+
+func (r *AdminSessionRepository) RevokeFamilies(ctx context.Context, now time.Time, familyIDs ...string) error {
+	if len(familyIDs) == 0 {
+		return nil
+	}
+
+	now = now.UTC()
+	args := make([]any, 0, len(familyIDs)+2)
+	args = append(args, now, now)
+	for _, familyID := range familyIDs {
+		args = append(args, familyID)
+	}
+
+	query := fmt.Sprintf(revokeFamiliesSQL, r.Table, strings.TrimSuffix(strings.Repeat("?,", len(familyIDs)), ","))
+
+	_, err := r.db(ctx).ExecContext(ctx, query, args...)
+	return r.error(err)
+}
+
+func (r *AdminSessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	_, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(deleteExpiredSQL, r.Table), now.UTC())
+	return r.error(err)
+}
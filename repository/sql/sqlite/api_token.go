@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const (
+	revokeSQL = "UPDATE %s SET revoked = ?, updated = ? WHERE id IN (%s) AND revoked IS NULL"
+	touchSQL  = "UPDATE %s SET last_used = ?, last_used_ip = ? WHERE id = ? AND revoked IS NULL"
+)
+
+var _ repository.APIToken = (*APITokenRepository)(nil)
+
+type APITokenRepository struct {
+	Repository[model.APIToken, int64]
+}
+
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{
+		Repository[model.APIToken, int64]{
+			DB:    db,
+			Table: "api_tokens",
+			SelectColumns: []string{
+				"id", "admin_id", "name", "prefix", "hash", "role", "resources", "expires", "revoked",
+				"last_used", "last_used_ip", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.APIToken) error {
+				var role Role
+				if err := row.Scan(&m.ID, &m.AdminID, &m.Name, &m.Prefix, &m.Hash, &role, &JSON[[]string]{V: &m.Resources},
+					&m.Expires, &m.Revoked, &m.LastUsed, &m.LastUsedIP, &m.Created, &m.Updated); err != nil {
+					return err
+				}
+				m.Role = model.Role(role)
+				return nil
+			},
+			InsertValues: func(m *model.APIToken) map[string]any {
+				now := time.Now().UTC()
+				role := Role(m.Role)
+				return map[string]any{
+					"admin_id":     m.AdminID,
+					"name":         m.Name,
+					"prefix":       m.Prefix,
+					"hash":         m.Hash,
+					"role":         &role,
+					"resources":    JSON[[]string]{V: &m.Resources},
+					"expires":      m.Expires,
+					"revoked":      m.Revoked,
+					"last_used":    m.LastUsed,
+					"last_used_ip": m.LastUsedIP,
+					"created":      now,
+					"updated":      now,
+				}
+			},
+			UpdateValues: func(m *model.APIToken) map[string]any {
+				role := Role(m.Role)
+				return map[string]any{
+					"name":      m.Name,
+					"role":      &role,
+					"resources": JSON[[]string]{V: &m.Resources},
+					"expires":   m.Expires,
+					"updated":   time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *APITokenRepository) FindByHash(ctx context.Context, hash string) (model.APIToken, error) {
+	return r.FindBy(ctx, "hash", hash)
+}
+
+func (r *APITokenRepository) Revoke(ctx context.Context, now time.Time, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	now = now.UTC()
+	args := make([]any, 0, len(ids)+2)
+	args = append(args, now, now)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(revokeSQL, r.Table, strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","))
+
+	_, err := r.db(ctx).ExecContext(ctx, query, args...)
+	return r.error(err)
+}
+
+func (r *APITokenRepository) Touch(ctx context.Context, id int64, now time.Time, ip string) error {
+	_, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(touchSQL, r.Table), now.UTC(), ip, id)
+	return r.error(err)
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.AuditLog = (*AuditLogRepository)(nil)
+
+type AuditLogRepository struct {
+	Repository[model.AuditLog, int64]
+}
+
+func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		Repository[model.AuditLog, int64]{
+			DB:    db,
+			Table: "audit_log",
+			SelectColumns: []string{"id", "operation_id", "admin_id", "admin_email", "action", "target_type", "target_id",
+				"data_before", "data_after", "ip", "created"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.AuditLog) error {
+				return row.Scan(&m.ID, &m.OperationID, &m.AdminID, &m.AdminEmail, &m.Action, &m.TargetType, &m.TargetID,
+					&JSON[json.RawMessage]{V: &m.Before}, &JSON[json.RawMessage]{V: &m.After}, &m.IP, &m.Created)
+			},
+			InsertValues: func(m *model.AuditLog) map[string]any {
+				return map[string]any{
+					"operation_id": m.OperationID,
+					"admin_id":     m.AdminID,
+					"admin_email":  m.AdminEmail,
+					"action":       m.Action,
+					"target_type":  m.TargetType,
+					"target_id":    m.TargetID,
+					"data_before":  JSON[json.RawMessage]{V: &m.Before},
+					"data_after":   JSON[json.RawMessage]{V: &m.After},
+					"ip":           m.IP,
+					"created":      time.Now().UTC(),
+				}
+			},
+			UpdateValues: func(m *model.AuditLog) map[string]any {
+				return map[string]any{
+					"operation_id": m.OperationID,
+					"admin_id":     m.AdminID,
+					"admin_email":  m.AdminEmail,
+					"action":       m.Action,
+					"target_type":  m.TargetType,
+					"target_id":    m.TargetID,
+					"data_before":  JSON[json.RawMessage]{V: &m.Before},
+					"data_after":   JSON[json.RawMessage]{V: &m.After},
+					"ip":           m.IP,
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.PageDraft = (*PageDraftRepository)(nil)
+
+type PageDraftRepository struct {
+	Repository[model.PageDraft, int64]
+}
+
+func NewPageDraftRepository(db *sql.DB) *PageDraftRepository {
+	return &PageDraftRepository{
+		Repository[model.PageDraft, int64]{
+			DB:            db,
+			Table:         "page_drafts",
+			SelectColumns: []string{"id", "page_id", "admin_id", "data", "created", "updated"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.PageDraft) error {
+				return row.Scan(&m.ID, &m.PageID, &m.AdminID, &JSON[model.Page]{V: &m.Page}, &m.Created, &m.Updated)
+			},
+			InsertValues: func(m *model.PageDraft) map[string]any {
+				now := time.Now().UTC()
+				return map[string]any{
+					"page_id":  m.PageID,
+					"admin_id": m.AdminID,
+					"data":     JSON[model.Page]{V: &m.Page},
+					"created":  now,
+					"updated":  now,
+				}
+			},
+			UpdateValues: func(m *model.PageDraft) map[string]any {
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"data":     JSON[model.Page]{V: &m.Page},
+					"updated":  time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *PageDraftRepository) FindByPageID(ctx context.Context, pageID int64) (model.PageDraft, error) {
+	return r.FindBy(ctx, "page_id", pageID)
+}
+
+func (r *PageDraftRepository) Publish(ctx context.Context, pageID int64, save func(context.Context, *model.Page) error) (page model.Page, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return model.Page{}, r.error(err)
+	}
+
+	defer func() {
+		if err == nil {
+			err = r.error(tx.Commit())
+		} else {
+			err = errors.Join(err, r.error(tx.Rollback()))
+		}
+	}()
+
+	ctx = WithTx(ctx, tx)
+
+	draft, err := r.FindByPageID(ctx, pageID)
+	if err != nil {
+		return model.Page{}, err
+	}
+
+	page = draft.Live()
+	if err = save(ctx, &page); err != nil {
+		return model.Page{}, err
+	}
+
+	err = r.Delete(ctx, draft.ID)
+	return
+}
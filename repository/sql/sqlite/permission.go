@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.Permission = (*PermissionRepository)(nil)
+
+type PermissionRepository struct {
+	Repository[model.Permission, int64]
+}
+
+func NewPermissionRepository(db *sql.DB) *PermissionRepository {
+	return &PermissionRepository{
+		Repository[model.Permission, int64]{
+			DB:            db,
+			Table:         "permissions",
+			SelectColumns: []string{"id", "admin_id", "role", "resource", "site_id", "page_id", "created", "updated"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.Permission) error {
+				var role Role
+				if err := row.Scan(&m.ID, &m.AdminID, &role, &m.Resource, &m.SiteID, &m.PageID, &m.Created, &m.Updated); err != nil {
+					return err
+				}
+				m.Role = model.Role(role)
+				return nil
+			},
+			InsertValues: func(m *model.Permission) map[string]any {
+				now := time.Now().UTC()
+				role := Role(m.Role)
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"role":     &role,
+					"resource": m.Resource,
+					"site_id":  m.SiteID,
+					"page_id":  m.PageID,
+					"created":  now,
+					"updated":  now,
+				}
+			},
+			UpdateValues: func(m *model.Permission) map[string]any {
+				role := Role(m.Role)
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"role":     &role,
+					"resource": m.Resource,
+					"site_id":  m.SiteID,
+					"page_id":  m.PageID,
+					"updated":  time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *PermissionRepository) FindByAdminID(ctx context.Context, adminID int64) ([]model.Permission, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{Conditions: []any{cr.Condition{Column: "admin_id", Value: adminID}}},
+		SortBy: cr.SortBy{cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+const hitSQL = "UPDATE %s SET hits = hits + ?, last_hit = ? WHERE id = ?"
+
+var _ repository.Redirect = (*RedirectRepository)(nil)
+
+type RedirectRepository struct {
+	Repository[model.Redirect, int64]
+}
+
+func NewRedirectRepository(db *sql.DB) *RedirectRepository {
+	return &RedirectRepository{
+		Repository[model.Redirect, int64]{
+			DB:    db,
+			Table: "redirects",
+			SelectColumns: []string{
+				"id", "site_id", "page_id", "type", "source", "target", "status", "enabled",
+				"hits", "last_hit", "created", "updated",
+			},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.Redirect) error {
+				var target sql.NullString
+
+				if err := row.Scan(&m.ID, &m.SiteID, &m.PageID, &m.Type, &m.Source, &target, &m.Status,
+					&m.Enabled, &m.Hits, &m.LastHit, &m.Created, &m.Updated); err != nil {
+					return err
+				}
+
+				m.Target = target.String
+				return nil
+			},
+			InsertValues: func(m *model.Redirect) map[string]any {
+				now := time.Now().UTC()
+				return map[string]any{
+					"site_id": m.SiteID,
+					"page_id": m.PageID,
+					"type":    m.Type,
+					"source":  m.Source,
+					"target":  sql.NullString{String: m.Target, Valid: m.Target != ""},
+					"status":  m.Status,
+					"enabled": m.Enabled,
+					"created": now,
+					"updated": now,
+				}
+			},
+			UpdateValues: func(m *model.Redirect) map[string]any {
+				return map[string]any{
+					"site_id": m.SiteID,
+					"page_id": m.PageID,
+					"type":    m.Type,
+					"source":  m.Source,
+					"target":  sql.NullString{String: m.Target, Valid: m.Target != ""},
+					"status":  m.Status,
+					"enabled": m.Enabled,
+					"updated": time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
+
+func (r *RedirectRepository) FindBySiteID(ctx context.Context, siteID int64) ([]model.Redirect, error) {
+	return r.Find(ctx, &cr.Criteria{
+		Filter: cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: "enabled", Value: true},
+				cr.Filter{
+					Operator: cr.OpOR,
+					Conditions: []any{
+						cr.Condition{Column: "site_id", Value: siteID},
+						"site_id IS NULL",
+					},
+				},
+			},
+		},
+		SortBy: cr.SortBy{cr.Sort{Column: "id", Order: "ASC"}},
+	})
+}
+
+func (r *RedirectRepository) Hit(ctx context.Context, id int64, hits int64, last time.Time) error {
+	_, err := r.db(ctx).ExecContext(ctx, fmt.Sprintf(hitSQL, r.Table), hits, last.UTC(), id)
+	return r.error(err)
+}
@@ -0,0 +1,363 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/repository"
+)
+
+const (
+	selectSQL       = "SELECT %s FROM %s"
+	selectOneSQL    = "SELECT %s FROM %s WHERE %s = ? LIMIT 1"
+	countSQL        = "SELECT COUNT(*) FROM %s"
+	insertSQL       = "INSERT INTO %s (%s) VALUES (%s)"
+	updateSQL       = "UPDATE %s SET %s WHERE id = ?"
+	deleteSQL       = "DELETE FROM %s WHERE id IN (%s)"
+	uniqueViolation = "UNIQUE constraint failed"
+)
+
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, ctxTxKey{}, tx)
+}
+
+type (
+	ctxTxKey struct{}
+	txDB     interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+		QueryRowContext(context.Context, string, ...any) *sql.Row
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	}
+)
+
+type Repository[T interface{ GetID() ID }, ID any] struct {
+	DB            *sql.DB
+	Table         string
+	SelectColumns []string
+	RowScan       func(interface{ Scan(dest ...any) error }, *T) error
+	InsertValues  func(*T) map[string]any
+	UpdateValues  func(*T) map[string]any
+	OnError       func(error) error
+}
+
+func (r Repository[T, ID]) FindAndCount(ctx context.Context, criteria *cr.Criteria) ([]T, int, error) {
+	if criteria == nil {
+		criteria = cr.New()
+	}
+
+	where, args := r.where(criteria)
+
+	var total int
+	if err := r.db(ctx).QueryRowContext(ctx, fmt.Sprintf(countSQL, r.Table)+where, args...).Scan(&total); err != nil {
+		return nil, 0, r.error(err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	data, err := r.find(ctx, criteria, where, args)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, total, nil
+}
+
+func (r Repository[T, ID]) Find(ctx context.Context, criteria *cr.Criteria) ([]T, error) {
+	if criteria == nil {
+		criteria = cr.New()
+	}
+
+	where, args := r.where(criteria)
+
+	return r.find(ctx, criteria, where, args)
+}
+
+func (r Repository[T, ID]) find(ctx context.Context, criteria *cr.Criteria, where string, args []any) ([]T, error) {
+	var (
+		query strings.Builder
+		data  []T
+		size  int
+	)
+
+	query.WriteString(where)
+
+	if len(criteria.SortBy) > 0 {
+		query.WriteString(" ORDER BY ")
+		query.WriteString(criteria.SortBy.String())
+	}
+
+	if criteria.Size != nil && *criteria.Size > 0 {
+		query.WriteString(" LIMIT ? OFFSET ?")
+
+		size = *criteria.Size
+		args = append(args, size, criteria.GetOffset())
+	}
+
+	rows, err := r.db(ctx).QueryContext(ctx, fmt.Sprintf(selectSQL, r.columns(), r.Table)+query.String(), args...)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	data = make([]T, 0, size)
+	for rows.Next() {
+		var item T
+		if err = r.RowScan(rows, &item); err != nil {
+			return nil, r.error(err)
+		}
+		data = append(data, item)
+	}
+	return slices.Clip(data), nil
+}
+
+// where renders the criteria filter as an SQLite WHERE clause.
+//
+// SQLite has no array parameters, so every slice argument bound to an
+// IN (?) placeholder is expanded into one placeholder per element, an empty
+// one makes the condition constant, and ILIKE is rewritten to LIKE, which is
+// case-insensitive for ASCII.
+func (r Repository[T, ID]) where(criteria *cr.Criteria) (string, []any) {
+	s, args := criteria.Filter.ToSQL()
+	if s == "" {
+		return "", nil
+	}
+
+	var (
+		where strings.Builder
+		index int
+	)
+
+	expanded := make([]any, 0, len(args))
+
+	where.WriteString(" WHERE ")
+	for i := 0; i < len(s); i++ {
+		if i > 0 && (i+5) < len(s) && s[i-1] == ' ' && s[i+5] == ' ' &&
+			strings.EqualFold(s[i:i+5], "ILIKE") {
+			where.WriteString("LIKE")
+			i += 4
+			continue
+		}
+
+		if s[i] == '?' {
+			if index >= len(args) {
+				where.WriteByte(s[i])
+				continue
+			}
+
+			arg := args[index]
+			index++
+
+			if values, ok := toSlice(arg); ok && isInClause(s[:i]) {
+				if len(values) == 0 {
+					head := emptyInClause(where.String())
+					where.Reset()
+					where.WriteString(head)
+					if i+1 < len(s) && s[i+1] == ')' {
+						i++
+					}
+					continue
+				}
+				where.WriteString(strings.TrimSuffix(strings.Repeat("?,", len(values)), ","))
+				for _, value := range values {
+					expanded = append(expanded, bindValue(value))
+				}
+				continue
+			}
+
+			where.WriteByte('?')
+			expanded = append(expanded, bindValue(arg))
+			continue
+		}
+
+		where.WriteByte(s[i])
+	}
+
+	return where.String(), expanded
+}
+
+func (r Repository[T, ID]) FindByID(ctx context.Context, id ID) (T, error) {
+	m, err := r.FindBy(ctx, "id", id)
+	return m, r.error(err)
+}
+
+func (r Repository[T, ID]) FindBy(ctx context.Context, column string, value any) (m T, err error) {
+	query := fmt.Sprintf(selectOneSQL, r.columns(), r.Table, column)
+	row := r.db(ctx).QueryRowContext(ctx, query, value)
+	err = r.error(r.RowScan(row, &m))
+	return
+}
+
+func (r Repository[T, ID]) Delete(ctx context.Context, ids ...ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(deleteSQL, r.Table, strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","))
+
+	_, err := r.db(ctx).ExecContext(ctx, query, args...)
+	return r.error(err)
+}
+
+func (r Repository[T, ID]) Create(ctx context.Context, m *T) error {
+	if m == nil {
+		panic("sql: Create called with nil pointer")
+	}
+
+	data := r.InsertValues(m)
+	columns := make([]string, 0, len(data))
+	values := make([]string, 0, len(data))
+	args := make([]any, 0, len(data))
+
+	for column, value := range data {
+		columns = append(columns, column)
+		args = append(args, value)
+		values = append(values, "?")
+	}
+
+	query := fmt.Sprintf(insertSQL, r.Table, strings.Join(columns, ","), strings.Join(values, ","))
+
+	result, err := r.db(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return r.error(err)
+	}
+
+	var id any
+	if v, ok := data["id"]; ok {
+		id = v
+	} else if id, err = result.LastInsertId(); err != nil {
+		return r.error(err)
+	}
+
+	return r.reload(ctx, id, m)
+}
+
+func (r Repository[T, ID]) Update(ctx context.Context, m *T) error {
+	if m == nil {
+		panic("sql: Update called with nil pointer")
+	}
+
+	data := r.UpdateValues(m)
+	columns := make([]string, 0, len(data))
+	args := make([]any, 0, len(data)+1)
+
+	for column, value := range data {
+		args = append(args, value)
+		columns = append(columns, fmt.Sprintf("%s = ?", column))
+	}
+
+	id := (*m).GetID()
+	args = append(args, id)
+	query := fmt.Sprintf(updateSQL, r.Table, strings.Join(columns, ","))
+
+	result, err := r.db(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return r.error(err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return r.error(sql.ErrNoRows)
+	}
+
+	return r.reload(ctx, id, m)
+}
+
+// reload emulates the RETURNING clause of the PostgreSQL repository
+// by reading the just written row back into the model.
+func (r Repository[T, ID]) reload(ctx context.Context, id any, m *T) error {
+	query := fmt.Sprintf(selectOneSQL, r.columns(), r.Table, "id")
+	row := r.db(ctx).QueryRowContext(ctx, query, id)
+	return r.error(r.RowScan(row, m))
+}
+
+func (r Repository[T, ID]) columns() string {
+	if len(r.SelectColumns) == 0 {
+		return "*"
+	}
+	return strings.Join(r.SelectColumns, ",")
+}
+
+func (r Repository[T, ID]) error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		err = errors.Join(err, repository.ErrNotFound)
+	} else if strings.Contains(err.Error(), uniqueViolation) {
+		err = errors.Join(err, repository.ErrUniqueViolation)
+	}
+	if r.OnError != nil {
+		return r.OnError(err)
+	}
+	return err
+}
+
+func (r Repository[T, ID]) db(ctx context.Context) txDB {
+	var db txDB = r.DB
+	if tx, ok := ctx.Value(ctxTxKey{}).(*sql.Tx); ok {
+		db = tx
+	}
+	return db
+}
+
+func isInClause(s string) bool {
+	s = strings.TrimRight(s, " (")
+	return len(s) >= 3 && strings.EqualFold(s[len(s)-3:], " IN")
+}
+
+// emptyInClause replaces the condition "column IN (" ending the clause written
+// with 1=0, as an empty list matches nothing, and "column NOT IN (" with 1=1.
+func emptyInClause(written string) string {
+	head := strings.TrimRight(written, " (")
+	head = head[:len(head)-len(" IN")]
+
+	cond := "1=0"
+	if len(head) >= 4 && strings.EqualFold(head[len(head)-4:], " NOT") {
+		head = head[:len(head)-4]
+		cond = "1=1"
+	}
+
+	return head[:strings.LastIndexAny(head, " (")+1] + cond
+}
+
+func toSlice(arg any) ([]any, bool) {
+	if arg == nil {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(arg)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
+
+func bindValue(arg any) any {
+	switch v := arg.(type) {
+	case time.Time:
+		return v.UTC()
+	case *time.Time:
+		return utc(v)
+	default:
+		return arg
+	}
+}
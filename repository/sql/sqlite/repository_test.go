@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+)
+
+func TestRepository_Where(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter cr.Filter
+		where  string
+		args   []any
+	}{
+		{
+			name:   "in",
+			filter: cr.Filter{Conditions: []any{cr.Condition{Column: "id", Operator: cr.OpIN, Value: []int64{1, 2}}}},
+			where:  " WHERE id IN (?,?)",
+			args:   []any{int64(1), int64(2)},
+		},
+		{
+			name:   "empty in",
+			filter: cr.Filter{Conditions: []any{cr.Condition{Column: "id", Operator: cr.OpIN, Value: []int64{}}}},
+			where:  " WHERE 1=0",
+			args:   []any{},
+		},
+		{
+			name:   "empty not in",
+			filter: cr.Filter{Conditions: []any{cr.Condition{Column: "id", Operator: "NOT IN", Value: []int64{}}}},
+			where:  " WHERE 1=1",
+			args:   []any{},
+		},
+		{
+			name: "empty not in nested",
+			filter: cr.Filter{Conditions: []any{
+				cr.Condition{Column: "name", Value: "a"},
+				cr.Filter{Operator: cr.OpOR, Conditions: []any{
+					cr.Condition{Column: "id", Operator: "NOT IN", Value: []int64{}},
+					"id IS NULL",
+				}},
+			}},
+			where: " WHERE name = (?) AND (1=1 OR id IS NULL)",
+			args:  []any{"a"},
+		},
+		{
+			name:   "ilike",
+			filter: cr.Filter{Conditions: []any{cr.Condition{Column: "name", Operator: "ILIKE", Value: "%a%"}}},
+			where:  " WHERE name LIKE (?)",
+			args:   []any{"%a%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := Repository[model.Site, int64]{}.where(cr.New().SetFilter(tt.filter))
+			if where != tt.where {
+				t.Fatalf("where = %q, want %q", where, tt.where)
+			}
+			if !slices.Equal(args, tt.args) {
+				t.Fatalf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gowool/cr"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	_ repository.PageRevision     = (*RevisionRepository[model.Page])(nil)
+	_ repository.TemplateRevision = (*RevisionRepository[model.Template])(nil)
+)
+
+// appendRevisionSQL allocates the next version of the record along with the insert.
+const appendRevisionSQL = `INSERT INTO %[1]s (record_id, version, admin_id, data, created)
+SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ?, ? FROM %[1]s WHERE record_id = ?`
+
+// appendRetries is the number of attempts of Append, the parallel appends of a record
+// may allocate the same version and all but one of them fail on the unique index.
+const appendRetries = 5
+
+type RevisionRepository[T any] struct {
+	Repository[model.Revision[T], int64]
+}
+
+func NewPageRevisionRepository(db *sql.DB) *RevisionRepository[model.Page] {
+	return NewRevisionRepository[model.Page](db, "page_revisions")
+}
+
+func NewTemplateRevisionRepository(db *sql.DB) *RevisionRepository[model.Template] {
+	return NewRevisionRepository[model.Template](db, "template_revisions")
+}
+
+func NewRevisionRepository[T any](db *sql.DB, table string) *RevisionRepository[T] {
+	return &RevisionRepository[T]{
+		Repository[model.Revision[T], int64]{
+			DB:            db,
+			Table:         table,
+			SelectColumns: []string{"id", "record_id", "version", "admin_id", "data", "created"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.Revision[T]) error {
+				return row.Scan(&m.ID, &m.RecordID, &m.Version, &m.AdminID, &JSON[T]{V: &m.Data}, &m.Created)
+			},
+			InsertValues: func(m *model.Revision[T]) map[string]any {
+				return map[string]any{
+					"record_id": m.RecordID,
+					"version":   m.Version,
+					"admin_id":  m.AdminID,
+					"data":      JSON[T]{V: &m.Data},
+					"created":   time.Now().UTC(),
+				}
+			},
+			UpdateValues: func(m *model.Revision[T]) map[string]any {
+				return map[string]any{
+					"admin_id": m.AdminID,
+					"data":     JSON[T]{V: &m.Data},
+				}
+			},
+		},
+	}
+}
+
+func (r *RevisionRepository[T]) FindByVersion(ctx context.Context, recordID int64, version int) (model.Revision[T], error) {
+	return r.findOne(ctx, cr.New().SetFilter(cr.Filter{
+		Conditions: []any{
+			cr.Condition{Column: "record_id", Value: recordID},
+			cr.Condition{Column: "version", Value: version},
+		},
+	}))
+}
+
+func (r *RevisionRepository[T]) FindLatest(ctx context.Context, recordID int64) (model.Revision[T], error) {
+	return r.findOne(ctx, cr.New().
+		SetFilter(cr.Filter{Conditions: []any{cr.Condition{Column: "record_id", Value: recordID}}}).
+		SetSortBy(cr.Sort{Column: "version", Order: "DESC"}))
+}
+
+func (r *RevisionRepository[T]) findOne(ctx context.Context, criteria *cr.Criteria) (model.Revision[T], error) {
+	data, err := r.Find(ctx, criteria.SetSize(1))
+	if err != nil {
+		return model.Revision[T]{}, err
+	}
+	if len(data) == 0 {
+		return model.Revision[T]{}, r.error(sql.ErrNoRows)
+	}
+	return data[0], nil
+}
+
+func (r *RevisionRepository[T]) Append(ctx context.Context, m *model.Revision[T]) (err error) {
+	query := fmt.Sprintf(appendRevisionSQL, r.Table)
+
+	for range appendRetries {
+		var result sql.Result
+		result, err = r.db(ctx).ExecContext(ctx, query, m.RecordID, m.AdminID, JSON[T]{V: &m.Data}, time.Now().UTC(), m.RecordID)
+		if err = r.error(err); errors.Is(err, repository.ErrUniqueViolation) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		var id int64
+		if id, err = result.LastInsertId(); err != nil {
+			return r.error(err)
+		}
+		return r.reload(ctx, id, m)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var _ repository.TranslationGroup = (*TranslationGroupRepository)(nil)
+
+type TranslationGroupRepository struct {
+	Repository[model.TranslationGroup, int64]
+}
+
+func NewTranslationGroupRepository(db *sql.DB) *TranslationGroupRepository {
+	return &TranslationGroupRepository{
+		Repository[model.TranslationGroup, int64]{
+			DB:            db,
+			Table:         "translation_groups",
+			SelectColumns: []string{"id", "name", "created", "updated"},
+			RowScan: func(row interface{ Scan(...any) error }, m *model.TranslationGroup) error {
+				return row.Scan(&m.ID, &m.Name, &m.Created, &m.Updated)
+			},
+			InsertValues: func(m *model.TranslationGroup) map[string]any {
+				now := time.Now().UTC()
+				return map[string]any{
+					"name":    m.Name,
+					"created": now,
+					"updated": now,
+				}
+			},
+			UpdateValues: func(m *model.TranslationGroup) map[string]any {
+				return map[string]any{
+					"name":    m.Name,
+					"updated": time.Now().UTC(),
+				}
+			},
+		},
+	}
+}
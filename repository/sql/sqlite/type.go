@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+)
+
+type Metas []model.Meta
+
+func (m *Metas) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal(internal.Bytes(src), m)
+	case []byte:
+		return json.Unmarshal(src, m)
+	default:
+		return errors.New("invalid src type for Metas")
+	}
+}
+
+func (m Metas) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return "[]", nil
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return internal.String(raw), nil
+}
+
+type JSON[T any] struct {
+	V *T
+}
+
+func (j *JSON[T]) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal(internal.Bytes(src), j.V)
+	case []byte:
+		return json.Unmarshal(src, j.V)
+	default:
+		return errors.New("invalid src type for JSON")
+	}
+}
+
+func (j JSON[T]) Value() (driver.Value, error) {
+	raw, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, err
+	}
+	return internal.String(raw), nil
+}
+
+type StrMap map[string]string
+
+func (m *StrMap) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal(internal.Bytes(src), m)
+	case []byte:
+		return json.Unmarshal(src, m)
+	default:
+		return errors.New("invalid src type for StrMap")
+	}
+}
+
+func (m StrMap) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return internal.String(raw), nil
+}
+
+type Role model.Role
+
+func (r *Role) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		*r = Role(model.NewRole(src))
+		return nil
+	case []byte:
+		*r = Role(model.NewRole(string(src)))
+		return nil
+	default:
+		return errors.New("invalid src type for Role")
+	}
+}
+
+func (r *Role) Value() (driver.Value, error) {
+	if r == nil {
+		return model.RoleGuest.String(), nil
+	}
+	return model.Role(*r).String(), nil
+}
+
+type Password model.Password
+
+func (p *Password) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		*p = make(Password, len(src))
+		copy(*p, src)
+		return nil
+	case string:
+		*p = Password(src)
+		return nil
+	default:
+		return errors.New("invalid src type for Password")
+	}
+}
+
+func (p *Password) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, errors.New("password is nil")
+	}
+	dst := make([]byte, len(*p))
+	copy(dst, *p)
+	return dst, nil
+}
+
+type OTP model.OTP
+
+func (otp *OTP) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		copy(otp[:], src)
+		return nil
+	case string:
+		copy(otp[:], src)
+		return nil
+	default:
+		return errors.New("invalid src type for OTP")
+	}
+}
+
+func (otp *OTP) Value() (driver.Value, error) {
+	dst := make([]byte, len(otp))
+	copy(dst, otp[:])
+	return dst, nil
+}
+
+// utc normalizes timestamps before they are written, SQLite compares
+// them as text so every stored value must share the same offset.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
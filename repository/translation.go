@@ -0,0 +1,7 @@
+package repository
+
+import "github.com/gowool/cms/model"
+
+type TranslationGroup interface {
+	repository[model.TranslationGroup, int64]
+}
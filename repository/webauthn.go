@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gowool/cms/model"
+)
+
+type WebAuthnCredential interface {
+	repository[model.WebAuthnCredential, int64]
+	FindByAdminID(ctx context.Context, adminID int64) ([]model.WebAuthnCredential, error)
+	FindByCredentialID(ctx context.Context, credentialID string) (model.WebAuthnCredential, error)
+	// Use records an assertion of the credential unless its sign count stays behind the stored one,
+	// which hints at a cloned authenticator, it reports whether it did. Authenticators without
+	// a counter always report zero.
+	Use(ctx context.Context, id int64, signCount uint32, backupState bool, now time.Time) (bool, error)
+}
+
+type RecoveryCode interface {
+	repository[model.RecoveryCode, int64]
+	FindByAdminID(ctx context.Context, adminID int64) ([]model.RecoveryCode, error)
+	FindByHash(ctx context.Context, hash string) (model.RecoveryCode, error)
+	// Spend marks the unused code of the admin used, it reports whether it did.
+	Spend(ctx context.Context, adminID int64, hash string, now time.Time) (bool, error)
+}
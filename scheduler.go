@@ -0,0 +1,265 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gowool/cr"
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+type ScheduleAction string
+
+const (
+	SchedulePublish ScheduleAction = "publish"
+	ScheduleExpire  ScheduleAction = "expire"
+)
+
+type ScheduleEvent struct {
+	Action ScheduleAction
+	Time   time.Time
+	Site   *model.Site
+	Page   *model.Page
+}
+
+func (e ScheduleEvent) Tags() []string {
+	var tags []string
+	if e.Site != nil {
+		tags = append(tags, SitesTag, SiteTag(e.Site.ID))
+	}
+	if e.Page != nil {
+		tags = append(tags, MenusTag, PageTag(e.Page.ID))
+		if e.Page.ParentID != nil {
+			tags = append(tags, PageTag(*e.Page.ParentID))
+		}
+	}
+	return tags
+}
+
+type ScheduleListener interface {
+	OnSchedule(ctx context.Context, event ScheduleEvent) error
+}
+
+type ScheduleListenerFunc func(ctx context.Context, event ScheduleEvent) error
+
+func (f ScheduleListenerFunc) OnSchedule(ctx context.Context, event ScheduleEvent) error {
+	return f(ctx, event)
+}
+
+type Scheduler interface {
+	Start(context.Context) error
+	Stop(context.Context) error
+}
+
+// schedulerLastKey keeps the time of the last run in the cache, so the changes
+// scheduled while no instance was running are handled on start. The cached
+// entries outlive it only if they were set after it, i.e. by a running scheduler.
+const schedulerLastKey = "cms::scheduler:last"
+
+// DefaultScheduler periodically looks for sites and pages whose published or
+// expired time has been crossed since the previous run, drops the cached
+// entries depending on them and notifies the listeners.
+type DefaultScheduler struct {
+	siteRepository repository.Site
+	pageRepository repository.Page
+	cache          Cache
+	listeners      []ScheduleListener
+	interval       time.Duration
+	logger         *zap.Logger
+
+	mu     sync.Mutex
+	last   time.Time
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewDefaultScheduler(
+	siteRepository repository.Site,
+	pageRepository repository.Page,
+	cache Cache,
+	interval time.Duration,
+	logger *zap.Logger,
+	listeners ...ScheduleListener,
+) *DefaultScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &DefaultScheduler{
+		siteRepository: siteRepository,
+		pageRepository: pageRepository,
+		cache:          cache,
+		listeners:      listeners,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (s *DefaultScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return nil
+	}
+
+	if err := s.cache.Get(ctx, schedulerLastKey, &s.last); err != nil {
+		s.last = time.Now().Truncate(time.Minute)
+	}
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.loop(loopCtx, s.done)
+
+	return nil
+}
+
+func (s *DefaultScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *DefaultScheduler) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Run(ctx, now); err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("scheduler run failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Run handles everything scheduled after the previous run and up to now,
+// times are truncated to a minute the same way Site.IsEnabled and
+// Page.IsEnabled do.
+func (s *DefaultScheduler) Run(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.Truncate(time.Minute)
+	if !now.After(s.last) {
+		return nil
+	}
+
+	events, err := s.events(ctx, s.last, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, event := range events {
+		for _, tag := range event.Tags() {
+			if err = s.cache.DelByTag(ctx, tag); err != nil {
+				errs = append(errs, fmt.Errorf("scheduler: invalidate tag %q: %w", tag, err))
+			}
+		}
+		for _, listener := range s.listeners {
+			if err = listener.OnSchedule(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		fields := []zap.Field{zap.String("action", string(event.Action)), zap.Time("time", event.Time)}
+		if event.Site != nil {
+			fields = append(fields, zap.Int64("site_id", event.Site.ID))
+		}
+		if event.Page != nil {
+			fields = append(fields, zap.Int64("page_id", event.Page.ID))
+		}
+		s.logger.Info("scheduled change", fields...)
+	}
+
+	s.last = now
+	if err = s.cache.Set(ctx, schedulerLastKey, now); err != nil {
+		errs = append(errs, fmt.Errorf("scheduler: save last run: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *DefaultScheduler) events(ctx context.Context, from, to time.Time) ([]ScheduleEvent, error) {
+	criteria := cr.New().SetFilter(scheduleFilter(from, to))
+
+	sites, err := s.siteRepository.Find(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := s.pageRepository.Find(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ScheduleEvent, 0, len(sites)+len(pages))
+	for _, site := range sites {
+		for _, action := range scheduleActions(site.Published, site.Expired, from, to) {
+			events = append(events, ScheduleEvent{Action: action, Time: to, Site: &site})
+		}
+	}
+	for _, page := range pages {
+		for _, action := range scheduleActions(page.Published, page.Expired, from, to) {
+			events = append(events, ScheduleEvent{Action: action, Time: to, Page: &page})
+		}
+	}
+	return events, nil
+}
+
+func scheduleFilter(from, to time.Time) cr.Filter {
+	between := func(column string) cr.Filter {
+		return cr.Filter{
+			Conditions: []any{
+				cr.Condition{Column: column, Operator: cr.OpGt, Value: from},
+				cr.Condition{Column: column, Operator: cr.OpLte, Value: to},
+			},
+		}
+	}
+
+	return cr.Filter{
+		Operator:   cr.OpOR,
+		Conditions: []any{between("published"), between("expired")},
+	}
+}
+
+func scheduleActions(published, expired *time.Time, from, to time.Time) []ScheduleAction {
+	crossed := func(t *time.Time) bool {
+		return t != nil && t.After(from) && !t.After(to)
+	}
+
+	var actions []ScheduleAction
+	if crossed(published) {
+		actions = append(actions, SchedulePublish)
+	}
+	if crossed(expired) {
+		actions = append(actions, ScheduleExpire)
+	}
+	return actions
+}
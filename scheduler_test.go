@@ -0,0 +1,80 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+func TestDefaultScheduler_ResumesFromLastRun(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(100, 0, nil)
+
+	now := time.Now().Truncate(time.Minute)
+	handled, published := now.Add(30*time.Minute), now.Add(90*time.Minute)
+
+	pages := memory.NewPageRepository()
+	for _, p := range []model.Page{
+		{SiteID: 1, Name: "handled", Pattern: "/handled", Template: "page", Published: &handled},
+		{SiteID: 1, Name: "launch", Pattern: "/launch", Template: "page", Published: &published},
+	} {
+		if err := pages.Create(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	page, err := pages.FindBy(ctx, "name", "launch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []cms.ScheduleEvent
+	listener := cms.ScheduleListenerFunc(func(_ context.Context, event cms.ScheduleEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	// the previous instance handled the first page and stopped before the second one was published
+	first := cms.NewDefaultScheduler(memory.NewSiteRepository(), pages, c, time.Hour, zap.NewNop())
+	if err := first.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Run(ctx, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = c.Set(ctx, "menu", "stale", cms.MenusTag)
+	_ = c.Set(ctx, "children", "stale", cms.PageTag(page.ID))
+
+	second := cms.NewDefaultScheduler(memory.NewSiteRepository(), pages, c, time.Hour, zap.NewNop(), listener)
+	if err := second.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = second.Stop(ctx) }()
+
+	if err := second.Run(ctx, now.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second instance resumes from the last run of the first one, not from its own start
+	if len(events) != 1 || events[0].Action != cms.SchedulePublish || events[0].Page == nil || events[0].Page.ID != page.ID {
+		t.Fatalf("events = %+v, want the publish of page %d", events, page.ID)
+	}
+
+	var v string
+	for _, key := range []string{"menu", "children"} {
+		if err := c.Get(ctx, key, &v); !errors.Is(err, cache.ErrMiss) {
+			t.Errorf("%s: err = %v, want a miss", key, err)
+		}
+	}
+}
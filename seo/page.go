@@ -124,6 +124,13 @@ func (s *pageSEO) Page(page *model.Page) SEO {
 		}
 	}
 
+	for _, t := range page.Translations {
+		if t.Site == nil || t.Site.Locale == "" || t.URL == "" || t.IsDynamic() {
+			continue
+		}
+		s.AddLangAlternate(strings.TrimRight(t.Site.URL(), "/")+t.URL, model.Hreflang(t.Site.Locale))
+	}
+
 	return s.setMetas(page.Metas)
 }
 
@@ -0,0 +1,102 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Storage keeps the binary content of media objects addressed by slash separated keys.
+// Implementations report a missing object with an error matching fs.ErrNotExist.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, keys ...string) error
+	// DeletePrefix removes every object whose key starts with the prefix directory.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+var _ Storage = (*LocalStorage)(nil)
+
+type LocalStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) *LocalStorage {
+	if dir == "" {
+		panic("storage directory is not specified")
+	}
+	return &LocalStorage{dir: dir}
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, r io.Reader) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(f.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+func (s *LocalStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(name)
+}
+
+func (s *LocalStorage) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		name, err := s.path(key)
+		if err != nil {
+			return err
+		}
+		if err = os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LocalStorage) DeletePrefix(_ context.Context, prefix string) error {
+	name, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(name)
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	key = path.Clean("/" + key)[1:]
+	if key == "" {
+		return "", &fs.PathError{Op: "open", Path: key, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
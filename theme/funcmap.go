@@ -28,21 +28,56 @@ var (
 
 type FuncMap struct {
 	pageRepo    repository.Page
+	searchRepo  repository.Search
+	blockRepo   repository.Block
+	blockTypes  cms.BlockTypes
+	media       *cms.MediaService
 	menuService cms.Menu
 	matcher     cms.Matcher
 }
 
-func NewFuncMap(pageRepo repository.Page, menu cms.Menu, matcher cms.Matcher) *FuncMap {
+type FuncMapConfig struct {
+	PageRepository repository.Page
+	Menu           cms.Menu
+	Matcher        cms.Matcher
+	// SearchRepository backs the search func. Optional.
+	SearchRepository repository.Search
+	// BlockRepository backs the render_blocks func. Optional.
+	BlockRepository repository.Block
+	// BlockTypes renders the blocks, the default block types without it. Optional.
+	BlockTypes cms.BlockTypes
+	// MediaService backs the media_url func. Optional.
+	MediaService *cms.MediaService
+}
+
+func NewFuncMap(cfg FuncMapConfig) *FuncMap {
+	if cfg.PageRepository == nil {
+		panic("page repository is not specified")
+	}
+	if cfg.Menu == nil {
+		panic("menu is not specified")
+	}
+	if cfg.Matcher == nil {
+		panic("matcher is not specified")
+	}
+	if cfg.BlockTypes == nil {
+		cfg.BlockTypes = cms.NewDefaultBlockTypes()
+	}
 	return &FuncMap{
-		pageRepo:    pageRepo,
-		menuService: menu,
-		matcher:     matcher,
+		pageRepo:    cfg.PageRepository,
+		searchRepo:  cfg.SearchRepository,
+		blockRepo:   cfg.BlockRepository,
+		blockTypes:  cfg.BlockTypes,
+		media:       cfg.MediaService,
+		menuService: cfg.Menu,
+		matcher:     cfg.Matcher,
 	}
 }
 
 func (fm *FuncMap) FuncMap(t theme.Theme) template.FuncMap {
 	return template.FuncMap{
 		"menu":              fm.menu(t),
+		"render_blocks":     fm.renderBlocks(t),
 		"node_is_current":   fm.matcher.IsCurrent,
 		"node_is_ancestor":  fm.matcher.IsAncestor,
 		"strip_tags":        stripTags,
@@ -57,15 +92,21 @@ func (fm *FuncMap) FuncMap(t theme.Theme) template.FuncMap {
 		"lang_alternates":   langAlternates,
 		"oembed_links":      oEmbedLinks,
 		"page_url":          fm.pageURL,
+		"media_url":         fm.mediaURL,
 		"page_by_id":        fm.findPage,
 		"page_children":     fm.pageChildren,
+		"page_translations": pageTranslations,
 		"pages_by_criteria": fm.pagesByCriteria,
+		"search":            fm.search,
 		"js": func(str string) template.JS {
 			return template.JS(str)
 		},
 		"css": func(str string) template.CSS {
 			return template.CSS(str)
 		},
+		"safe_html": func(str string) template.HTML {
+			return template.HTML(str)
+		},
 	}
 }
 
@@ -87,6 +128,54 @@ func (fm *FuncMap) menu(t theme.Theme) func(context.Context, string, string, map
 	}
 }
 
+// renderBlocks renders the blocks of the region of the current page in order,
+// blocks of unknown types and blocks failed to render are skipped.
+func (fm *FuncMap) renderBlocks(t theme.Theme) func(context.Context, string) template.HTML {
+	return func(ctx context.Context, region string) template.HTML {
+		site := cms.CtxSite(ctx)
+		page := cms.CtxPage(ctx)
+		if site == nil || page == nil || fm.blockRepo == nil {
+			return ""
+		}
+
+		blocks, err := fm.blockRepo.FindByPage(ctx, site.ID, page.ID)
+		if err != nil {
+			return ""
+		}
+
+		var b strings.Builder
+		for _, block := range model.BlockTree(blocks, region) {
+			b.WriteString(fm.renderBlock(ctx, t, block, site, page))
+		}
+		return template.HTML(b.String())
+	}
+}
+
+func (fm *FuncMap) renderBlock(ctx context.Context, t theme.Theme, block model.Block, site *model.Site, page *model.Page) string {
+	blockType, ok := fm.blockTypes.Get(block.Type)
+	if !ok {
+		return ""
+	}
+
+	var children strings.Builder
+	for _, child := range block.Children {
+		children.WriteString(fm.renderBlock(ctx, t, child, site, page))
+	}
+
+	str, err := t.HTML(ctx, blockType.Template, map[string]any{
+		"ctx":      ctx,
+		"site":     site,
+		"page":     page,
+		"block":    block,
+		"settings": block.Settings,
+		"children": template.HTML(children.String()),
+	})
+	if err != nil {
+		return ""
+	}
+	return str
+}
+
 func (fm *FuncMap) pageURL(ctx context.Context, name any, args ...any) string {
 	switch name := name.(type) {
 	case string:
@@ -104,6 +193,52 @@ func (fm *FuncMap) pageURL(ctx context.Context, name any, args ...any) string {
 	return ""
 }
 
+// mediaURL returns the address of a media object given as model.Media, its key or its ID,
+// args are pairs of the derivative options w, h and fit.
+func (fm *FuncMap) mediaURL(ctx context.Context, media any, args ...any) string {
+	if fm.media == nil {
+		return ""
+	}
+
+	var key string
+	switch media := media.(type) {
+	case model.Media:
+		key = media.Key
+	case *model.Media:
+		if media != nil {
+			key = media.Key
+		}
+	case string:
+		key = media
+	default:
+		id, err := cast.ToInt64E(media)
+		if err != nil {
+			return ""
+		}
+		m, err := fm.media.FindByID(ctx, id)
+		if err != nil {
+			return ""
+		}
+		key = m.Key
+	}
+	if key == "" {
+		return ""
+	}
+
+	var opts cms.MediaOptions
+	for i := 0; i+1 < len(args); i += 2 {
+		switch args[i] {
+		case "w", "width":
+			opts.Width = cast.ToInt(args[i+1])
+		case "h", "height":
+			opts.Height = cast.ToInt(args[i+1])
+		case "fit":
+			opts.Fit = cast.ToString(args[i+1])
+		}
+	}
+	return fm.media.URL(key, opts)
+}
+
 func (fm *FuncMap) pageURLByAlias(ctx context.Context, alias string, args ...any) string {
 	site := cms.CtxSite(ctx)
 	if site == nil {
@@ -182,11 +317,71 @@ func (fm *FuncMap) pageChildren(ctx context.Context, parentID int64, now time.Ti
 	return pages
 }
 
+// pageTranslations lists the current page and its translations for a language switcher,
+// translations without an addressable URL are skipped.
+func pageTranslations(ctx context.Context) []model.PageTranslation {
+	page := cms.CtxPage(ctx)
+	if page == nil {
+		return nil
+	}
+
+	translations := make([]model.PageTranslation, 0, len(page.Translations))
+	for _, t := range page.Translations {
+		current := t.ID == page.ID
+		if t.Site == nil || (!current && (t.URL == "" || t.IsDynamic())) {
+			continue
+		}
+
+		u := strings.TrimRight(t.Site.URL(), "/") + t.URL
+		if current {
+			requestURL := cms.CtxURL(ctx)
+			u = strings.TrimRight(t.Site.URL(), "/") + requestURL.Path
+		}
+
+		translations = append(translations, model.PageTranslation{
+			Locale:  t.Site.Locale,
+			URL:     u,
+			Site:    t.Site,
+			Page:    &t,
+			Current: current,
+		})
+	}
+	return translations
+}
+
 func (fm *FuncMap) pagesByCriteria(ctx context.Context, criteria *cr.Criteria) map[string]any {
 	pages, total, _ := fm.pageRepo.FindAndCount(ctx, criteria)
 	return map[string]any{"pages": pages, "total": total}
 }
 
+// search looks for the published pages of the current site and locale,
+// page starts from 1.
+func (fm *FuncMap) search(ctx context.Context, text string, page, limit int) map[string]any {
+	result := map[string]any{"hits": []model.SearchHit{}, "total": 0, "page": max(page, 1), "limit": limit, "pages": 0}
+
+	site := cms.CtxSite(ctx)
+	if site == nil || fm.searchRepo == nil || limit <= 0 {
+		return result
+	}
+
+	hits, total, err := fm.searchRepo.Search(ctx, model.SearchQuery{
+		SiteID: site.ID,
+		Locale: site.Locale,
+		Text:   text,
+		Now:    time.Now(),
+		Offset: (max(page, 1) - 1) * limit,
+		Limit:  limit,
+	})
+	if err != nil {
+		return result
+	}
+
+	result["hits"] = hits
+	result["total"] = total
+	result["pages"] = (total + limit - 1) / limit
+	return result
+}
+
 func titleTag(seo seo.SEO, args ...string) template.HTML {
 	return template.HTML(
 		fmt.Sprintf(
@@ -0,0 +1,185 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrThrottled = errors.New("too many attempts")
+
+// ThrottledError reports the time left until the next attempt is allowed.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrThrottled, e.RetryAfter)
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// RetryAfterHeader returns the value of the Retry-After header in whole seconds.
+func (e *ThrottledError) RetryAfterHeader() string {
+	return strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// ThrottleEmailKey and ThrottleIPKey keep the attempts of an account apart from the attempts of an address.
+func ThrottleEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ThrottleIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+type attempts struct {
+	Failures int       `json:"failures"`
+	Last     time.Time `json:"last"`
+	Locked   time.Time `json:"locked"`
+}
+
+// Throttle slows down the failed attempts of a key exponentially and locks the key out
+// after too many of them. The state is kept in the cache, so it is shared by the instances
+// using the same cache. An attempt reserves its keys until it is done, so the parallel attempts
+// of a key are throttled instead of passing the check before any failure is counted, the reservation
+// is atomic across the instances when the cache implements CacheCounter.
+type Throttle struct {
+	cache   Cache
+	counter CacheCounter
+
+	// BaseDelay is the delay after the first failure, it doubles with every next one up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxFailures is the number of failures locking the key out for Lockout.
+	MaxFailures int
+	Lockout     time.Duration
+	// Window is the time after the last failure the failures are forgotten.
+	Window time.Duration
+	// Timeout limits the reservation of the keys by an attempt never done, e.g. of a crashed instance.
+	Timeout time.Duration
+}
+
+func NewThrottle(cache Cache) *Throttle {
+	if cache == nil {
+		panic("cache is not specified")
+	}
+	return &Throttle{
+		cache:       cache,
+		counter:     counterOf(cache),
+		BaseDelay:   time.Second,
+		MaxDelay:    time.Minute,
+		MaxFailures: 5,
+		Lockout:     15 * time.Minute,
+		Window:      time.Hour,
+		Timeout:     30 * time.Second,
+	}
+}
+
+// Allow returns a ThrottledError when any of the keys has to wait or is reserved by a parallel attempt,
+// otherwise it reserves the keys until Done is called.
+func (t *Throttle) Allow(ctx context.Context, keys ...string) error {
+	for i, key := range keys {
+		n, err := t.counter.Incr(WithCacheTTL(ctx, t.Timeout), t.reservationKey(key), 1)
+		if err != nil || n > 1 {
+			// the reservations of a parallel attempt are not released here
+			t.Done(ctx, keys[:i]...)
+			if err != nil {
+				return err
+			}
+			return &ThrottledError{RetryAfter: t.BaseDelay}
+		}
+	}
+
+	// the state is read once the keys are reserved, so it includes the failures of the former attempts
+	now := time.Now()
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		if wait := t.wait(t.load(ctx, key, now), now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		t.Done(ctx, keys...)
+		return &ThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Done releases the keys reserved by Allow.
+func (t *Throttle) Done(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		_ = t.cache.DelByKey(ctx, t.reservationKey(key))
+	}
+}
+
+// Fail counts a failed attempt of the keys and reports whether any of them got locked out.
+func (t *Throttle) Fail(ctx context.Context, keys ...string) (locked bool, err error) {
+	now := time.Now()
+
+	var errs []error
+	for _, key := range keys {
+		a := t.load(ctx, key, now)
+		a.Failures++
+		a.Last = now
+
+		if t.MaxFailures > 0 && a.Failures >= t.MaxFailures {
+			a.Failures = 0
+			a.Locked = now.Add(t.Lockout)
+			locked = true
+		}
+
+		errs = append(errs, t.cache.Set(WithCacheTTL(ctx, t.Window+t.Lockout), t.key(key), a))
+	}
+	return locked, errors.Join(errs...)
+}
+
+// Reset forgets the failures of the keys.
+func (t *Throttle) Reset(ctx context.Context, keys ...string) error {
+	var errs []error
+	for _, key := range keys {
+		errs = append(errs, t.cache.DelByKey(ctx, t.key(key)))
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Throttle) load(ctx context.Context, key string, now time.Time) (a attempts) {
+	if err := t.cache.Get(ctx, t.key(key), &a); err != nil {
+		return attempts{}
+	}
+	// the caches keeping no expiry keep a stale state, it is dropped on read
+	if now.After(a.Locked) && now.Sub(a.Last) > t.Window {
+		return attempts{}
+	}
+	return a
+}
+
+func (t *Throttle) wait(a attempts, now time.Time) time.Duration {
+	if now.Before(a.Locked) {
+		return a.Locked.Sub(now)
+	}
+	if a.Failures == 0 {
+		return 0
+	}
+
+	delay := t.BaseDelay
+	for i := 1; i < a.Failures && delay < t.MaxDelay; i++ {
+		delay *= 2
+	}
+	return max(a.Last.Add(min(delay, t.MaxDelay)).Sub(now), 0)
+}
+
+func (t *Throttle) key(key string) string {
+	return "cms::throttle:" + key
+}
+
+func (t *Throttle) reservationKey(key string) string {
+	return "cms::throttle:reservation:" + key
+}
@@ -0,0 +1,98 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+)
+
+func TestThrottle_ParallelAttempts(t *testing.T) {
+	ctx := context.Background()
+	throttle := cms.NewThrottle(cache.NewLRU(100, 0, nil))
+
+	keys := []string{cms.ThrottleEmailKey("admin@example.com"), cms.ThrottleIPKey("127.0.0.1")}
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+		start   = make(chan struct{})
+	)
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := throttle.Allow(ctx, keys...); err == nil {
+				allowed.Add(1)
+			} else if !errors.Is(err, cms.ErrThrottled) {
+				t.Error(err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if n := allowed.Load(); n != 1 {
+		t.Fatalf("%d parallel attempts allowed, want 1", n)
+	}
+}
+
+func TestThrottle_FailDelaysNextAttempt(t *testing.T) {
+	ctx := context.Background()
+	throttle := cms.NewThrottle(cache.NewLRU(100, 0, nil))
+	throttle.BaseDelay, throttle.MaxDelay = time.Hour, time.Hour
+
+	key := cms.ThrottleEmailKey("admin@example.com")
+
+	if err := throttle.Allow(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := throttle.Fail(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	throttle.Done(ctx, key)
+
+	var throttled *cms.ThrottledError
+	if err := throttle.Allow(ctx, key); !errors.As(err, &throttled) || throttled.RetryAfter <= time.Minute {
+		t.Fatalf("got %v, want a throttled error of about an hour", err)
+	}
+
+	if err := throttle.Reset(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.Allow(ctx, key); err != nil {
+		t.Fatalf("got %v after reset", err)
+	}
+}
+
+func TestThrottle_Lockout(t *testing.T) {
+	ctx := context.Background()
+	throttle := cms.NewThrottle(cache.NewLRU(100, 0, nil))
+	throttle.BaseDelay = 0
+	throttle.MaxFailures = 3
+
+	key := cms.ThrottleIPKey("127.0.0.1")
+	for i := range throttle.MaxFailures {
+		if err := throttle.Allow(ctx, key); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+		locked, err := throttle.Fail(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		throttle.Done(ctx, key)
+		if locked != (i == throttle.MaxFailures-1) {
+			t.Fatalf("attempt %d: locked %v", i+1, locked)
+		}
+	}
+
+	if err := throttle.Allow(ctx, key); !errors.Is(err, cms.ErrThrottled) {
+		t.Fatalf("got %v, want %v", err, cms.ErrThrottled)
+	}
+}
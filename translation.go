@@ -0,0 +1,152 @@
+package cms
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var ErrTranslationSiteConflict = errors.New("translation group already has a page of the site")
+
+type TranslationService struct {
+	groups repository.TranslationGroup
+	pages  repository.Page
+	sites  repository.Site
+}
+
+func NewTranslationService(groups repository.TranslationGroup, pages repository.Page, sites repository.Site) *TranslationService {
+	if groups == nil {
+		panic("translation group repository is not specified")
+	}
+	if pages == nil {
+		panic("page repository is not specified")
+	}
+	if sites == nil {
+		panic("site repository is not specified")
+	}
+	return &TranslationService{groups: groups, pages: pages, sites: sites}
+}
+
+// Pages returns the pages of the group regardless of their lifespan.
+func (s *TranslationService) Pages(ctx context.Context, groupID int64) ([]model.Page, error) {
+	if _, err := s.groups.FindByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	return s.pages.FindByTranslationGroupID(ctx, groupID, time.Time{})
+}
+
+// SetPages makes the pages the only members of the group, a group holds at most one page of a site.
+func (s *TranslationService) SetPages(ctx context.Context, groupID int64, pageIDs ...int64) error {
+	if _, err := s.groups.FindByID(ctx, groupID); err != nil {
+		return err
+	}
+
+	members, err := s.pages.FindByTranslationGroupID(ctx, groupID, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	pages := make([]model.Page, 0, len(pageIDs))
+	for _, id := range pageIDs {
+		page, err := s.pages.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(pages, func(p model.Page) bool { return p.SiteID == page.SiteID && p.ID != page.ID }) {
+			return ErrTranslationSiteConflict
+		}
+		pages = append(pages, page)
+	}
+
+	for _, member := range members {
+		if slices.Contains(pageIDs, member.ID) {
+			continue
+		}
+		member.TranslationGroupID = nil
+		if err = s.pages.Update(ctx, &member); err != nil {
+			return err
+		}
+	}
+
+	for _, page := range pages {
+		if page.TranslationGroupID != nil && *page.TranslationGroupID == groupID {
+			continue
+		}
+		page.TranslationGroupID = &groupID
+		if err = s.pages.Update(ctx, &page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete detaches the pages of the groups and deletes the groups.
+func (s *TranslationService) Delete(ctx context.Context, ids ...int64) error {
+	for _, id := range ids {
+		members, err := s.pages.FindByTranslationGroupID(ctx, id, time.Time{})
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			member.TranslationGroupID = nil
+			if err = s.pages.Update(ctx, &member); err != nil {
+				return err
+			}
+		}
+	}
+	return s.groups.Delete(ctx, ids...)
+}
+
+// Translations returns the enabled pages of the translation group of the page, the page itself included,
+// each with its site. Sites sharing the host are addressed like the current site. Nothing is returned
+// unless the page has at least one translation.
+func (s *TranslationService) Translations(ctx context.Context, page *model.Page, site *model.Site, scheme string, now time.Time) ([]model.Page, error) {
+	if page == nil || page.TranslationGroupID == nil {
+		return nil, nil
+	}
+
+	pages, err := s.pages.FindByTranslationGroupID(ctx, *page.TranslationGroupID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make([]model.Page, 0, len(pages))
+	for _, p := range pages {
+		if p.ID == page.ID {
+			p = *page
+			p.Site = site
+			p.Translations = nil
+			translations = append(translations, p)
+			continue
+		}
+
+		item, err := s.sites.FindByID(ctx, p.SiteID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrSiteNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if !item.IsEnabled(now) {
+			continue
+		}
+
+		host := item.Host
+		if item.IsLocalhost() && site != nil {
+			host = site.Host
+		}
+		item = item.WithHost(scheme, host)
+
+		p.Site = &item
+		translations = append(translations, p)
+	}
+
+	if len(translations) < 2 {
+		return nil, nil
+	}
+	return translations, nil
+}
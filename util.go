@@ -1,6 +1,9 @@
 package cms
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"mime"
 	"net"
 	"net/http"
@@ -141,3 +144,18 @@ func getLocale(r *http.Request, fallback string) string {
 func RandomString(length int) string {
 	return internal.RandomString(length)
 }
+
+// randomToken returns size random bytes from the system source encoded as hex.
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of a high-entropy secret, which is enough to store it.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,285 @@
+package cms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/gowool/cms/internal"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository"
+)
+
+var (
+	ErrWebAuthnCeremony      = errors.New("webauthn: ceremony not found")
+	ErrWebAuthnNoCredentials = errors.New("webauthn: no credentials")
+	ErrWebAuthnCloned        = errors.New("webauthn: credential may be cloned")
+)
+
+var _ webauthn.User = webAuthnUser{}
+
+type webAuthnUser struct {
+	admin       model.Admin
+	credentials []model.WebAuthnCredential
+}
+
+// WebAuthnID is the id of the admin, the email may change.
+func (u webAuthnUser) WebAuthnID() []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(u.admin.ID))
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	return u.admin.Email
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	return u.admin.Email
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return internal.Map(u.credentials, func(m model.WebAuthnCredential) webauthn.Credential {
+		id, _ := base64.RawURLEncoding.DecodeString(m.CredentialID)
+		return webauthn.Credential{
+			ID:              id,
+			PublicKey:       m.PublicKey,
+			AttestationType: m.AttestationType,
+			Transport:       internal.Map(m.Transports, func(t string) protocol.AuthenticatorTransport { return protocol.AuthenticatorTransport(t) }),
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: m.BackupEligible,
+				BackupState:    m.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    m.AAGUID,
+				SignCount: m.SignCount,
+			},
+		}
+	})
+}
+
+// WebAuthnService registers the passkeys and the security keys of the admins and verifies
+// their assertions, the state of a ceremony is kept in the cache between its two steps.
+type WebAuthnService struct {
+	webAuthn *webauthn.WebAuthn
+	repo     repository.WebAuthnCredential
+	cache    Cache
+	counter  CacheCounter
+}
+
+func NewWebAuthnService(cfg *webauthn.Config, repo repository.WebAuthnCredential, cache Cache) (*WebAuthnService, error) {
+	if repo == nil {
+		panic("webauthn credential repository is not specified")
+	}
+	if cache == nil {
+		panic("cache is not specified")
+	}
+
+	w, err := webauthn.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &WebAuthnService{webAuthn: w, repo: repo, cache: cache, counter: counterOf(cache)}, nil
+}
+
+func (s *WebAuthnService) Credentials(ctx context.Context, adminID int64) ([]model.WebAuthnCredential, error) {
+	return s.repo.FindByAdminID(ctx, adminID)
+}
+
+// Delete removes the credentials of the admin, the credentials of other admins are left intact.
+func (s *WebAuthnService) Delete(ctx context.Context, adminID int64, ids ...int64) error {
+	var own []int64
+	for _, id := range ids {
+		m, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if m.AdminID == adminID {
+			own = append(own, id)
+		}
+	}
+	if len(own) == 0 {
+		return repository.ErrNotFound
+	}
+	return s.repo.Delete(ctx, own...)
+}
+
+// BeginRegistration returns the options of the credential creation for the browser.
+func (s *WebAuthnService) BeginRegistration(ctx context.Context, admin model.Admin) (*protocol.CredentialCreation, error) {
+	user, err := s.user(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions := internal.Map(user.WebAuthnCredentials(), func(c webauthn.Credential) protocol.CredentialDescriptor {
+		return c.Descriptor()
+	})
+
+	creation, session, err := s.webAuthn.BeginRegistration(user, webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return nil, err
+	}
+	if err = s.setSession(ctx, "registration", admin.ID, session, s.webAuthn.Config.Timeouts.Registration.Timeout); err != nil {
+		return nil, err
+	}
+	return creation, nil
+}
+
+// FinishRegistration verifies the created credential and stores it under the name.
+func (s *WebAuthnService) FinishRegistration(ctx context.Context, admin model.Admin, name string, response []byte) (model.WebAuthnCredential, error) {
+	session, err := s.session(ctx, "registration", admin.ID, s.webAuthn.Config.Timeouts.Registration.Timeout)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	user, err := s.user(ctx, admin)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	credential, err := s.webAuthn.CreateCredential(user, session, parsed)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	m := model.WebAuthnCredential{
+		AdminID:         admin.ID,
+		Name:            name,
+		CredentialID:    base64.RawURLEncoding.EncodeToString(credential.ID),
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		Transports:      internal.Map(credential.Transport, func(t protocol.AuthenticatorTransport) string { return string(t) }),
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+	if err = s.repo.Create(ctx, &m); err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+	return m, nil
+}
+
+// BeginLogin returns the options of the assertion for the browser.
+func (s *WebAuthnService) BeginLogin(ctx context.Context, admin model.Admin) (*protocol.CredentialAssertion, error) {
+	user, err := s.user(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.credentials) == 0 {
+		return nil, ErrWebAuthnNoCredentials
+	}
+
+	assertion, session, err := s.webAuthn.BeginLogin(user)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.setSession(ctx, "login", admin.ID, session, s.webAuthn.Config.Timeouts.Login.Timeout); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+// FinishLogin verifies the assertion and returns the credential it was made with.
+func (s *WebAuthnService) FinishLogin(ctx context.Context, admin model.Admin, response []byte) (model.WebAuthnCredential, error) {
+	session, err := s.session(ctx, "login", admin.ID, s.webAuthn.Config.Timeouts.Login.Timeout)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	user, err := s.user(ctx, admin)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(user, session, parsed)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+	if credential.Authenticator.CloneWarning {
+		return model.WebAuthnCredential{}, ErrWebAuthnCloned
+	}
+
+	m, err := s.repo.FindByCredentialID(ctx, base64.RawURLEncoding.EncodeToString(credential.ID))
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+
+	// the sign count is checked again by the update, the parallel assertions of
+	// a cloned authenticator pass the check of the library with the same count
+	now := time.Now()
+	used, err := s.repo.Use(ctx, m.ID, credential.Authenticator.SignCount, credential.Flags.BackupState, now)
+	if err != nil {
+		return model.WebAuthnCredential{}, err
+	}
+	if !used {
+		return model.WebAuthnCredential{}, ErrWebAuthnCloned
+	}
+
+	m.SignCount = credential.Authenticator.SignCount
+	m.BackupState = credential.Flags.BackupState
+	m.LastUsed = &now
+	return m, nil
+}
+
+func (s *WebAuthnService) user(ctx context.Context, admin model.Admin) (webAuthnUser, error) {
+	credentials, err := s.repo.FindByAdminID(ctx, admin.ID)
+	if err != nil {
+		return webAuthnUser{}, err
+	}
+	return webAuthnUser{admin: admin, credentials: credentials}, nil
+}
+
+// setSession keeps the state of the ceremony until it expires, the timeout of the ceremony
+// limits the state the library does not set the expiry of.
+func (s *WebAuthnService) setSession(ctx context.Context, ceremony string, adminID int64, session *webauthn.SessionData, timeout time.Duration) error {
+	if !session.Expires.IsZero() {
+		timeout = time.Until(session.Expires)
+	}
+	return s.cache.Set(WithCacheTTL(ctx, timeout), s.key(ceremony, adminID), session)
+}
+
+// session takes the state of the ceremony out of the cache, so it cannot be replayed.
+// The state is claimed atomically by its challenge, the parallel finishes of a ceremony
+// read the same state but only the first one claims it.
+func (s *WebAuthnService) session(ctx context.Context, ceremony string, adminID int64, timeout time.Duration) (session webauthn.SessionData, err error) {
+	key := s.key(ceremony, adminID)
+	if err = s.cache.Get(ctx, key, &session); err != nil {
+		return session, errors.Join(ErrWebAuthnCeremony, err)
+	}
+
+	if !session.Expires.IsZero() {
+		timeout = time.Until(session.Expires)
+	}
+	claimed, err := s.counter.Incr(WithCacheTTL(ctx, timeout), s.key(ceremony+":claim:"+session.Challenge, adminID), 1)
+	if err != nil {
+		return session, err
+	}
+	if claimed > 1 {
+		return session, ErrWebAuthnCeremony
+	}
+
+	_ = s.cache.DelByKey(ctx, key)
+	return session, nil
+}
+
+func (s *WebAuthnService) key(ceremony string, adminID int64) string {
+	return fmt.Sprintf("cms::webauthn:%s:%d", ceremony, adminID)
+}
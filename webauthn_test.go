@@ -0,0 +1,281 @@
+package cms_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/gowool/cms"
+	"github.com/gowool/cms/cache"
+	"github.com/gowool/cms/model"
+	"github.com/gowool/cms/repository/memory"
+)
+
+const (
+	testRPID     = "localhost"
+	testRPOrigin = "http://localhost"
+)
+
+// softAuthenticator is a software security key with a single ES256 credential.
+type softAuthenticator struct {
+	id        []byte
+	key       *ecdsa.PrivateKey
+	signCount uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return &softAuthenticator{id: id, key: key}
+}
+
+func (a *softAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+func (a *softAuthenticator) clientData(t *testing.T, typ string, challenge protocol.URLEncodedBase64) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]string{
+		"type":      typ,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    testRPOrigin,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func (a *softAuthenticator) create(t *testing.T, creation *protocol.CredentialCreation) []byte {
+	t.Helper()
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attested := make([]byte, 16) // aaguid
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, publicKey...)
+
+	attestation, err := cbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(0x45, attested), // user present, user verified, attested credential data
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a.response(t, map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData(t, "webauthn.create", creation.Response.Challenge)),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+	})
+}
+
+func (a *softAuthenticator) get(t *testing.T, assertion *protocol.CredentialAssertion) []byte {
+	t.Helper()
+
+	a.signCount++
+	authData := a.authData(0x05, nil) // user present, user verified
+	clientData := a.clientData(t, "webauthn.get", assertion.Response.Challenge)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a.response(t, map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+func (a *softAuthenticator) response(t *testing.T, response map[string]string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]any{
+		"id":       base64.RawURLEncoding.EncodeToString(a.id),
+		"rawId":    base64.RawURLEncoding.EncodeToString(a.id),
+		"type":     "public-key",
+		"response": response,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func newWebAuthnService(t *testing.T) *cms.WebAuthnService {
+	t.Helper()
+
+	service, err := cms.NewWebAuthnService(&webauthn.Config{
+		RPID:          testRPID,
+		RPDisplayName: "cms",
+		RPOrigins:     []string{testRPOrigin},
+	}, memory.NewWebAuthnCredentialRepository(), cache.NewLRU(100, 0, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+func TestWebAuthnService_SoftwareAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	service := newWebAuthnService(t)
+	admin := model.Admin{ID: 1, Email: "admin@example.com"}
+	authenticator := newSoftAuthenticator(t)
+
+	creation, err := service.BeginRegistration(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := service.FinishRegistration(ctx, admin, "key", authenticator.create(t, creation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credential.CredentialID != base64.RawURLEncoding.EncodeToString(authenticator.id) {
+		t.Fatalf("got credential %q", credential.CredentialID)
+	}
+
+	assertion, err := service.BeginLogin(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := authenticator.get(t, assertion)
+	if _, err = service.FinishLogin(ctx, admin, response); err != nil {
+		t.Fatal(err)
+	}
+
+	// the ceremony state is taken out of the cache, the assertion cannot be replayed
+	if _, err = service.FinishLogin(ctx, admin, response); !errors.Is(err, cms.ErrWebAuthnCeremony) {
+		t.Fatalf("got %v, want %v", err, cms.ErrWebAuthnCeremony)
+	}
+
+	// a counter going back reveals a cloned key
+	authenticator.signCount = 0
+	if assertion, err = service.BeginLogin(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = service.FinishLogin(ctx, admin, authenticator.get(t, assertion)); !errors.Is(err, cms.ErrWebAuthnCloned) {
+		t.Fatalf("got %v, want %v", err, cms.ErrWebAuthnCloned)
+	}
+}
+
+func TestWebAuthnService_ForeignAssertion(t *testing.T) {
+	ctx := context.Background()
+	service := newWebAuthnService(t)
+	admin := model.Admin{ID: 1, Email: "admin@example.com"}
+
+	creation, err := service.BeginRegistration(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = service.FinishRegistration(ctx, admin, "key", newSoftAuthenticator(t).create(t, creation)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertion, err := service.BeginLogin(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = service.FinishLogin(ctx, admin, newSoftAuthenticator(t).get(t, assertion)); err == nil {
+		t.Fatal("the assertion of an unknown key is accepted")
+	}
+}
+
+func TestWebAuthnService_ParallelFinish(t *testing.T) {
+	ctx := context.Background()
+	service := newWebAuthnService(t)
+	admin := model.Admin{ID: 1, Email: "admin@example.com"}
+	authenticator := newSoftAuthenticator(t)
+
+	creation, err := service.BeginRegistration(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = service.FinishRegistration(ctx, admin, "key", authenticator.create(t, creation)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertion, err := service.BeginLogin(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := authenticator.get(t, assertion)
+
+	var (
+		wg     sync.WaitGroup
+		logins atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch _, err := service.FinishLogin(ctx, admin, response); {
+			case err == nil:
+				logins.Add(1)
+			case !errors.Is(err, cms.ErrWebAuthnCeremony) && !errors.Is(err, cms.ErrWebAuthnCloned):
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := logins.Load(); n != 1 {
+		t.Fatalf("the assertion is accepted %d times", n)
+	}
+}
+
+func TestWebAuthnCredentialRepository_UseGrowingSignCount(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewWebAuthnCredentialRepository()
+
+	m := model.WebAuthnCredential{AdminID: 1, CredentialID: "id", SignCount: 5}
+	if err := repo.Create(ctx, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		signCount uint32
+		want      bool
+	}{{6, true}, {6, false}, {3, false}, {7, true}} {
+		if used, err := repo.Use(ctx, m.ID, tt.signCount, false, time.Now()); err != nil || used != tt.want {
+			t.Fatalf("use with %d = %t, %v, want %t", tt.signCount, used, err, tt.want)
+		}
+	}
+}